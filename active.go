@@ -0,0 +1,57 @@
+package avail
+
+import "time"
+
+// WithActiveBetween bounds the Timeframe to the half-open period [start, end), outside of which
+// it is never able. Ex. "0 9 * * 5 *" active between the start of July and the start of
+// October 2025 occurs every Friday in Q3 2025 and nowhere else. Able, Next, Prev, Windows,
+// Count and everything built on them respect the period; Next returns ErrNoOccurrence once it
+// has ended. A zero start or end leaves that side of the period unbounded.
+func WithActiveBetween(start, end time.Time) Option {
+	return func(o *options) {
+		o.activeStart, o.activeEnd = start, end
+	}
+}
+
+// bounded reports whether the options limit the period the Timeframe is active in.
+func (o options) bounded() bool {
+	return !o.activeStart.IsZero() || !o.activeEnd.IsZero()
+}
+
+// active reports whether the time given is within the period the Timeframe is active in.
+func (o options) active(t time.Time) bool {
+	if !o.activeStart.IsZero() && t.Before(o.activeStart) {
+		return false
+	}
+	return o.activeEnd.IsZero() || t.Before(o.activeEnd)
+}
+
+// clampActive returns the part of [start, end) within the period the Timeframe is active in,
+// which may be empty.
+func (o options) clampActive(start, end time.Time) (time.Time, time.Time) {
+	if !o.activeStart.IsZero() && start.Before(o.activeStart) {
+		start = o.activeStart.In(start.Location())
+	}
+	if !o.activeEnd.IsZero() && end.After(o.activeEnd) {
+		end = o.activeEnd.In(end.Location())
+	}
+	return start, end
+}
+
+// sameActive reports whether both options are active in the same period.
+func (o options) sameActive(other options) bool {
+	return o.activeStart.Equal(other.activeStart) && o.activeEnd.Equal(other.activeEnd)
+}
+
+// intersectActive returns the period both options are active in, as the start and end to
+// give WithActiveBetween.
+func (o options) intersectActive(other options) (time.Time, time.Time) {
+	start, end := o.activeStart, o.activeEnd
+	if start.IsZero() || other.activeStart.After(start) {
+		start = other.activeStart
+	}
+	if end.IsZero() || (!other.activeEnd.IsZero() && other.activeEnd.Before(end)) {
+		end = other.activeEnd
+	}
+	return start, end
+}
@@ -0,0 +1,177 @@
+package avail
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestActiveBetween(t *testing.T) {
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	fridays, err := New("0 9 * * 5 *", WithActiveBetween(start, end))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ableTests := map[string]struct {
+		time time.Time
+		want bool
+	}{
+		"before":       {time.Date(2025, 6, 27, 9, 0, 0, 0, time.UTC), false},
+		"first friday": {time.Date(2025, 7, 4, 9, 0, 0, 0, time.UTC), true},
+		"last friday":  {time.Date(2025, 9, 26, 9, 0, 0, 0, time.UTC), true},
+		"after":        {time.Date(2025, 10, 3, 9, 0, 0, 0, time.UTC), false},
+	}
+
+	for name, tc := range ableTests {
+		t.Run(name, func(t *testing.T) {
+			if got := fridays.Able(tc.time); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+
+	next, err := fridays.Next(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2025, 7, 4, 9, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("want %s, got %s", want, next)
+	}
+
+	if _, err := fridays.Next(time.Date(2025, 9, 26, 9, 0, 0, 0, time.UTC)); !errors.Is(err, ErrNoOccurrence) {
+		t.Errorf("want %v, got %v", ErrNoOccurrence, err)
+	}
+
+	prev, err := fridays.Prev(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2025, 9, 26, 9, 0, 0, 0, time.UTC); !prev.Equal(want) {
+		t.Errorf("want %s, got %s", want, prev)
+	}
+
+	if _, err := fridays.Prev(time.Date(2025, 7, 4, 9, 0, 0, 0, time.UTC)); !errors.Is(err, ErrNoOccurrence) {
+		t.Errorf("want %v, got %v", ErrNoOccurrence, err)
+	}
+
+	if got := len(fridays.NextN(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 100)); got != 13 {
+		t.Errorf("want %d, got %d", 13, got)
+	}
+
+	if got := fridays.Count(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)); got != 13 {
+		t.Errorf("want %d, got %d", 13, got)
+	}
+}
+
+func TestActiveBetweenWindows(t *testing.T) {
+	start := time.Date(2020, 6, 8, 9, 30, 0, 0, time.UTC)
+	end := time.Date(2020, 6, 9, 10, 0, 0, 0, time.UTC)
+
+	timeframe, err := New("* 9-11 * * * *", WithActiveBetween(start, end))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Window{
+		{Start: start, End: time.Date(2020, 6, 8, 12, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2020, 6, 9, 9, 0, 0, 0, time.UTC), End: end},
+	}
+	diff := cmp.Diff(want, timeframe.Windows(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 6, 30, 0, 0, 0, 0, time.UTC)))
+	if diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+
+	window, ok := timeframe.NextWindow(time.Date(2020, 6, 9, 8, 0, 0, 0, time.UTC))
+	if !ok {
+		t.Fatal("want a window")
+	}
+	if diff := cmp.Diff(want[1], window); diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+}
+
+func TestActiveBetweenUnbounded(t *testing.T) {
+	end := time.Date(2020, 6, 9, 0, 0, 0, 0, time.UTC)
+
+	timeframe, err := New("0 9 * * * *", WithActiveBetween(time.Time{}, end))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !timeframe.Able(time.Date(1990, 1, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("want %t, got %t", true, false)
+	}
+	if timeframe.Able(time.Date(2020, 6, 9, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("want %t, got %t", false, true)
+	}
+}
+
+func TestActiveBetweenCombined(t *testing.T) {
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	august := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	september := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	early, err := New("0 9 * * * *", WithActiveBetween(july, september))
+	if err != nil {
+		t.Fatal(err)
+	}
+	late, err := New("0 9 * * 1-5 *", WithActiveBetween(august, time.Time{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	intersection := early.Intersect(late)
+	tests := map[string]struct {
+		time time.Time
+		want bool
+	}{
+		"only early":   {time.Date(2025, 7, 7, 9, 0, 0, 0, time.UTC), false},
+		"both weekday": {time.Date(2025, 8, 4, 9, 0, 0, 0, time.UTC), true},
+		"both weekend": {time.Date(2025, 8, 2, 9, 0, 0, 0, time.UTC), false},
+		"only late":    {time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := intersection.Able(tc.time); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+
+	unbounded := MustNew("0 9 * * * *")
+	if early.Equal(unbounded) {
+		t.Errorf("want %t, got %t", false, true)
+	}
+	if !early.Equal(MustNew("0 9 * * * *", WithActiveBetween(july, september))) {
+		t.Errorf("want %t, got %t", true, false)
+	}
+	if early.Contains(unbounded) {
+		t.Errorf("want %t, got %t", false, true)
+	}
+}
+
+func TestActiveBetweenInvalid(t *testing.T) {
+	start := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		end time.Time
+	}{
+		"equal":    {start},
+		"reversed": {start.AddDate(0, 0, -1)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New("0 9 * * * *", WithActiveBetween(start, tc.end))
+			if err == nil {
+				t.Errorf("active period ending %s should not be accepted", tc.end)
+			}
+		})
+	}
+}
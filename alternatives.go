@@ -0,0 +1,62 @@
+package avail
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// alternativeSeparator separates the alternatives of a combined expression.
+// Ex. "0 9 * * 1-5 * || 0 10 * * 6,0 *".
+const alternativeSeparator = "||"
+
+// isCombinedExpression reports whether the expression is made up of several alternatives.
+func isCombinedExpression(expression string) bool {
+	return strings.Contains(expression, alternativeSeparator)
+}
+
+// parseAlternatives parses each alternative of a combined expression using the same options.
+// Alternatives which can never match are kept, as the expression is only impossible if all of
+// them are.
+func parseAlternatives(schedule string, opts []Option) ([]Timeframe, error) {
+	alternatives := []Timeframe{}
+	for i, alternative := range strings.Split(schedule, alternativeSeparator) {
+		offset := alternativeOffset(schedule, i)
+		alternative = strings.TrimSpace(alternative)
+		if alternative == "" {
+			return nil, fmt.Errorf("alternatives cannot be empty")
+		}
+
+		timeframe, err := parse(alternative, opts)
+		if err != nil {
+			return nil, shiftPosition(err, offset)
+		}
+		alternatives = append(alternatives, timeframe)
+	}
+
+	return alternatives, nil
+}
+
+// alternativeOffset returns the byte offset within the schedule at which the text of the
+// alternative at index begins, ignoring leading whitespace.
+func alternativeOffset(schedule string, index int) int {
+	offset := 0
+	for i, alternative := range strings.Split(schedule, alternativeSeparator) {
+		if i == index {
+			return offset + len(alternative) - len(strings.TrimLeftFunc(alternative, unicode.IsSpace))
+		}
+		offset += len(alternative) + len(alternativeSeparator)
+	}
+	return offset
+}
+
+// matchesAny reports whether any of the alternatives is able at the time given.
+func matchesAny(alternatives []Timeframe, t time.Time) bool {
+	for i := range alternatives {
+		if alternatives[i].Able(t) {
+			return true
+		}
+	}
+	return false
+}
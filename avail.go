@@ -2,28 +2,39 @@ package avail
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 	"time"
 )
 
-// fieldType is an enum which represents different parts of a total cron expression.
+// FieldType is an enum which represents different parts of a total cron expression.
 // For example in the expression "0 10 15 * * *", 0 would be of type "minute".
-type fieldType string
+type FieldType string
 
 const (
-	minute  fieldType = "minute"
-	hour    fieldType = "hour"
-	day     fieldType = "day"
-	month   fieldType = "month"
-	weekday fieldType = "weekday"
-	year    fieldType = "year"
+	second  FieldType = "second"
+	minute  FieldType = "minute"
+	hour    FieldType = "hour"
+	day     FieldType = "day"
+	month   FieldType = "month"
+	weekday FieldType = "weekday"
+	year    FieldType = "year"
 )
 
-var cronExpressionRegex = regexp.MustCompile(`^((((\d+,)+\d+|(\d+(-)\d+)|\d+|\*) ?){6})$`)
+// The types of each field, as used by the Kind of a Field and by NewFromSets.
+const (
+	SecondField  = second
+	MinuteField  = minute
+	HourField    = hour
+	DayField     = day
+	MonthField   = month
+	WeekdayField = weekday
+	YearField    = year
+)
 
 // ParsedExpression represents a breakdown of a given cron time expression
 type ParsedExpression struct {
+	// Seconds is only populated when the expression was parsed using WithSeconds.
+	Seconds  Field
 	Minutes  Field
 	Hours    Field
 	Days     Field
@@ -37,72 +48,374 @@ type ParsedExpression struct {
 type Timeframe struct {
 	Expression       string // * * * * * * 6 fields - min, hours, day of month, month, day of week, year
 	ParsedExpression ParsedExpression
+	// Interval is only set for "@every <duration>" expressions. When set the Timeframe matches
+	// every time that is a whole multiple of Interval since the unix epoch and
+	// ParsedExpression is left empty.
+	Interval time.Duration
+	// Location is set by a leading "CRON_TZ=" or "TZ=" prefix. When set Able evaluates the times
+	// it is given in this location regardless of the location they were created in.
+	Location *time.Location
+
+	options options
+	// alternatives are set for combined expressions; the Timeframe matches whenever any of
+	// them does.
+	alternatives []Timeframe
+	// exclusions are Timeframes carved out of this one with Except.
+	exclusions []Timeframe
 }
 
 // New will parse the given cron expression and allow user to check if the time given is within
-func New(expression string) (Timeframe, error) {
-	isMatch := cronExpressionRegex.MatchString(expression)
-	if !isMatch {
-		return Timeframe{}, fmt.Errorf("could not parse cron expression: %s; mis-formatted expression", expression)
+//
+// Predefined macros like "@daily" are also accepted and are expanded before parsing. The
+// returned Timeframe retains the original macro as its Expression.
+//
+// Interval expressions in the form of "@every <duration>" (ex. "@every 1h30m") are accepted
+// and match every time that is a whole multiple of the duration since the unix epoch.
+//
+// Any of the above may be prefixed with a timezone in the form of "CRON_TZ=<zone>" or
+// "TZ=<zone>" (ex. "CRON_TZ=America/New_York 0 9 * * * *"), in which case Able evaluates times
+// in that zone.
+//
+// Terms may be separated by any amount of whitespace and leading or trailing whitespace is
+// ignored. Months (JAN-DEC) and weekdays (SUN-SAT) may be given by name, and names, macros and
+// prefixes are all case-insensitive.
+//
+// Several expressions may be combined into one by separating them with "||"
+// (ex. "0 9 * * 1-5 * || 0 10 * * 6,0 *"), in which case Able is true whenever any of them
+// matches. A leading timezone prefix applies to every alternative.
+//
+// How the expression is parsed can be changed with options. Ex. WithDialect for other cron
+// syntaxes, WithSeconds for a leading seconds term, WithYearRange for the years allowed,
+// WithHashKey for "H" terms and WithRejectImpossible for expressions which can never match.
+func New(expression string, opts ...Option) (Timeframe, error) {
+	timeframe, err := parse(expression, opts)
+	if err != nil {
+		return Timeframe{}, err
 	}
 
-	terms := strings.Split(expression, " ")
-	// we need this extra check to make sure there are the proper amount of fields because I am bad at regex
-	if len(terms) != 6 {
-		return Timeframe{}, fmt.Errorf("could not parse cron expression: %s; must have 6 terms", expression)
+	if timeframe.options.rejectImpossible {
+		if err := timeframe.checkPossible(); err != nil {
+			return Timeframe{}, fmt.Errorf("could not parse cron expression: %s; %w", expression, err)
+		}
 	}
 
-	minutes, err := newField(minute, terms[0], 0, 59)
+	return timeframe, nil
+}
+
+// expressionKind is the form of the schedule an expression holds.
+type expressionKind int
+
+const (
+	nativeExpression expressionKind = iota
+	combinedExpression
+	dialectExpression
+	intervalExpression
+)
+
+// preparedExpression is an expression after the steps New and Validate share: its options are
+// applied, its location prefix is parsed and its form is checked. Only the sets of values its
+// terms match remain to be built or checked.
+type preparedExpression struct {
+	options  options
+	location *time.Location
+	schedule string
+	// offset is the byte offset of the schedule within the expression.
+	offset int
+	kind   expressionKind
+
+	// spec is the specification dialect expressions are parsed with, interval is the duration
+	// of interval expressions and terms are the terms of native expressions.
+	spec     dialectSpec
+	interval time.Duration
+	terms    []string
+}
+
+// prepareExpression runs the steps of parsing the expression which New and Validate share, so
+// that both accept the same expressions. Errors are returned in the form New returns them.
+func prepareExpression(expression string, opts []Option) (preparedExpression, error) {
+	options, err := newOptions(opts)
 	if err != nil {
-		return Timeframe{}, err
+		return preparedExpression{}, fmt.Errorf("could not parse cron expression: %s; %w", expression, err)
 	}
-	hours, err := newField(hour, terms[1], 0, 23)
+
+	location, schedule, err := parseLocationPrefix(strings.TrimSpace(expression), options.zoneFallback)
 	if err != nil {
-		return Timeframe{}, err
+		return preparedExpression{}, fmt.Errorf("could not parse cron expression: %s; %w", expression, err)
 	}
-	day, err := newField(day, terms[2], 1, 31)
-	if err != nil {
-		return Timeframe{}, err
+	if location == nil {
+		location = options.location
 	}
-	month, err := newField(month, terms[3], 1, 12)
-	if err != nil {
-		return Timeframe{}, err
+
+	prepared := preparedExpression{
+		options:  options,
+		location: location,
+		schedule: schedule,
+		offset:   scheduleOffset(expression, schedule),
 	}
-	weekday, err := newField(weekday, terms[4], 0, 6)
+
+	if isCombinedExpression(schedule) {
+		prepared.kind = combinedExpression
+		return prepared, nil
+	}
+
+	if spec, ok := dialects[options.dialect]; ok {
+		spec.minYear, spec.maxYear = options.yearBounds(spec.minYear, spec.maxYear)
+		prepared.kind, prepared.spec = dialectExpression, spec
+		return prepared, nil
+	}
+
+	if isIntervalExpression(schedule) {
+		interval, err := parseInterval(schedule, options.precision())
+		if err != nil {
+			return preparedExpression{}, fmt.Errorf("could not parse cron expression: %s; %w", expression, err)
+		}
+		if options.holidays != nil {
+			return preparedExpression{}, fmt.Errorf("could not parse cron expression: %s; holidays cannot be applied to intervals", expression)
+		}
+
+		prepared.kind, prepared.interval = intervalExpression, interval
+		return prepared, nil
+	}
+
+	terms, err := nativeTerms(schedule, options)
 	if err != nil {
-		return Timeframe{}, err
+		return preparedExpression{}, fmt.Errorf("could not parse cron expression: %s; %w", expression, err)
 	}
-	year, err := newField(year, terms[5], 1970, 2100)
+
+	prepared.kind, prepared.terms = nativeExpression, terms
+	return prepared, nil
+}
+
+// parse parses the expression as New does, without rejecting expressions which can never
+// match.
+func parse(expression string, opts []Option) (Timeframe, error) {
+	prepared, err := prepareExpression(expression, opts)
 	if err != nil {
 		return Timeframe{}, err
 	}
+	options, location, schedule := prepared.options, prepared.location, prepared.schedule
+
+	switch prepared.kind {
+	case combinedExpression:
+		// Alternatives inherit the location from the combined Timeframe rather than the option,
+		// so that a prefix takes precedence over it.
+		alternatives, err := parseAlternatives(schedule, append(append([]Option{}, opts...), WithLocation(nil)))
+		if err != nil {
+			err = shiftPosition(err, prepared.offset)
+			return Timeframe{}, fmt.Errorf("could not parse cron expression: %s; %w", expression, err)
+		}
+
+		return Timeframe{
+			Expression:   expression,
+			Location:     location,
+			options:      options,
+			alternatives: alternatives,
+		}, nil
+
+	case dialectExpression:
+		parsedExpression, err := parseDialect(prepared.spec, schedule)
+		if err != nil {
+			err = shiftPosition(err, prepared.offset)
+			return Timeframe{}, fmt.Errorf("could not parse cron expression: %s; %w", expression, err)
+		}
+
+		options.seconds = prepared.spec.seconds
+
+		return Timeframe{
+			Expression:       expression,
+			ParsedExpression: parsedExpression,
+			Location:         location,
+			options:          options,
+		}, nil
+
+	case intervalExpression:
+		return Timeframe{
+			Expression: expression,
+			Interval:   prepared.interval,
+			Location:   location,
+			options:    options,
+		}, nil
+	}
+
+	parsedExpression := ParsedExpression{}
+	targets := []*Field{
+		&parsedExpression.Seconds, &parsedExpression.Minutes, &parsedExpression.Hours, &parsedExpression.Days,
+		&parsedExpression.Months, &parsedExpression.Weekdays, &parsedExpression.Years,
+	}
+
+	fields := nativeFields
+	if !options.seconds {
+		fields, targets = fields[1:], targets[1:]
+	}
+
+	for i, bounds := range fields {
+		if bounds.kind == year {
+			bounds.min, bounds.max = options.yearBounds(bounds.min, bounds.max)
+		}
+		field, err := newField(bounds.kind, prepared.terms[i], bounds.min, bounds.max, options)
+		if err != nil {
+			return Timeframe{}, locateTerm(err, schedule, i, prepared.offset)
+		}
+		*targets[i] = field
+	}
 
 	return Timeframe{
-		Expression: expression,
-		ParsedExpression: ParsedExpression{
-			Minutes:  minutes,
-			Hours:    hours,
-			Days:     day,
-			Months:   month,
-			Weekdays: weekday,
-			Years:    year,
-		},
+		Expression:       expression,
+		ParsedExpression: parsedExpression,
+		Location:         location,
+		options:          options,
 	}, nil
 }
 
-// Able will evaluate if the time given is within the cron expression.
+// nativeFields are the kinds and bounds of the terms of a native expression, in order. The
+// seconds term only leads the expression when WithSeconds is given, and the bounds of the year
+// term are replaced by WithYearRange.
+var nativeFields = []struct {
+	kind     FieldType
+	min, max int
+}{
+	{second, 0, 59},
+	{minute, 0, 59},
+	{hour, 0, 23},
+	{day, 1, 31},
+	{month, 1, 12},
+	{weekday, 0, 6},
+	{year, 1970, 2100},
+}
+
+// nativeTerms expands any macro in the schedule and splits it into its terms, filling in an
+// omitted year when the options allow it.
+func nativeTerms(schedule string, options options) ([]string, error) {
+	parsable := expandMacro(schedule, options)
+
+	termCount := 6
+	if options.seconds {
+		termCount = 7
+	}
+
+	terms := strings.Fields(parsable)
+	if options.optionalYear && len(terms) == termCount-1 {
+		terms = append(terms, "*")
+	}
+
+	if len(terms) != termCount {
+		return nil, termCountError(len(terms), termCount, "must have %d terms", termCount)
+	}
+
+	return terms, nil
+}
+
+// MustNew is like New but panics if the expression cannot be parsed. It simplifies the
+// initialization of package level schedules and tests, where the expression is fixed by the
+// program. Other grammars are parsed by passing WithDialect.
+func MustNew(expression string, opts ...Option) Timeframe {
+	timeframe, err := New(expression, opts...)
+	if err != nil {
+		panic(fmt.Sprintf("avail: %v", err))
+	}
+	return timeframe
+}
+
+// Able will evaluate if the time given is within the cron expression and not within any of
+// the Timeframe's exclusions.
 func (a *Timeframe) Able(time time.Time) bool {
-	fieldTypes := []fieldType{
+	for _, exclusion := range a.exclusions {
+		if exclusion.Able(time) {
+			return false
+		}
+	}
+
+	return a.matches(time)
+}
+
+// AbleNow evaluates whether the Timeframe is able at the current time, as given by the clock
+// set with WithClock or time.Now otherwise. As with Able, the time is evaluated in the
+// Timeframe's location if it has one.
+func (a *Timeframe) AbleNow() bool {
+	return a.Able(a.Now())
+}
+
+// Now returns the current time, as given by the clock set with WithClock or time.Now
+// otherwise, in the Timeframe's location if it has one.
+func (a *Timeframe) Now() time.Time {
+	now := time.Now
+	if a.options.clock != nil {
+		now = a.options.clock
+	}
+
+	if a.Location != nil {
+		return now().In(a.Location)
+	}
+	return now()
+}
+
+// Precision returns the smallest step between the times the Timeframe can tell apart:
+// time.Second for expressions with seconds or parsed WithGranularity(time.Second), and
+// time.Minute otherwise.
+func (a *Timeframe) Precision() time.Duration {
+	return a.options.precision()
+}
+
+// Except returns a copy of the Timeframe which is not able whenever the given Timeframe is,
+// even if its own expression matches. Ex. "* * * * * *" except "* * 25 12 * *" is able at any
+// time other than Christmas day. Except may be called repeatedly to carve out several
+// exclusions; the Expression of the returned Timeframe is left unchanged.
+//
+// Except subtracts one Timeframe from another, complementing Intersect and Union, and Next,
+// Prev and Windows all skip the excluded times.
+func (a Timeframe) Except(other Timeframe) Timeframe {
+	exclusions := make([]Timeframe, 0, len(a.exclusions)+1)
+	exclusions = append(exclusions, a.exclusions...)
+	a.exclusions = append(exclusions, other)
+	return a
+}
+
+// matches evaluates if the time given is within the cron expression, without considering
+// exclusions.
+func (a *Timeframe) matches(time time.Time) bool {
+	if !a.options.active(time) {
+		return false
+	}
+
+	if a.Location != nil {
+		time = time.In(a.Location)
+	}
+
+	if a.alternatives != nil {
+		return matchesAny(a.alternatives, time)
+	}
+
+	if a.Interval != 0 {
+		return ableInterval(a.Interval, a.options.precision(), time)
+	}
+
+	if a.options.dstPolicy != DSTWallClock {
+		return a.matchesAcrossTransitions(time)
+	}
+	return a.matchesWallClock(time)
+}
+
+// matchesWallClock evaluates if the wall clock time of the time given, which must be in the
+// Timeframe's location, matches the plain expression.
+func (a *Timeframe) matchesWallClock(time time.Time) bool {
+	fieldTypes := []FieldType{
+		second,
 		minute,
 		hour,
 		day,
 		month,
-		weekday,
 		year,
 	}
 
 	for _, field := range fieldTypes {
 		switch field {
+		case second:
+			if !a.options.seconds {
+				continue
+			}
+			if _, ok := a.ParsedExpression.Seconds.Values[time.Second()]; !ok {
+				return false
+			}
 		case minute:
 			if _, ok := a.ParsedExpression.Minutes.Values[time.Minute()]; !ok {
 				return false
@@ -112,19 +425,16 @@ func (a *Timeframe) Able(time time.Time) bool {
 				return false
 			}
 		case day:
-			if _, ok := a.ParsedExpression.Days.Values[time.Day()]; !ok {
+			// The weekday is matched alongside the day, as either may satisfy WithDomDowOr.
+			if !a.matchesDay(time) {
 				return false
 			}
 		case month:
 			if _, ok := a.ParsedExpression.Months.Values[int(time.Month())]; !ok {
 				return false
 			}
-		case weekday:
-			if _, ok := a.ParsedExpression.Weekdays.Values[int(time.Weekday())]; !ok {
-				return false
-			}
 		case year:
-			if _, ok := a.ParsedExpression.Years.Values[time.Year()]; !ok {
+			if !a.ParsedExpression.Years.Has(time.Year()) {
 				return false
 			}
 		}
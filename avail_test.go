@@ -2,6 +2,7 @@ package avail
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -24,6 +25,12 @@ func TestParseable(t *testing.T) {
 		"range + single value": {
 			expression: "* * * 6,7,8 * 2020",
 		},
+		"macro": {
+			expression: "@daily",
+		},
+		"interval": {
+			expression: "@every 1h30m",
+		},
 	}
 
 	for name, tc := range tests {
@@ -58,6 +65,30 @@ func TestUnparseable(t *testing.T) {
 		"out of bounds list": {
 			expression: "* 1,40,100 * * * *",
 		},
+		"unknown macro": {
+			expression: "@fortnightly",
+		},
+		"unknown name": {
+			expression: "* * * * mon-fry *",
+		},
+		"name in wrong field": {
+			expression: "* * * mon * *",
+		},
+		"last in wrong field": {
+			expression: "* L * * * *",
+		},
+		"last offset too large": {
+			expression: "* * L-28 * * *",
+		},
+		"interval less than a minute": {
+			expression: "@every 30s",
+		},
+		"interval not whole minutes": {
+			expression: "@every 90s",
+		},
+		"interval unparseable duration": {
+			expression: "@every often",
+		},
 	}
 
 	for name, tc := range tests {
@@ -131,7 +162,7 @@ func TestNew(t *testing.T) {
 				t.Error(err)
 			}
 
-			diff := cmp.Diff(tc.want, got)
+			diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(Timeframe{}, options{}, Field{}))
 			if diff != "" {
 				t.Errorf("result is different than expected(-want +got):\n%s", diff)
 			}
@@ -139,6 +170,617 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestMustNew(t *testing.T) {
+	timeframe := MustNew("0 0 9 ? * MON-FRI", WithDialect(Quartz))
+	if !timeframe.Able(time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("want %t, got %t", true, false)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("invalid expression should panic")
+		}
+	}()
+
+	MustNew("0 9 * *")
+}
+
+func TestMacros(t *testing.T) {
+	tests := map[string]struct {
+		macro string
+		time  time.Time
+		want  bool
+	}{
+		"yearly":              {"@yearly", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		"yearly wrong month":  {"@yearly", time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), false},
+		"monthly":             {"@monthly", time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), true},
+		"weekly":              {"@weekly", time.Date(2020, 6, 7, 0, 0, 0, 0, time.UTC), true},
+		"weekly wrong day":    {"@weekly", time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC), false},
+		"daily":               {"@daily", time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC), true},
+		"daily wrong minute":  {"@daily", time.Date(2020, 6, 8, 0, 1, 0, 0, time.UTC), false},
+		"hourly":              {"@hourly", time.Date(2020, 6, 8, 13, 0, 0, 0, time.UTC), true},
+		"hourly wrong minute": {"@hourly", time.Date(2020, 6, 8, 13, 30, 0, 0, time.UTC), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.macro)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if avail.Expression != tc.macro {
+				t.Errorf("expression should be retained as %s; got %s", tc.macro, avail.Expression)
+			}
+
+			if avail.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+}
+
+func TestInterval(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		time       time.Time
+		want       bool
+	}{
+		"on the quarter hour":       {"@every 15m", time.Date(2020, 6, 8, 13, 45, 0, 0, time.UTC), true},
+		"off the quarter hour":      {"@every 15m", time.Date(2020, 6, 8, 13, 46, 0, 0, time.UTC), false},
+		"seconds are ignored":       {"@every 15m", time.Date(2020, 6, 8, 13, 45, 59, 0, time.UTC), true},
+		"spans multiple hours":      {"@every 2h30m", time.Date(1970, 1, 1, 5, 0, 0, 0, time.UTC), true},
+		"spans multiple hours miss": {"@every 2h30m", time.Date(1970, 1, 1, 6, 0, 0, 0, time.UTC), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if avail.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+}
+
+func TestSeconds(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		time       time.Time
+		want       bool
+	}{
+		"wildcard":              {"* * * * * * *", time.Now(), true},
+		"specific second":       {"30 0 12 * * * *", time.Date(2020, 6, 8, 12, 0, 30, 0, time.UTC), true},
+		"specific second miss":  {"30 0 12 * * * *", time.Date(2020, 6, 8, 12, 0, 31, 0, time.UTC), false},
+		"second range":          {"0-14 * * * * * *", time.Date(2020, 6, 8, 12, 0, 14, 0, time.UTC), true},
+		"macro at second zero":  {"@hourly", time.Date(2020, 6, 8, 12, 0, 0, 0, time.UTC), true},
+		"macro past second one": {"@hourly", time.Date(2020, 6, 8, 12, 0, 1, 0, time.UTC), false},
+		"interval in seconds":   {"@every 30s", time.Date(2020, 6, 8, 12, 0, 30, 0, time.UTC), true},
+		"interval in seconds miss": {
+			"@every 30s", time.Date(2020, 6, 8, 12, 0, 31, 0, time.UTC), false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression, WithSeconds())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if avail.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+}
+
+func TestSecondsUnparseable(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+	}{
+		"missing seconds term":    {"* * * * * *"},
+		"out of bounds seconds":   {"60 * * * * * *"},
+		"too many arguments":      {"* * * * * * * *"},
+		"sub-second interval":     {"@every 500ms"},
+		"non whole second period": {"@every 1500ms"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(tc.expression, WithSeconds())
+			if err == nil {
+				t.Errorf("expression %s should not be parsed successfully", tc.expression)
+			}
+		})
+	}
+}
+
+func TestGranularity(t *testing.T) {
+	after := time.Date(2020, 6, 8, 8, 59, 58, 0, time.UTC)
+
+	timeframe, err := New("0 9 * * * *", WithGranularity(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !timeframe.Able(time.Date(2020, 6, 8, 9, 0, 59, 0, time.UTC)) {
+		t.Errorf("want %t, got %t", true, false)
+	}
+	if timeframe.Able(time.Date(2020, 6, 8, 9, 1, 0, 0, time.UTC)) {
+		t.Errorf("want %t, got %t", false, true)
+	}
+
+	want := []time.Time{
+		time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC),
+		time.Date(2020, 6, 8, 9, 0, 1, 0, time.UTC),
+		time.Date(2020, 6, 8, 9, 0, 2, 0, time.UTC),
+	}
+	diff := cmp.Diff(want, timeframe.NextN(after, 3))
+	if diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+
+	start := time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC)
+	if count := timeframe.Count(start, start.AddDate(0, 0, 1)); count != 60 {
+		t.Errorf("want %d, got %d", 60, count)
+	}
+
+	interval, err := New("@every 30s", WithGranularity(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !interval.Able(time.Date(2020, 6, 8, 9, 0, 30, 0, time.UTC)) {
+		t.Errorf("want %t, got %t", true, false)
+	}
+}
+
+func TestGranularityInvalid(t *testing.T) {
+	tests := map[string]struct {
+		granularity time.Duration
+	}{
+		"hour":        {time.Hour},
+		"millisecond": {time.Millisecond},
+		"negative":    {-time.Second},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New("0 9 * * * *", WithGranularity(tc.granularity))
+			if err == nil {
+				t.Errorf("granularity %s should not be accepted", tc.granularity)
+			}
+		})
+	}
+}
+
+func TestOptionalYear(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+		time       time.Time
+		want       bool
+	}{
+		"crontab expression": {
+			"0 9 * * 1-5", []Option{WithOptionalYear()},
+			time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), true,
+		},
+		"crontab expression miss": {
+			"0 9 * * 1-5", []Option{WithOptionalYear()},
+			time.Date(2020, 6, 7, 9, 0, 0, 0, time.UTC), false,
+		},
+		"year still accepted": {
+			"0 9 * * 1-5 2021", []Option{WithOptionalYear()},
+			time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), false,
+		},
+		"with seconds": {
+			"15 0 9 * * 1-5", []Option{WithOptionalYear(), WithSeconds()},
+			time.Date(2020, 6, 8, 9, 0, 15, 0, time.UTC), true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if avail.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+}
+
+func TestYearRange(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+		after      time.Time
+		want       time.Time
+	}{
+		"past 2100": {
+			"0 0 1 1 * 2150", []Option{WithYearRange(1970, 2500)},
+			time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2150, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"wildcard past 2100": {
+			"0 0 1 1 * *", []Option{WithYearRange(1970, 2500)},
+			time.Date(2200, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2201, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"narrow": {
+			"0 9 * * * *", []Option{WithYearRange(2020, 2030)},
+			time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC),
+		},
+		"whole range": {
+			"0 0 1 1 * *", []Option{WithYearRange(1, 9999)},
+			time.Date(9000, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(9001, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"quartz": {
+			"0 0 0 1 1 ? 2300", []Option{WithDialect(Quartz), WithYearRange(1970, 2500)},
+			time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := Validate(tc.expression, tc.opts...); err != nil {
+				t.Fatal(err)
+			}
+
+			avail, err := New(tc.expression, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			next, err := avail.Next(tc.after)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !next.Equal(tc.want) {
+				t.Errorf("want %s, got %s", tc.want, next)
+			}
+
+			normalized, err := avail.Normalize()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := New(normalized, tc.opts...); err != nil {
+				t.Errorf("normalized expression %s should parse with the same options: %v", normalized, err)
+			}
+		})
+	}
+
+	narrow := MustNew("0 9 * * * *", WithYearRange(2020, 2030))
+	if years := narrow.ParsedExpression.Years; years.Min != 2020 || years.Max != 2030 {
+		t.Errorf("want years 2020-2030, got %d-%d", years.Min, years.Max)
+	}
+	if _, err := narrow.Next(time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("want no occurrence after the range")
+	}
+
+	// Wildcard years are kept as their bounds rather than listed, however many there are.
+	wide := MustNew("0 9 * * * *", WithYearRange(1, 9999))
+	if got := len(wide.ParsedExpression.Years.Values); got != 0 {
+		t.Errorf("want %d, got %d", 0, got)
+	}
+	prev, err := wide.Prev(time.Date(2, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(1, 12, 31, 9, 0, 0, 0, time.UTC); !prev.Equal(want) {
+		t.Errorf("want %s, got %s", want, prev)
+	}
+}
+
+func TestYearRangeInvalid(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+	}{
+		"outside default":   {"0 0 1 1 * 2150", nil},
+		"outside narrow":    {"0 0 1 1 * 2019", []Option{WithYearRange(2020, 2030)}},
+		"outside in quartz": {"0 0 0 1 1 ? 2019", []Option{WithDialect(Quartz), WithYearRange(2020, 2030)}},
+		"descending range":  {"* * * * * *", []Option{WithYearRange(2030, 2020)}},
+		"zero minimum":      {"* * * * * *", []Option{WithYearRange(0, 2020)}},
+		"above 9999":        {"* * * * * *", []Option{WithYearRange(1970, 10000)}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := New(tc.expression, tc.opts...); err == nil {
+				t.Errorf("invalid expression should not be parsed successfully")
+			}
+			if err := Validate(tc.expression, tc.opts...); err == nil {
+				t.Errorf("invalid expression should not be validated successfully")
+			}
+		})
+	}
+}
+
+func TestDomDowOr(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		time       time.Time
+		want       bool
+	}{
+		"day only":             {"0 0 1,15 * 1 *", time.Date(2021, 6, 15, 0, 0, 0, 0, time.UTC), true},
+		"weekday only":         {"0 0 1,15 * 1 *", time.Date(2021, 6, 7, 0, 0, 0, 0, time.UTC), true},
+		"neither":              {"0 0 1,15 * 1 *", time.Date(2021, 6, 8, 0, 0, 0, 0, time.UTC), false},
+		"wrong hour":           {"0 0 1,15 * 1 *", time.Date(2021, 6, 7, 1, 0, 0, 0, time.UTC), false},
+		"wrong month":          {"0 0 1,15 6 1 *", time.Date(2021, 7, 5, 0, 0, 0, 0, time.UTC), false},
+		"wildcard weekday":     {"0 0 15 * * *", time.Date(2021, 6, 7, 0, 0, 0, 0, time.UTC), false},
+		"wildcard day":         {"0 0 * * 1 *", time.Date(2021, 6, 15, 0, 0, 0, 0, time.UTC), false},
+		"relative day":         {"0 0 L * 1 *", time.Date(2021, 6, 30, 0, 0, 0, 0, time.UTC), true},
+		"crontab dialect":      {"0 0 1 * 5", time.Date(2021, 6, 4, 0, 0, 0, 0, time.UTC), true},
+		"crontab dialect miss": {"0 0 1 * 5", time.Date(2021, 6, 5, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := []Option{WithDomDowOr()}
+			if len(strings.Fields(tc.expression)) == 5 {
+				opts = append(opts, WithDialect(Crontab))
+			}
+			avail, err := New(tc.expression, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := avail.Able(tc.time); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDomDowOrOccurrences(t *testing.T) {
+	avail, err := New("0 0 1,15 * 1 *", WithDomDowOr())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2021, 5, 30, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	want := []time.Time{}
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if avail.Able(day) {
+			want = append(want, day)
+		}
+	}
+	if len(want) != 13 {
+		t.Fatalf("want 13 occurrences, got %d", len(want))
+	}
+
+	got := avail.NextN(start.Add(-time.Minute), len(want))
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+
+	if prev, err := avail.Prev(end); err != nil || !prev.Equal(want[len(want)-1]) {
+		t.Errorf("want %s, got %s (%v)", want[len(want)-1], prev, err)
+	}
+
+	if got := avail.Count(start, end); got != len(want) {
+		t.Errorf("want %d, got %d", len(want), got)
+	}
+
+	and := MustNew("0 0 1,15 * 1 *")
+	if avail.Equal(and) {
+		t.Errorf("expressions matching either day term should not equal those matching both")
+	}
+	if !avail.Contains(and) || and.Contains(avail) {
+		t.Errorf("expressions matching either day term should contain those matching both")
+	}
+}
+
+func TestWhitespaceAndCase(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		time       time.Time
+		want       bool
+	}{
+		"repeated spaces":     {"0  9 *   * * *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), true},
+		"tabs":                {"0\t9\t*\t*\t*\t*", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), true},
+		"surrounding spaces":  {"  0 9 * * * *\n", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), true},
+		"named month":         {"0 9 * jun * *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), true},
+		"named month miss":    {"0 9 * Jul * *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), false},
+		"named weekday range": {"0 9 * * MON-fri *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), true},
+		"named weekday list":  {"0 9 * * sat,Sun *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), false},
+		"uppercase macro":     {"@DAILY", time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC), true},
+		"mixed case interval": {"@Every  15m", time.Date(2020, 6, 8, 0, 15, 0, 0, time.UTC), true},
+		"lowercase prefix": {
+			"cron_tz=America/New_York\t0 9 * * * *",
+			time.Date(2020, 6, 8, 13, 0, 0, 0, time.UTC), true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if avail.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+}
+
+func TestWeekdayKeywords(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		time       time.Time
+		want       bool
+	}{
+		"weekdays on monday":     {"0 9 * * weekdays *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), true},
+		"weekdays on sunday":     {"0 9 * * weekdays *", time.Date(2020, 6, 7, 9, 0, 0, 0, time.UTC), false},
+		"weekends on sunday":     {"0 9 * * Weekends *", time.Date(2020, 6, 7, 9, 0, 0, 0, time.UTC), true},
+		"weekends on monday":     {"0 9 * * WEEKENDS *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), false},
+		"singular keyword":       {"0 9 * * weekend *", time.Date(2020, 6, 6, 9, 0, 0, 0, time.UTC), true},
+		"keyword in a list":      {"0 9 * * weekends,wed *", time.Date(2020, 6, 10, 9, 0, 0, 0, time.UTC), true},
+		"keyword in a list miss": {"0 9 * * weekends,wed *", time.Date(2020, 6, 9, 9, 0, 0, 0, time.UTC), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if avail.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+
+	if _, err := New("0 9 weekdays * * *"); err == nil {
+		t.Error("keywords should only be accepted in the weekday field")
+	}
+}
+
+func TestLastDay(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		time       time.Time
+		want       bool
+	}{
+		"last day":                {"0 0 L * * *", time.Date(2020, 6, 30, 0, 0, 0, 0, time.UTC), true},
+		"last day miss":           {"0 0 L * * *", time.Date(2020, 6, 29, 0, 0, 0, 0, time.UTC), false},
+		"last day of february":    {"0 0 L * * *", time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC), true},
+		"lowercase last day":      {"0 0 l * * *", time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC), true},
+		"offset from last day":    {"0 0 L-3 * * *", time.Date(2020, 7, 28, 0, 0, 0, 0, time.UTC), true},
+		"offset in short month":   {"0 0 L-3 * * *", time.Date(2021, 2, 25, 0, 0, 0, 0, time.UTC), true},
+		"offset miss":             {"0 0 L-3 * * *", time.Date(2020, 7, 27, 0, 0, 0, 0, time.UTC), false},
+		"offset with other terms": {"0 0 L-1 12 * *", time.Date(2020, 12, 30, 0, 0, 0, 0, time.UTC), true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if avail.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+}
+
+func TestLastWeekday(t *testing.T) {
+	tests := map[string]struct {
+		time time.Time
+		want bool
+	}{
+		"month ending on a weekday":       {time.Date(2020, 6, 30, 17, 0, 0, 0, time.UTC), true},
+		"month ending on a saturday":      {time.Date(2020, 10, 30, 17, 0, 0, 0, time.UTC), true},
+		"month ending on a sunday":        {time.Date(2020, 5, 29, 17, 0, 0, 0, time.UTC), true},
+		"weekend at the end of the month": {time.Date(2020, 5, 31, 17, 0, 0, 0, time.UTC), false},
+		"earlier weekday":                 {time.Date(2020, 6, 29, 17, 0, 0, 0, time.UTC), false},
+	}
+
+	avail, err := New("0 17 lw * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if avail.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+}
+
+func TestExcept(t *testing.T) {
+	base, err := New("* 9-16 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lunch, err := New("* 12 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	christmas, err := New("* * 25 12 * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	avail := base.Except(lunch).Except(christmas)
+
+	tests := map[string]struct {
+		time time.Time
+		want bool
+	}{
+		"base matches":          {time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC), true},
+		"base does not match":   {time.Date(2020, 6, 8, 18, 0, 0, 0, time.UTC), false},
+		"first exclusion":       {time.Date(2020, 6, 8, 12, 30, 0, 0, time.UTC), false},
+		"second exclusion":      {time.Date(2020, 12, 25, 10, 0, 0, 0, time.UTC), false},
+		"outside of exclusions": {time.Date(2020, 12, 24, 10, 0, 0, 0, time.UTC), true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if avail.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+
+	if !base.Able(time.Date(2020, 6, 8, 12, 30, 0, 0, time.UTC)) {
+		t.Error("except should not modify the original timeframe")
+	}
+}
+
+func TestCombined(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		time       time.Time
+		want       bool
+	}{
+		"first alternative":    {"0 9 * * 1-5 * || 0 10 * * 6,0 *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), true},
+		"second alternative":   {"0 9 * * 1-5 * || 0 10 * * 6,0 *", time.Date(2020, 6, 7, 10, 0, 0, 0, time.UTC), true},
+		"neither alternative":  {"0 9 * * 1-5 * || 0 10 * * 6,0 *", time.Date(2020, 6, 7, 9, 0, 0, 0, time.UTC), false},
+		"without whitespace":   {"0 9 * * 1-5 *||0 10 * * 6,0 *", time.Date(2020, 6, 7, 10, 0, 0, 0, time.UTC), true},
+		"macro alternative":    {"@daily || 0 12 * * * *", time.Date(2020, 6, 7, 0, 0, 0, 0, time.UTC), true},
+		"interval alternative": {"0 9 * * * * || @every 2h", time.Date(2020, 6, 7, 14, 0, 0, 0, time.UTC), true},
+		"shared timezone": {"CRON_TZ=America/New_York 0 9 * * * * || 0 17 * * * *",
+			time.Date(2020, 6, 7, 21, 0, 0, 0, time.UTC), true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if avail.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+
+	for _, expression := range []string{"0 9 * * * * ||", "|| 0 9 * * * *", "0 9 * * * * || 0 25 * * * *"} {
+		if _, err := New(expression); err == nil {
+			t.Errorf("expected error for expression %q", expression)
+		}
+	}
+}
+
 func TestParseWildcard(t *testing.T) {
 	want := Field{
 		Kind:   minute,
@@ -147,12 +789,12 @@ func TestParseWildcard(t *testing.T) {
 		Max:    59,
 		Values: generateSequentialSet(0, 59),
 	}
-	got, err := newField(minute, "*", 0, 59)
+	got, err := newField(minute, "*", 0, 59, options{})
 	if err != nil {
 		t.Error(err)
 	}
 
-	diff := cmp.Diff(want, got)
+	diff := cmp.Diff(want, got, cmp.AllowUnexported(Field{}))
 	if diff != "" {
 		t.Errorf("result is different than expected(-want +got):\n%s", diff)
 	}
@@ -166,12 +808,12 @@ func TestParseSpan(t *testing.T) {
 		Max:    23,
 		Values: generateSequentialSet(4, 14),
 	}
-	got, err := newField(hour, "4-14", 0, 23)
+	got, err := newField(hour, "4-14", 0, 23, options{})
 	if err != nil {
 		t.Error(err)
 	}
 
-	diff := cmp.Diff(want, got)
+	diff := cmp.Diff(want, got, cmp.AllowUnexported(Field{}))
 	if diff != "" {
 		t.Errorf("result is different than expected(-want +got):\n%s", diff)
 	}
@@ -226,3 +868,34 @@ func ExampleTimeframe_Able() {
 	fmt.Println(avail.Able(now))
 	// Output: true
 }
+
+func TestAbleNow(t *testing.T) {
+	now := time.Date(2020, 6, 8, 13, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	tests := map[string]struct {
+		expression string
+		want       bool
+	}{
+		"able":          {"* 13 * * * *", true},
+		"not able":      {"* 9 * * * *", false},
+		"with location": {"CRON_TZ=America/New_York * 9 * * * *", true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression, WithClock(clock))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if avail.AbleNow() != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+
+			if !avail.Now().Equal(now) {
+				t.Errorf("want %s, got %s", now, avail.Now())
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Package availgrpc provides gRPC server interceptors which only admit calls while an avail
+// Timeframe is able.
+//
+// Calls made outside of the Timeframe are either rejected with codes.Unavailable, carrying
+// the time the Timeframe next opens in the error details, or queued until it opens.
+package availgrpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/clintjedwards/avail/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ErrorReason is the reason set in the ErrorInfo detail of rejected calls.
+const ErrorReason = "OUTSIDE_AVAILABILITY_WINDOW"
+
+// NextOpenKey is the ErrorInfo metadata key containing the RFC 3339 formatted time the
+// Timeframe next opens.
+const NextOpenKey = "next_open"
+
+// Option configures the behavior of an interceptor.
+type Option func(*interceptor)
+
+// WithQueue makes the interceptor block calls made outside of the Timeframe until it opens,
+// instead of rejecting them. Queued calls are still rejected if their context is done before
+// the Timeframe opens.
+func WithQueue() Option {
+	return func(i *interceptor) {
+		i.queue = true
+	}
+}
+
+type interceptor struct {
+	window avail.Timeframe
+	queue  bool
+
+	// now returns the current time; it is swapped out in tests.
+	now func() time.Time
+}
+
+func newInterceptor(window avail.Timeframe, opts []Option) *interceptor {
+	i := &interceptor{
+		window: window,
+		now:    time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i
+}
+
+// UnaryServerInterceptor returns a unary interceptor which only admits calls while the given
+// Timeframe is able.
+func UnaryServerInterceptor(window avail.Timeframe, opts ...Option) grpc.UnaryServerInterceptor {
+	return newInterceptor(window, opts).unary()
+}
+
+// StreamServerInterceptor returns a stream interceptor which only admits streams while the
+// given Timeframe is able. Streams already admitted are not interrupted when the Timeframe
+// closes.
+func StreamServerInterceptor(window avail.Timeframe, opts ...Option) grpc.StreamServerInterceptor {
+	return newInterceptor(window, opts).stream()
+}
+
+func (i *interceptor) unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := i.admit(ctx); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (i *interceptor) stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := i.admit(stream.Context()); err != nil {
+			return err
+		}
+
+		return handler(srv, stream)
+	}
+}
+
+// admit returns nil once a call may proceed, or a status error explaining why it may not.
+func (i *interceptor) admit(ctx context.Context) error {
+	for {
+		now := i.now()
+		if i.window.Able(now) {
+			return nil
+		}
+
+		nextOpen, err := i.window.Next(now)
+		ok := err == nil
+		if !i.queue {
+			return unavailableError(now, nextOpen, ok)
+		}
+
+		wait := time.Minute
+		if ok {
+			wait = nextOpen.Sub(now)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return status.FromContextError(ctx.Err()).Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// unavailableError builds the status returned for rejected calls. When the next open time is
+// known it is included as both RetryInfo and ErrorInfo details.
+func unavailableError(now, nextOpen time.Time, ok bool) error {
+	st := status.New(codes.Unavailable, "call made outside of availability window")
+	if !ok {
+		return st.Err()
+	}
+
+	detailed, err := st.WithDetails(
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(nextOpen.Sub(now))},
+		&errdetails.ErrorInfo{
+			Reason:   ErrorReason,
+			Domain:   "avail",
+			Metadata: map[string]string{NextOpenKey: nextOpen.Format(time.RFC3339)},
+		},
+	)
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
@@ -0,0 +1,129 @@
+package availgrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/clintjedwards/avail/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	window, err := avail.New("* 9-16 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	tests := map[string]struct {
+		now      time.Time
+		wantCode codes.Code
+		wantNext string
+	}{
+		"inside window":  {time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC), codes.OK, ""},
+		"outside window": {time.Date(2020, 6, 8, 8, 30, 0, 0, time.UTC), codes.Unavailable, "2020-06-08T09:00:00Z"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			i := newInterceptor(window, nil)
+			i.now = func() time.Time { return tc.now }
+
+			_, err := i.unary()(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+			st := status.Convert(err)
+			if st.Code() != tc.wantCode {
+				t.Fatalf("incorrect code; want %s, got %s", tc.wantCode, st.Code())
+			}
+
+			if tc.wantNext == "" {
+				return
+			}
+
+			for _, detail := range st.Details() {
+				if info, ok := detail.(*errdetails.ErrorInfo); ok {
+					if got := info.Metadata[NextOpenKey]; got != tc.wantNext {
+						t.Errorf("incorrect next open time; want %s, got %s", tc.wantNext, got)
+					}
+					return
+				}
+			}
+
+			t.Error("rejected call should carry ErrorInfo details")
+		})
+	}
+}
+
+func TestSecondsWindow(t *testing.T) {
+	window, err := avail.New("30 * * * * * *", avail.WithSeconds())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i := newInterceptor(window, nil)
+	i.now = func() time.Time { return time.Date(2020, 6, 8, 8, 30, 10, 0, time.UTC) }
+
+	st := status.Convert(i.admit(context.Background()))
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			if got := info.Metadata[NextOpenKey]; got != "2020-06-08T08:30:30Z" {
+				t.Errorf("incorrect next open time; want %s, got %s", "2020-06-08T08:30:30Z", got)
+			}
+			return
+		}
+	}
+
+	t.Error("rejected call should carry ErrorInfo details")
+}
+
+func TestQueuedCallAdmitted(t *testing.T) {
+	window, err := avail.New("30 * * * * * *", avail.WithSeconds())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i := newInterceptor(window, []Option{WithQueue()})
+
+	// The window opens 10ms after the call is made.
+	times := []time.Time{
+		time.Date(2020, 6, 8, 8, 30, 29, int(990*time.Millisecond), time.UTC),
+		time.Date(2020, 6, 8, 8, 30, 30, 0, time.UTC),
+	}
+	i.now = func() time.Time {
+		now := times[0]
+		if len(times) > 1 {
+			times = times[1:]
+		}
+		return now
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := i.admit(ctx); err != nil {
+		t.Errorf("queued call should be admitted once the window opens; got %v", err)
+	}
+}
+
+func TestQueuedCallCancelled(t *testing.T) {
+	window, err := avail.New("* * * * * 1970")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i := newInterceptor(window, []Option{WithQueue()})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if code := status.Code(i.admit(ctx)); code != codes.DeadlineExceeded {
+		t.Errorf("incorrect code; want %s, got %s", codes.DeadlineExceeded, code)
+	}
+}
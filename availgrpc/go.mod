@@ -0,0 +1,16 @@
+module github.com/clintjedwards/avail/v2/availgrpc
+
+go 1.25.0
+
+require (
+	github.com/clintjedwards/avail/v2 v2.0.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260921155816-b14227669459
+	google.golang.org/grpc v1.84.0
+	google.golang.org/protobuf v1.36.12
+)
+
+require (
+	golang.org/x/net v0.57.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.40.0 // indirect
+)
@@ -0,0 +1,354 @@
+package avail
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// binaryVersion is the version of the format written by MarshalBinary. It is the first byte
+// of every encoding and must be incremented whenever the format changes, so that encodings
+// made by other versions are rejected instead of misread.
+const binaryVersion = 6
+
+// MarshalBinary implements encoding.BinaryMarshaler, encoding the parsed Timeframe, including
+// its alternatives and exclusions, in a compact form that UnmarshalBinary can rehydrate
+// without parsing the expression again. It is also used by encoding/gob.
+//
+// Field values are stored as bitsets, so a typical expression encodes in around a hundred
+// bytes. Clocks set with WithClock cannot be encoded and are dropped, while Timeframes with
+// holidays cannot be encoded at all.
+func (a Timeframe) MarshalBinary() ([]byte, error) {
+	encoder := binaryEncoder{}
+	encoder.buffer.WriteByte(binaryVersion)
+
+	if err := encoder.timeframe(&a); err != nil {
+		return nil, fmt.Errorf("could not marshal cron expression: %s; %w", a.Expression, err)
+	}
+
+	return encoder.buffer.Bytes(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, restoring a Timeframe encoded by
+// MarshalBinary. Encodings made by a different version of the format are rejected.
+func (a *Timeframe) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("could not unmarshal timeframe: no data")
+	}
+	if data[0] != binaryVersion {
+		return fmt.Errorf("could not unmarshal timeframe: unsupported version %d; expected %d", data[0], binaryVersion)
+	}
+
+	decoder := binaryDecoder{reader: bytes.NewReader(data[1:])}
+	timeframe := decoder.timeframe()
+	if decoder.err == nil && decoder.reader.Len() != 0 {
+		decoder.err = fmt.Errorf("%d unexpected trailing bytes", decoder.reader.Len())
+	}
+	if decoder.err != nil {
+		return fmt.Errorf("could not unmarshal timeframe: %w", decoder.err)
+	}
+
+	*a = timeframe
+	return nil
+}
+
+// Option flags stored in a single byte.
+const (
+	binarySeconds byte = 1 << iota
+	binaryOptionalYear
+	binaryExpandedJSON
+	binaryDomDowOr
+	binaryActiveStart
+	binaryActiveEnd
+)
+
+type binaryEncoder struct {
+	buffer bytes.Buffer
+}
+
+func (e *binaryEncoder) uvarint(value uint64) {
+	var scratch [binary.MaxVarintLen64]byte
+	e.buffer.Write(scratch[:binary.PutUvarint(scratch[:], value)])
+}
+
+func (e *binaryEncoder) varint(value int64) {
+	var scratch [binary.MaxVarintLen64]byte
+	e.buffer.Write(scratch[:binary.PutVarint(scratch[:], value)])
+}
+
+func (e *binaryEncoder) string(value string) {
+	e.uvarint(uint64(len(value)))
+	e.buffer.WriteString(value)
+}
+
+func (e *binaryEncoder) time(value time.Time) {
+	e.varint(value.Unix())
+	e.varint(int64(value.Nanosecond()))
+}
+
+func (e *binaryEncoder) timeframe(a *Timeframe) error {
+	e.string(a.Expression)
+
+	if a.options.holidays != nil {
+		return fmt.Errorf("holidays cannot be encoded")
+	}
+
+	if err := checkLocationName(a.Location); err != nil {
+		return err
+	}
+
+	location := ""
+	if a.Location != nil {
+		location = a.Location.String()
+	}
+	e.string(location)
+	e.varint(int64(a.Interval))
+
+	var flags byte
+	if a.options.seconds {
+		flags |= binarySeconds
+	}
+	if a.options.optionalYear {
+		flags |= binaryOptionalYear
+	}
+	if a.options.expandedJSON {
+		flags |= binaryExpandedJSON
+	}
+	if a.options.domDowOr {
+		flags |= binaryDomDowOr
+	}
+	if !a.options.activeStart.IsZero() {
+		flags |= binaryActiveStart
+	}
+	if !a.options.activeEnd.IsZero() {
+		flags |= binaryActiveEnd
+	}
+	e.buffer.WriteByte(flags)
+	e.string(a.options.hashKey)
+	e.string(string(a.options.dialect))
+	e.varint(int64(a.options.minYear))
+	e.varint(int64(a.options.maxYear))
+	e.varint(int64(a.options.dstPolicy))
+	e.varint(int64(a.options.granularity))
+	if !a.options.activeStart.IsZero() {
+		e.time(a.options.activeStart)
+	}
+	if !a.options.activeEnd.IsZero() {
+		e.time(a.options.activeEnd)
+	}
+
+	parsed := &a.ParsedExpression
+	for _, field := range []*Field{
+		&parsed.Seconds, &parsed.Minutes, &parsed.Hours, &parsed.Days, &parsed.Months, &parsed.Weekdays, &parsed.Years,
+	} {
+		if err := e.field(field); err != nil {
+			return err
+		}
+	}
+
+	for _, nested := range [][]Timeframe{a.alternatives, a.exclusions} {
+		e.uvarint(uint64(len(nested)))
+		for i := range nested {
+			if err := e.timeframe(&nested[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// field encodes the field's bounds, its values as a bitset over those bounds and its
+// relative values.
+func (e *binaryEncoder) field(f *Field) error {
+	e.string(string(f.Kind))
+	e.string(f.Term)
+	e.varint(int64(f.Min))
+	e.varint(int64(f.Max))
+
+	bits := []byte{}
+	if f.Max >= f.Min {
+		bits = make([]byte, (f.Max-f.Min)/8+1)
+	}
+	for value := range f.values() {
+		if value < f.Min || value > f.Max {
+			return fmt.Errorf("%s value(%d) is outside of the field's bounds", f.Kind, value)
+		}
+		bits[(value-f.Min)/8] |= 1 << uint((value-f.Min)%8)
+	}
+	e.buffer.Write(bits)
+
+	e.uvarint(uint64(len(f.relative)))
+	for _, relative := range f.relative {
+		e.string(string(relative.kind))
+		e.varint(int64(relative.offset))
+		e.varint(int64(relative.day))
+		e.varint(int64(relative.weekday))
+		e.varint(int64(relative.nth))
+	}
+
+	return nil
+}
+
+// binaryDecoder reads an encoding made by binaryEncoder. The first error encountered is kept
+// and all reads after it return zero values.
+type binaryDecoder struct {
+	reader *bytes.Reader
+	err    error
+}
+
+func (d *binaryDecoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	value, err := binary.ReadUvarint(d.reader)
+	if err != nil {
+		d.err = fmt.Errorf("truncated data")
+	}
+	return value
+}
+
+func (d *binaryDecoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	value, err := binary.ReadVarint(d.reader)
+	if err != nil {
+		d.err = fmt.Errorf("truncated data")
+	}
+	return value
+}
+
+func (d *binaryDecoder) bytes(length uint64) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if length > uint64(d.reader.Len()) {
+		d.err = fmt.Errorf("truncated data")
+		return nil
+	}
+	data := make([]byte, length)
+	_, _ = d.reader.Read(data)
+	return data
+}
+
+func (d *binaryDecoder) byte() byte {
+	data := d.bytes(1)
+	if data == nil {
+		return 0
+	}
+	return data[0]
+}
+
+func (d *binaryDecoder) string() string {
+	return string(d.bytes(d.uvarint()))
+}
+
+// time returns the time in UTC, as its location is not encoded.
+func (d *binaryDecoder) time() time.Time {
+	seconds := d.varint()
+	return time.Unix(seconds, d.varint()).UTC()
+}
+
+func (d *binaryDecoder) timeframe() Timeframe {
+	timeframe := Timeframe{Expression: d.string()}
+
+	if location := d.string(); location != "" && d.err == nil {
+		loaded, err := time.LoadLocation(location)
+		if err != nil {
+			d.err = &ZoneError{Zone: location, Err: err}
+		}
+		timeframe.Location = loaded
+	}
+	timeframe.Interval = time.Duration(d.varint())
+
+	flags := d.byte()
+	timeframe.options = options{
+		seconds:      flags&binarySeconds != 0,
+		optionalYear: flags&binaryOptionalYear != 0,
+		expandedJSON: flags&binaryExpandedJSON != 0,
+		domDowOr:     flags&binaryDomDowOr != 0,
+		hashKey:      d.string(),
+		dialect:      Dialect(d.string()),
+		minYear:      int(d.varint()),
+		maxYear:      int(d.varint()),
+		dstPolicy:    DSTPolicy(d.varint()),
+		granularity:  time.Duration(d.varint()),
+	}
+	if flags&binaryActiveStart != 0 {
+		timeframe.options.activeStart = d.time()
+	}
+	if flags&binaryActiveEnd != 0 {
+		timeframe.options.activeEnd = d.time()
+	}
+
+	parsed := &timeframe.ParsedExpression
+	for _, field := range []*Field{
+		&parsed.Seconds, &parsed.Minutes, &parsed.Hours, &parsed.Days, &parsed.Months, &parsed.Weekdays, &parsed.Years,
+	} {
+		*field = d.field()
+	}
+
+	for _, nested := range []*[]Timeframe{&timeframe.alternatives, &timeframe.exclusions} {
+		count := d.uvarint()
+		if count > uint64(d.reader.Len()) {
+			d.err = fmt.Errorf("truncated data")
+		}
+		for i := uint64(0); i < count && d.err == nil; i++ {
+			*nested = append(*nested, d.timeframe())
+		}
+	}
+
+	return timeframe
+}
+
+func (d *binaryDecoder) field() Field {
+	field := Field{
+		Kind: FieldType(d.string()),
+		Term: d.string(),
+		Min:  int(d.varint()),
+		Max:  int(d.varint()),
+	}
+	if d.err != nil {
+		return Field{}
+	}
+
+	if field.Max >= field.Min {
+		if field.Max-field.Min > 1<<16 {
+			d.err = fmt.Errorf("%s field bounds are too large", field.Kind)
+			return Field{}
+		}
+
+		field.Values = map[int]struct{}{}
+		bits := d.bytes(uint64((field.Max-field.Min)/8 + 1))
+		for i := range bits {
+			for bit := 0; bit < 8; bit++ {
+				if bits[i]&(1<<uint(bit)) != 0 {
+					field.Values[field.Min+i*8+bit] = struct{}{}
+				}
+			}
+		}
+	}
+
+	count := d.uvarint()
+	if count > uint64(d.reader.Len()) {
+		d.err = fmt.Errorf("truncated data")
+	}
+	for i := uint64(0); i < count && d.err == nil; i++ {
+		field.relative = append(field.relative, relativeValue{
+			kind:    termKind(d.string()),
+			offset:  int(d.varint()),
+			day:     int(d.varint()),
+			weekday: time.Weekday(d.varint()),
+			nth:     int(d.varint()),
+		})
+	}
+
+	// Wildcard years are kept as their bounds, as when parsed.
+	if field.Kind == year && identifyTermKind(field.Term) == wildcard {
+		field.setWildcard()
+	}
+
+	return field
+}
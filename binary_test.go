@@ -0,0 +1,161 @@
+package avail
+
+import (
+	"bytes"
+	"encoding/gob"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+	}{
+		"simple":       {"0 9 * * 1-5 *", nil},
+		"location":     {"CRON_TZ=America/New_York 30 9 * * MON-FRI *", nil},
+		"relative":     {"0 0 L-3 * * *", nil},
+		"nth weekday":  {"0 0 9 ? * 6#2", []Option{WithDialect(Quartz)}},
+		"alternatives": {"0 9 * * 1-5 * || 0 10 * * 6,0 *", nil},
+		"seconds":      {"0,30 0 9 * * * *", []Option{WithSeconds()}},
+		"quartz":       {"0 0 9 ? * MON-FRI", []Option{WithDialect(Quartz)}},
+		"hashed":       {"H H * * * *", []Option{WithHashKey("backup")}},
+		"year range":   {"0 9 1 1 * 2018-2150", []Option{WithYearRange(2000, 2200)}},
+		"either day":   {"0 9 1 * 1 *", []Option{WithDomDowOr()}},
+		"dst policy":   {"CRON_TZ=America/New_York 30 2 * * * *", []Option{WithDSTPolicy(DSTFirst)}},
+		"granularity":  {"0 9 * * * *", []Option{WithGranularity(time.Second)}},
+		"active": {"0 9 * * * *", []Option{WithActiveBetween(time.Date(2020, 6, 3, 0, 0, 0, 0, time.UTC),
+			time.Date(2020, 6, 10, 12, 30, 15, 500, time.UTC))}},
+	}
+
+	after := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			timeframe, err := New(tc.expression, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			encoded, err := timeframe.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var decoded Timeframe
+			if err := decoded.UnmarshalBinary(encoded); err != nil {
+				t.Fatal(err)
+			}
+
+			if decoded.Expression != timeframe.Expression {
+				t.Errorf("want %s, got %s", timeframe.Expression, decoded.Expression)
+			}
+
+			diff := cmp.Diff(timeframe.NextN(after, 20), decoded.NextN(after, 20))
+			if diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMarshalBinaryExclusions(t *testing.T) {
+	business, err := New("* 9-17 * * MON-FRI *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lunch, err := New("* 12 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timeframe := business.Except(lunch)
+
+	encoded, err := timeframe.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded Timeframe
+	if err := decoded.UnmarshalBinary(encoded); err != nil {
+		t.Fatal(err)
+	}
+
+	noon := time.Date(2020, 6, 8, 12, 30, 0, 0, time.UTC)
+	if decoded.Able(noon) {
+		t.Errorf("want %t, got %t", false, true)
+	}
+
+	morning := time.Date(2020, 6, 8, 10, 30, 0, 0, time.UTC)
+	if !decoded.Able(morning) {
+		t.Errorf("want %t, got %t", true, false)
+	}
+}
+
+func TestMarshalBinaryGob(t *testing.T) {
+	type job struct {
+		Name     string
+		Schedule Timeframe
+	}
+
+	schedule, err := New("CRON_TZ=Europe/Berlin 0 6 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buffer := bytes.Buffer{}
+	if err := gob.NewEncoder(&buffer).Encode(job{Name: "report", Schedule: schedule}); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := job{}
+	if err := gob.NewDecoder(&buffer).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !decoded.Schedule.Able(time.Date(2020, 6, 8, 6, 0, 0, 0, berlin)) {
+		t.Errorf("want %t, got %t", true, false)
+	}
+}
+
+func TestUnmarshalBinaryInvalid(t *testing.T) {
+	timeframe, err := New("0 9 * * 1-5 * || 0 10 * * 6,0 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := timeframe.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unknownVersion := append([]byte{binaryVersion + 1}, encoded[1:]...)
+	trailing := append(append([]byte{}, encoded...), 0)
+
+	tests := map[string][]byte{
+		"empty":           {},
+		"unknown version": unknownVersion,
+		"trailing bytes":  trailing,
+	}
+	for i := 1; i < len(encoded); i++ {
+		tests["truncated at "+strconv.Itoa(i)] = encoded[:i]
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			var decoded Timeframe
+			if err := decoded.UnmarshalBinary(data); err == nil {
+				t.Errorf("invalid data should not be unmarshalled")
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package avail
+
+import (
+	"fmt"
+	"time"
+)
+
+// Bitmap records which slots of a range of time a Timeframe is able at, so that systems which
+// cannot evaluate expressions themselves can join against the result. It is returned by
+// ExportBitmap.
+//
+// Slot i starts at Start.Add(i * Resolution) and is stored in Bits[i/64] as the bit
+// 1<<(i%64), so the least significant bit of Bits[0] is the first slot.
+type Bitmap struct {
+	Start      time.Time
+	Resolution time.Duration
+	// Length is the number of slots in the bitmap; bits beyond it are always unset.
+	Length int
+	Bits   []uint64
+}
+
+// ExportBitmap evaluates the Timeframe at the start of every slot of the given resolution from
+// the start of the range up to, but not including, its end. The resolution must be a whole
+// multiple of a minute (or second, for expressions with seconds).
+func (a *Timeframe) ExportBitmap(from, to time.Time, resolution time.Duration) (Bitmap, error) {
+	precision := a.options.precision()
+	if resolution < precision || resolution%precision != 0 {
+		return Bitmap{}, fmt.Errorf("resolution(%s) must be a whole multiple of %s", resolution, precision)
+	}
+
+	if !from.Before(to) {
+		return Bitmap{}, fmt.Errorf("start of range(%s) must be before end(%s)", from, to)
+	}
+
+	length := int((to.Sub(from) + resolution - 1) / resolution)
+	bitmap := Bitmap{
+		Start:      from,
+		Resolution: resolution,
+		Length:     length,
+		Bits:       make([]uint64, (length+63)/64),
+	}
+
+	for i := 0; i < length; i++ {
+		if a.Able(from.Add(time.Duration(i) * resolution)) {
+			bitmap.Bits[i/64] |= 1 << uint(i%64)
+		}
+	}
+
+	return bitmap, nil
+}
+
+// Able reports whether the slot containing the time given is set. Times outside of the
+// bitmap are never able.
+func (b Bitmap) Able(t time.Time) bool {
+	if t.Before(b.Start) || b.Resolution <= 0 {
+		return false
+	}
+
+	i := int(t.Sub(b.Start) / b.Resolution)
+	if i >= b.Length {
+		return false
+	}
+
+	return b.Bits[i/64]&(1<<uint(i%64)) != 0
+}
+
+// Count returns the number of slots which are set.
+func (b Bitmap) Count() int {
+	count := 0
+	for _, word := range b.Bits {
+		for ; word != 0; word &= word - 1 {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,69 @@
+package avail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExportBitmap(t *testing.T) {
+	avail, err := New("* 9-16 * * 1-5 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := time.Date(2020, 6, 7, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	bitmap, err := avail.ExportBitmap(from, to, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bitmap.Length != 7*24*60 || len(bitmap.Bits) != (7*24*60+63)/64 {
+		t.Fatalf("incorrect bitmap size; length %d, words %d", bitmap.Length, len(bitmap.Bits))
+	}
+
+	for current := from; current.Before(to); current = current.Add(time.Minute) {
+		if bitmap.Able(current) != avail.Able(current) {
+			t.Fatalf("bitmap and timeframe disagree at %s", current)
+		}
+	}
+
+	if bitmap.Count() != 5*8*60 {
+		t.Errorf("incorrect number of set slots; want %d, got %d", 5*8*60, bitmap.Count())
+	}
+
+	if bitmap.Able(to) || bitmap.Able(from.Add(-time.Minute)) {
+		t.Error("times outside of the bitmap should not be able")
+	}
+
+	hourly, err := avail.ExportBitmap(from, to, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hourly.Count() != 5*8 {
+		t.Errorf("incorrect number of set hourly slots; want %d, got %d", 5*8, hourly.Count())
+	}
+}
+
+func TestExportBitmapInvalid(t *testing.T) {
+	avail, err := New("* * * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := time.Date(2020, 6, 7, 0, 0, 0, 0, time.UTC)
+
+	if _, err := avail.ExportBitmap(from, from.Add(time.Hour), time.Second); err == nil {
+		t.Error("resolutions finer than the expression's precision should not be accepted")
+	}
+
+	if _, err := avail.ExportBitmap(from, from.Add(time.Hour), 90*time.Second); err == nil {
+		t.Error("resolutions which are not a multiple of the precision should not be accepted")
+	}
+
+	if _, err := avail.ExportBitmap(from, from, time.Minute); err == nil {
+		t.Error("empty ranges should not be accepted")
+	}
+}
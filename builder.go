@@ -0,0 +1,208 @@
+package avail
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Builder constructs a native expression from the values each of its terms should match, as
+// an alternative to formatting expression strings by hand. Ex.
+//
+//    timeframe, err := avail.Build().
+//        Minutes(0).
+//        Hours(avail.Span(9, 17)...).
+//        Weekdays(time.Monday, time.Wednesday, time.Friday).
+//        Timeframe()
+//
+// Terms which are not given any values match every value. Values given to the same term in
+// several calls accumulate. The first value outside of its term's range, or term given no
+// values, is reported by Expression and Timeframe.
+type Builder struct {
+	// values holds the values of each term in the order of nativeFields; nil matches every
+	// value.
+	values   []map[int]struct{}
+	location *time.Location
+	err      error
+}
+
+// Build returns a Builder whose terms match every value.
+func Build() *Builder {
+	return &Builder{values: make([]map[int]struct{}, len(nativeFields))}
+}
+
+// Span returns every value from start to end inclusive, for passing to the Builder's methods.
+// Ex. Hours(Span(9, 17)...).
+func Span(start, end int) []int {
+	values := []int{}
+	for value := start; value <= end; value++ {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Seconds adds values to the seconds term, giving the expression a leading seconds term as if
+// parsed WithSeconds.
+func (b *Builder) Seconds(values ...int) *Builder {
+	return b.add(0, values)
+}
+
+// Minutes adds values, from 0 to 59, to the minutes term.
+func (b *Builder) Minutes(values ...int) *Builder {
+	return b.add(1, values)
+}
+
+// Hours adds values, from 0 to 23, to the hours term.
+func (b *Builder) Hours(values ...int) *Builder {
+	return b.add(2, values)
+}
+
+// Days adds days of the month, from 1 to 31, to the days term.
+func (b *Builder) Days(values ...int) *Builder {
+	return b.add(3, values)
+}
+
+// Months adds months to the months term.
+func (b *Builder) Months(months ...time.Month) *Builder {
+	values := []int{}
+	for _, month := range months {
+		values = append(values, int(month))
+	}
+	return b.add(4, values)
+}
+
+// Weekdays adds days of the week to the weekdays term.
+func (b *Builder) Weekdays(weekdays ...time.Weekday) *Builder {
+	values := []int{}
+	for _, weekday := range weekdays {
+		values = append(values, int(weekday))
+	}
+	return b.add(5, values)
+}
+
+// Years adds years, from 1970 to 2100, to the years term.
+func (b *Builder) Years(values ...int) *Builder {
+	return b.add(6, values)
+}
+
+// In evaluates the expression in the location given, by prefixing it with "CRON_TZ=". The
+// location must be one time.LoadLocation can load by name.
+func (b *Builder) In(location *time.Location) *Builder {
+	b.location = location
+	return b
+}
+
+// add adds the values to the term at index within nativeFields, recording the first which is
+// out of its bounds.
+func (b *Builder) add(index int, values []int) *Builder {
+	bounds := nativeFields[index]
+	if len(values) == 0 && b.err == nil {
+		b.err = fmt.Errorf("%s term was given no values", bounds.kind)
+	}
+
+	if b.values[index] == nil {
+		b.values[index] = map[int]struct{}{}
+	}
+	for _, value := range values {
+		if (value < bounds.min || value > bounds.max) && b.err == nil {
+			b.err = fmt.Errorf("%s value(%d) must be within %d-%d", bounds.kind, value, bounds.min, bounds.max)
+		}
+		b.values[index][value] = struct{}{}
+	}
+	return b
+}
+
+// Expression returns the native expression matching the values given, in the canonical form
+// Normalize produces. Ex. "0 9-17 * * 1,3,5 *".
+func (b *Builder) Expression() (string, error) {
+	if b.err != nil {
+		return "", fmt.Errorf("could not build expression: %w", b.err)
+	}
+
+	timeframe, err := fromValues(b.values, b.location)
+	if err != nil {
+		return "", err
+	}
+	return timeframe.Expression, nil
+}
+
+// Timeframe returns a Timeframe matching the values given, whose Expression is the one
+// Expression returns.
+func (b *Builder) Timeframe() (Timeframe, error) {
+	if b.err != nil {
+		return Timeframe{}, fmt.Errorf("could not build expression: %w", b.err)
+	}
+	return fromValues(b.values, b.location)
+}
+
+// fromValues returns a Timeframe matching the values of each term, given in the order of
+// nativeFields, without parsing an expression. Terms without values match every value and the
+// seconds term is only included if it has values. The values must be within their bounds.
+func fromValues(values []map[int]struct{}, location *time.Location) (Timeframe, error) {
+	timeframe := Timeframe{Location: location, options: options{seconds: values[0] != nil}}
+	parsed := &timeframe.ParsedExpression
+	targets := []*Field{
+		&parsed.Seconds, &parsed.Minutes, &parsed.Hours, &parsed.Days, &parsed.Months, &parsed.Weekdays, &parsed.Years,
+	}
+	for i, bounds := range nativeFields {
+		*targets[i] = Field{Kind: bounds.kind, Min: bounds.min, Max: bounds.max, Values: values[i]}
+		if values[i] == nil {
+			targets[i].setWildcard()
+		}
+	}
+
+	expression, err := timeframe.formatNative(true)
+	if err != nil {
+		return Timeframe{}, fmt.Errorf("could not build expression: %w", err)
+	}
+
+	if location != nil {
+		expression = locationPrefixes[0] + location.String() + " " + expression
+	}
+	timeframe.Expression = expression
+
+	// Fields carry the terms they would have been parsed from.
+	terms := strings.Fields(expression)
+	if location != nil {
+		terms = terms[1:]
+	}
+	// As when parsed, expressions without seconds leave the seconds field empty.
+	if !timeframe.options.seconds {
+		parsed.Seconds = Field{}
+		targets = targets[1:]
+	}
+	for i, target := range targets {
+		target.Term = terms[i]
+	}
+
+	return timeframe, nil
+}
+
+// FromTime returns a Timeframe matching only the slot of the given precision containing t:
+// its second, minute, hour or day, given as time.Second, time.Minute, time.Hour or
+// 24*time.Hour. Ex. 14:30 on June 5th 2025 in UTC at minute precision is
+// "CRON_TZ=UTC 30 14 5 6 * 2025". It is intended for one-off schedules and for tests which
+// need a Timeframe able at a fixture time.
+//
+// The expression is evaluated in t's location so it matches exactly that slot. Locations which
+// cannot be loaded by name, such as those made with time.FixedZone, are converted to UTC.
+func FromTime(t time.Time, precision time.Duration) (Timeframe, error) {
+	if _, err := time.LoadLocation(t.Location().String()); err != nil || t.Location().String() == "" {
+		t = t.UTC()
+	}
+
+	builder := Build().In(t.Location()).Years(t.Year()).Months(t.Month()).Days(t.Day())
+	switch precision {
+	case time.Second:
+		builder.Seconds(t.Second()).Minutes(t.Minute()).Hours(t.Hour())
+	case time.Minute:
+		builder.Minutes(t.Minute()).Hours(t.Hour())
+	case time.Hour:
+		builder.Hours(t.Hour())
+	case 24 * time.Hour:
+	default:
+		return Timeframe{}, fmt.Errorf("could not build expression: precision(%s) must be a second, minute, hour or day", precision)
+	}
+
+	return builder.Timeframe()
+}
@@ -0,0 +1,138 @@
+package avail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuilder(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		builder *Builder
+		want    string
+	}{
+		"wildcards": {Build(), "* * * * * *"},
+		"business hours": {
+			Build().Minutes(0).Hours(Span(9, 17)...).Weekdays(time.Monday, time.Wednesday, time.Friday),
+			"0 9-17 * * 1,3,5 *",
+		},
+		"accumulated":  {Build().Minutes(0).Hours(9).Hours(17), "0 9,17 * * * *"},
+		"every value":  {Build().Minutes(Span(0, 59)...), "* * * * * *"},
+		"months":       {Build().Minutes(0).Hours(0).Days(1).Months(time.January, time.July), "0 0 1 1,7 * *"},
+		"years":        {Build().Minutes(30).Hours(12).Years(Span(2020, 2025)...), "30 12 * * * 2020-2025"},
+		"seconds":      {Build().Seconds(0, 30).Minutes(0).Hours(12), "0,30 0 12 * * * *"},
+		"only seconds": {Build().Seconds(0), "0 * * * * * *"},
+		"location":     {Build().Minutes(0).Hours(9).In(newYork), "CRON_TZ=America/New_York 0 9 * * * *"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			expression, err := tc.builder.Expression()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expression != tc.want {
+				t.Errorf("want %s, got %s", tc.want, expression)
+			}
+
+			timeframe, err := tc.builder.Timeframe()
+			if err != nil {
+				t.Fatal(err)
+			}
+			normalized, err := timeframe.Normalize()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if normalized != tc.want {
+				t.Errorf("want %s, got %s", tc.want, normalized)
+			}
+		})
+	}
+}
+
+func TestBuilderInvalid(t *testing.T) {
+	tests := map[string]*Builder{
+		"minute above max": Build().Minutes(60),
+		"day below min":    Build().Days(0),
+		"year above max":   Build().Years(2101),
+		"weekday":          Build().Weekdays(time.Weekday(7)),
+		"no values":        Build().Hours(),
+		"later valid call": Build().Hours(24).Hours(12),
+	}
+
+	for name, builder := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := builder.Expression(); err == nil {
+				t.Errorf("invalid values should not be built successfully")
+			}
+			if _, err := builder.Timeframe(); err == nil {
+				t.Errorf("invalid values should not be built successfully")
+			}
+		})
+	}
+}
+
+func TestFromTime(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixture := time.Date(2025, 6, 5, 14, 30, 15, 0, time.UTC)
+
+	tests := map[string]struct {
+		t         time.Time
+		precision time.Duration
+		want      string
+	}{
+		"second":      {fixture, time.Second, "CRON_TZ=UTC 15 30 14 5 6 * 2025"},
+		"minute":      {fixture, time.Minute, "CRON_TZ=UTC 30 14 5 6 * 2025"},
+		"hour":        {fixture, time.Hour, "CRON_TZ=UTC * 14 5 6 * 2025"},
+		"day":         {fixture, 24 * time.Hour, "CRON_TZ=UTC * * 5 6 * 2025"},
+		"location":    {fixture.In(newYork), time.Minute, "CRON_TZ=America/New_York 30 10 5 6 * 2025"},
+		"fixed zone":  {fixture.In(time.FixedZone("", 2*60*60)), time.Minute, "CRON_TZ=UTC 30 14 5 6 * 2025"},
+		"named fixed": {fixture.In(time.FixedZone("Office", -5*60*60)), time.Minute, "CRON_TZ=UTC 30 14 5 6 * 2025"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			timeframe, err := FromTime(tc.t, tc.precision)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if timeframe.Expression != tc.want {
+				t.Errorf("want %s, got %s", tc.want, timeframe.Expression)
+			}
+
+			if !timeframe.Able(tc.t) {
+				t.Errorf("want %t, got %t", true, false)
+			}
+			if timeframe.Able(tc.t.Add(-tc.precision)) || timeframe.Able(tc.t.Add(tc.precision)) {
+				t.Errorf("neighbouring slots should not be able")
+			}
+		})
+	}
+}
+
+func TestFromTimeInvalid(t *testing.T) {
+	tests := map[string]struct {
+		t         time.Time
+		precision time.Duration
+	}{
+		"precision":   {time.Date(2025, 6, 5, 14, 30, 0, 0, time.UTC), 15 * time.Minute},
+		"before 1970": {time.Date(1969, 6, 5, 14, 30, 0, 0, time.UTC), time.Minute},
+		"after 2100":  {time.Date(2101, 6, 5, 14, 30, 0, 0, time.UTC), time.Minute},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := FromTime(tc.t, tc.precision); err == nil {
+				t.Errorf("invalid times should not be built successfully")
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package avail
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Calendar layers rules including and excluding Timeframes, along with one-off exception
+// dates and holidays, into a single schedule. Ex. weekday business hours, except the last
+// Friday of each month, except public holidays.
+//
+// Each rule has a priority, and at any time the highest priority rule whose Timeframe is able
+// decides whether the calendar is able. Exclusions take precedence over inclusions of the same
+// priority, and exception dates and holidays take precedence over every rule. Times only to be
+// included on holidays can be given as a Timeframe parsed WithHolidaysOnly. A calendar without
+// a matching inclusion is never able.
+//
+// The rules are compiled into a single Timeframe, made up of unions and exclusions, whenever
+// one is added, so Able, Next and Windows are as cheap as they are for the Timeframe.
+type Calendar struct {
+	location   *time.Location
+	rules      []calendarRule
+	exceptions map[date]struct{}
+	holidays   []HolidayProvider
+
+	// timeframe is the compiled calendar, or nil if it has no inclusions.
+	timeframe *Timeframe
+}
+
+// calendarRule is a Timeframe included in or excluded from a Calendar.
+type calendarRule struct {
+	timeframe Timeframe
+	priority  int
+	exclude   bool
+}
+
+// NewCalendar returns a Calendar without rules, whose exception dates and holidays are kept in
+// the given location. If the location is nil they are those of the time being evaluated.
+func NewCalendar(location *time.Location) *Calendar {
+	return &Calendar{location: location, exceptions: map[date]struct{}{}}
+}
+
+// Include makes the calendar able whenever the Timeframe is, unless a rule of a higher
+// priority excludes the time.
+func (c *Calendar) Include(priority int, timeframe Timeframe) {
+	c.rules = append(c.rules, calendarRule{timeframe: timeframe, priority: priority})
+	c.compile()
+}
+
+// Exclude makes the calendar not able whenever the Timeframe is, unless a rule of a higher
+// priority includes the time.
+func (c *Calendar) Exclude(priority int, timeframe Timeframe) {
+	c.rules = append(c.rules, calendarRule{timeframe: timeframe, priority: priority, exclude: true})
+	c.compile()
+}
+
+// AddException makes the calendar not able for the entire calendar day of the given date,
+// regardless of its rules. The date must be within the years expressions can express.
+func (c *Calendar) AddException(day time.Time) error {
+	if _, err := exceptionTimeframe(dateOf(day), c.location); err != nil {
+		return fmt.Errorf("could not add exception: %w", err)
+	}
+
+	c.exceptions[dateOf(day)] = struct{}{}
+	c.compile()
+	return nil
+}
+
+// ExcludeHolidays makes the calendar not able on the holidays the provider reports, in the
+// calendar's location, regardless of its rules.
+func (c *Calendar) ExcludeHolidays(provider HolidayProvider) {
+	c.holidays = append(c.holidays, provider)
+	c.compile()
+}
+
+// Able reports whether the calendar is able at the time given.
+func (c *Calendar) Able(t time.Time) bool {
+	return c.timeframe != nil && c.timeframe.Able(t)
+}
+
+// Next returns the first time strictly after the time given at which the calendar is able, as
+// Timeframe's Next does. ErrNoOccurrence is returned if there is no such time.
+func (c *Calendar) Next(after time.Time) (time.Time, error) {
+	if c.timeframe == nil {
+		return time.Time{}, ErrNoOccurrence
+	}
+	return c.timeframe.Next(after)
+}
+
+// Windows returns, in chronological order, the spans of time within [start, end) during which
+// the calendar is able, as Timeframe's Windows does.
+func (c *Calendar) Windows(start, end time.Time) []Window {
+	if c.timeframe == nil {
+		return []Window{}
+	}
+	return c.timeframe.Windows(start, end)
+}
+
+// Timeframe returns the Timeframe the calendar is compiled into. The second return value is
+// false if the calendar has no inclusions, and so is never able.
+func (c *Calendar) Timeframe() (Timeframe, bool) {
+	if c.timeframe == nil {
+		return Timeframe{}, false
+	}
+	return *c.timeframe, true
+}
+
+// compile folds the rules into a single Timeframe from the lowest priority to the highest, so
+// that the last rule able at any time decides it, then excludes every exception date and
+// holiday.
+func (c *Calendar) compile() {
+	rules := append([]calendarRule{}, c.rules...)
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].priority != rules[j].priority {
+			return rules[i].priority < rules[j].priority
+		}
+		return !rules[i].exclude && rules[j].exclude
+	})
+
+	var compiled *Timeframe
+	for _, rule := range rules {
+		switch {
+		case !rule.exclude && compiled == nil:
+			timeframe := rule.timeframe
+			compiled = &timeframe
+		case !rule.exclude:
+			union := compiled.Union(rule.timeframe)
+			compiled = &union
+		case compiled != nil:
+			except := compiled.Except(rule.timeframe)
+			compiled = &except
+		}
+	}
+
+	if compiled != nil {
+		days := make([]date, 0, len(c.exceptions))
+		for day := range c.exceptions {
+			days = append(days, day)
+		}
+		sort.Slice(days, func(i, j int) bool {
+			return time.Date(days[i].year, days[i].month, days[i].day, 0, 0, 0, 0, time.UTC).Before(
+				time.Date(days[j].year, days[j].month, days[j].day, 0, 0, 0, 0, time.UTC))
+		})
+
+		for _, day := range days {
+			// Exception dates are checked when they are added.
+			exception, _ := exceptionTimeframe(day, c.location)
+			except := compiled.Except(exception)
+			compiled = &except
+		}
+
+		for _, provider := range c.holidays {
+			holidays := MustNew("* * * * * *", WithHolidaysOnly(provider), WithLocation(c.location))
+			except := compiled.Except(holidays)
+			compiled = &except
+		}
+	}
+
+	c.timeframe = compiled
+}
+
+// exceptionTimeframe returns a Timeframe able for the whole of the date in the location, or in
+// the location of the time being evaluated if it is nil.
+func exceptionTimeframe(day date, location *time.Location) (Timeframe, error) {
+	return Build().In(location).Years(day.year).Months(day.month).Days(day.day).Timeframe()
+}
@@ -0,0 +1,141 @@
+package avail
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCalendar(t *testing.T) {
+	calendar := NewCalendar(time.UTC)
+	calendar.Include(0, MustNew("* 9-16 * * 1-5 *"))
+	calendar.Exclude(1, MustNew("* 12 * * * *"))
+	calendar.Include(2, MustNew("* 12 * * 3 *"))
+	if err := calendar.AddException(time.Date(2020, 6, 10, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		time time.Time
+		want bool
+	}{
+		"included":                  {time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), true},
+		"not included":              {time.Date(2020, 6, 8, 8, 0, 0, 0, time.UTC), false},
+		"weekend":                   {time.Date(2020, 6, 6, 9, 0, 0, 0, time.UTC), false},
+		"excluded":                  {time.Date(2020, 6, 8, 12, 30, 0, 0, time.UTC), false},
+		"included over exclusion":   {time.Date(2020, 6, 17, 12, 30, 0, 0, time.UTC), true},
+		"exception":                 {time.Date(2020, 6, 10, 9, 0, 0, 0, time.UTC), false},
+		"exception over high rules": {time.Date(2020, 6, 10, 12, 30, 0, 0, time.UTC), false},
+		"higher include on weekend": {time.Date(2020, 6, 6, 12, 0, 0, 0, time.UTC), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := calendar.Able(tc.time); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+
+	next, err := calendar.Next(time.Date(2020, 6, 9, 17, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2020, 6, 11, 9, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("want %s, got %s", want, next)
+	}
+
+	want := []Window{
+		{Start: time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), End: time.Date(2020, 6, 8, 12, 0, 0, 0, time.UTC)},
+		{Start: time.Date(2020, 6, 8, 13, 0, 0, 0, time.UTC), End: time.Date(2020, 6, 8, 17, 0, 0, 0, time.UTC)},
+	}
+	diff := cmp.Diff(want, calendar.Windows(time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC), time.Date(2020, 6, 9, 0, 0, 0, 0, time.UTC)))
+	if diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+}
+
+func TestCalendarPrecedence(t *testing.T) {
+	weekdays := MustNew("* * * * 1-5 *")
+	monday := time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		build func(calendar *Calendar)
+		want  bool
+	}{
+		"exclusion wins a tie": {func(calendar *Calendar) {
+			calendar.Include(1, weekdays)
+			calendar.Exclude(1, weekdays)
+		}, false},
+		"exclusion wins a tie added first": {func(calendar *Calendar) {
+			calendar.Exclude(1, weekdays)
+			calendar.Include(1, weekdays)
+		}, false},
+		"higher inclusion wins": {func(calendar *Calendar) {
+			calendar.Include(2, weekdays)
+			calendar.Exclude(1, weekdays)
+		}, true},
+		"higher exclusion wins": {func(calendar *Calendar) {
+			calendar.Include(1, weekdays)
+			calendar.Exclude(2, weekdays)
+		}, false},
+		"only exclusions": {func(calendar *Calendar) {
+			calendar.Exclude(1, weekdays)
+		}, false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			calendar := NewCalendar(nil)
+			tc.build(calendar)
+
+			if got := calendar.Able(monday); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCalendarEmpty(t *testing.T) {
+	calendar := NewCalendar(nil)
+
+	if calendar.Able(time.Now()) {
+		t.Errorf("want %t, got %t", false, true)
+	}
+	if _, err := calendar.Next(time.Now()); !errors.Is(err, ErrNoOccurrence) {
+		t.Errorf("want %v, got %v", ErrNoOccurrence, err)
+	}
+	if windows := calendar.Windows(time.Now(), time.Now().Add(time.Hour)); len(windows) != 0 {
+		t.Errorf("want no windows, got %v", windows)
+	}
+	if _, ok := calendar.Timeframe(); ok {
+		t.Errorf("want %t, got %t", false, true)
+	}
+
+	if err := calendar.AddException(time.Date(2200, 1, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("exception outside of the years expressions can express should not be accepted")
+	}
+}
+
+func TestCalendarExceptionLocation(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calendar := NewCalendar(location)
+	calendar.Include(0, MustNew("* * * * * *"))
+	if err := calendar.AddException(time.Date(2020, 7, 4, 0, 0, 0, 0, location)); err != nil {
+		t.Fatal(err)
+	}
+
+	// 2:00 UTC on the 5th is still the 4th in New York.
+	if calendar.Able(time.Date(2020, 7, 5, 2, 0, 0, 0, time.UTC)) {
+		t.Errorf("want %t, got %t", false, true)
+	}
+	if !calendar.Able(time.Date(2020, 7, 5, 5, 0, 0, 0, time.UTC)) {
+		t.Errorf("want %t, got %t", true, false)
+	}
+}
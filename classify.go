@@ -0,0 +1,50 @@
+package avail
+
+// IsAlways reports whether the Timeframe is able at every minute (and every second, for
+// expressions with seconds) within the years it can express. Ex. "* * * * * *" and
+// "@every 1m" are always able; "* * * * 1-5 *" is not. Schedulers can use it to skip setting up
+// timers entirely.
+//
+// Every term of a plain expression must match all of its values. Combined Timeframes and
+// those with exclusions are checked with Contains, so "* 0-11 * * * * || * 12-23 * * * *" is
+// always able too.
+func (a *Timeframe) IsAlways() bool {
+	if a.alternatives == nil && len(a.exclusions) == 0 {
+		if a.Interval != 0 {
+			return a.Interval == a.options.precision()
+		}
+
+		parsed := a.ParsedExpression
+		fields := []Field{parsed.Minutes, parsed.Hours, parsed.Days, parsed.Months, parsed.Weekdays, parsed.Years}
+		if a.options.seconds {
+			fields = append(fields, parsed.Seconds)
+		}
+		for i := range fields {
+			if !fields[i].isWildcard() {
+				return false
+			}
+		}
+		return true
+	}
+
+	return a.Contains(always())
+}
+
+// IsNever reports whether the Timeframe can never be able, as when no date within its years
+// satisfies its day, month and weekday terms or its exclusions cover every time it could be.
+// Ex. "* * 30 2 * *". Configurations holding such schedules can be rejected early.
+func (a *Timeframe) IsNever() bool {
+	if a.neverMatches() {
+		return true
+	}
+	if a.alternatives == nil && len(a.exclusions) == 0 {
+		return false
+	}
+
+	return !a.Overlaps(always())
+}
+
+// always returns a Timeframe which is able at every minute.
+func always() Timeframe {
+	return MustNew("* * * * * *")
+}
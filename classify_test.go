@@ -0,0 +1,59 @@
+package avail
+
+import "testing"
+
+func TestIsAlways(t *testing.T) {
+	tests := map[string]struct {
+		timeframe Timeframe
+		want      bool
+	}{
+		"wildcards":           {MustNew("* * * * * *"), true},
+		"full spans":          {MustNew("0-59 0-23 1-31 1-12 0-6 *"), true},
+		"seconds":             {MustNew("* * * * * * *", WithSeconds()), true},
+		"some seconds":        {MustNew("0 * * * * * *", WithSeconds()), false},
+		"weekdays":            {MustNew("* * * * 1-5 *"), false},
+		"years":               {MustNew("* * * * * 2020-2030"), false},
+		"interval":            {MustNew("@every 1m"), true},
+		"longer interval":     {MustNew("@every 2m"), false},
+		"quartz":              {MustNew("* * * ? * *", WithDialect(Quartz)), true},
+		"complementary":       {MustNew("* 0-11 * * * * || * 12-23 * * * *"), true},
+		"incomplete":          {MustNew("* 0-11 * * * * || * 13-23 * * * *"), false},
+		"exclusion":           {MustNew("* * * * * *").Except(MustNew("* 12 * * * *")), false},
+		"impossible excluded": {MustNew("* * * * * *").Except(MustNew("* * 30 2 * *")), true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.timeframe.IsAlways(); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsNever(t *testing.T) {
+	tests := map[string]struct {
+		timeframe Timeframe
+		want      bool
+	}{
+		"wildcards":          {MustNew("* * * * * *"), false},
+		"february 30th":      {MustNew("* * 30 2 * *"), true},
+		"weekday and year":   {MustNew("0 0 31 * THU 2021"), true},
+		"interval":           {MustNew("@every 1h"), false},
+		"one alternative":    {MustNew("* * 30 2 * * || 0 9 * * * *"), false},
+		"every alternative":  {MustNew("* * 30 2 * * || * * 31 4 * *"), true},
+		"partly excluded":    {MustNew("* 9-17 * * * *").Except(MustNew("* 12 * * * *")), false},
+		"entirely excluded":  {MustNew("* 9-17 * * 1-5 *").Except(MustNew("* 8-18 * * * *")), true},
+		"excluded by union":  {MustNew("* 9 * * * *").Except(MustNew("* 9 * * 1-5 *").Union(MustNew("* 9 * * 6,0 *"))), true},
+		"union of excluded":  {MustNew("* 9 * * * *").Except(MustNew("* 9 * * * *")).Union(MustNew("* 10 30 2 * *")), true},
+		"union with a match": {MustNew("* 9 * * * *").Except(MustNew("* 9 * * * *")).Union(MustNew("* 10 * * * *")), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.timeframe.IsNever(); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,374 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/clintjedwards/avail/v2"
+)
+
+// config holds the settings given to availgen on the command line.
+type config struct {
+	pkg          string
+	typeName     string
+	seconds      bool
+	optionalYear bool
+	hashKey      string
+}
+
+// minYear is the first year an expression can express; years are stored as offsets from it.
+// The generated Able relies on the same value.
+const minYear = 1970
+
+// relativeDayRegex matches the day terms whose values depend on the month being evaluated.
+var relativeDayRegex = regexp.MustCompile(`(?i)^(LW|L(-([0-9]+))?)$`)
+
+// table is the precompiled form of a single expression. Every field is stored as a bitmask
+// with bit n set if the value n matches.
+type table struct {
+	Name       string
+	Expression string
+	Location   string
+	Interval   time.Duration
+	Precision  time.Duration
+
+	Seconds, Minutes, Hours, Days, Months, Weekdays uint64
+	Years                                           [3]uint64
+	// LastOffsets has bit n set if the day n days before the last day of the month matches.
+	LastOffsets uint32
+	LastWeekday bool
+}
+
+// generate parses each "name=expression" argument and returns the formatted Go source
+// implementing them.
+func generate(config config, args []string) ([]byte, error) {
+	if !token.IsIdentifier(config.pkg) || !token.IsIdentifier(config.typeName) {
+		return nil, fmt.Errorf("package and type must be valid identifiers")
+	}
+
+	if len(args) == 0 {
+		return nil, fmt.Errorf("at least one name=expression argument is required")
+	}
+
+	tables := []table{}
+	names := map[string]struct{}{}
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("could not parse argument %q: must be in the form name=expression", arg)
+		}
+
+		name, expression := strings.TrimSpace(parts[0]), parts[1]
+		if !token.IsIdentifier(name) || !token.IsExported(name) {
+			return nil, fmt.Errorf("could not parse argument %q: %s must be an exported identifier", arg, name)
+		}
+		if _, ok := names[name]; ok || name == config.typeName {
+			return nil, fmt.Errorf("could not parse argument %q: %s is declared more than once", arg, name)
+		}
+		names[name] = struct{}{}
+
+		table, err := compile(config, name, expression)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+
+	var buf bytes.Buffer
+	err := sourceTemplate.Execute(&buf, struct {
+		Package string
+		Type    string
+		Tables  []table
+	}{
+		Package: config.pkg,
+		Type:    config.typeName,
+		Tables:  tables,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// compile parses the expression with avail and converts the result into a table.
+func compile(config config, name, expression string) (table, error) {
+	hashKey := config.hashKey
+	if hashKey == "" {
+		hashKey = name
+	}
+
+	opts := []avail.Option{avail.WithHashKey(hashKey)}
+	if config.seconds {
+		opts = append(opts, avail.WithSeconds())
+	}
+	if config.optionalYear {
+		opts = append(opts, avail.WithOptionalYear())
+	}
+
+	// The alternatives of a combined expression are not exposed by avail, so they cannot be
+	// compiled into a single table.
+	if strings.Contains(expression, "||") {
+		return table{}, fmt.Errorf("could not compile %s: combined expressions are not supported; "+
+			"generate each alternative under its own name", name)
+	}
+
+	timeframe, err := avail.New(expression, opts...)
+	if err != nil {
+		return table{}, err
+	}
+
+	compiled := table{
+		Name:       name,
+		Expression: expression,
+		Interval:   timeframe.Interval,
+		Precision:  time.Minute,
+	}
+	if config.seconds {
+		compiled.Precision = time.Second
+	}
+	if timeframe.Location != nil {
+		compiled.Location = timeframe.Location.String()
+	}
+	if timeframe.Interval != 0 {
+		return compiled, nil
+	}
+
+	parsed := timeframe.ParsedExpression
+	if config.seconds {
+		compiled.Seconds = bitmask(parsed.Seconds.Values)
+	}
+	compiled.Minutes = bitmask(parsed.Minutes.Values)
+	compiled.Hours = bitmask(parsed.Hours.Values)
+	compiled.Days = bitmask(parsed.Days.Values)
+	compiled.Months = bitmask(parsed.Months.Values)
+	compiled.Weekdays = bitmask(parsed.Weekdays.Values)
+
+	for year := minYear; year < minYear+3*64; year++ {
+		if parsed.Years.Has(year) {
+			offset := year - minYear
+			compiled.Years[offset/64] |= 1 << uint(offset%64)
+		}
+	}
+
+	// Relative days are not represented in the field's values, so they are recovered from
+	// the term itself. avail has already validated it.
+	if match := relativeDayRegex.FindStringSubmatch(parsed.Days.Term); match != nil {
+		switch {
+		case strings.EqualFold(match[1], "LW"):
+			compiled.LastWeekday = true
+		case match[3] != "":
+			offset, _ := strconv.Atoi(match[3])
+			compiled.LastOffsets = 1 << uint(offset)
+		default:
+			compiled.LastOffsets = 1
+		}
+	}
+
+	return compiled, nil
+}
+
+// bitmask returns the set of values as a bitmask with bit n set when n is in the set.
+func bitmask(values map[int]struct{}) uint64 {
+	var mask uint64
+	for value := range values {
+		mask |= 1 << uint(value)
+	}
+	return mask
+}
+
+// durationLiteral returns Go source for the duration in terms of the time package's constants.
+// Ex. 90 minutes is "90 * time.Minute".
+func durationLiteral(duration time.Duration) string {
+	switch {
+	case duration == time.Minute:
+		return "time.Minute"
+	case duration == time.Second:
+		return "time.Second"
+	case duration%time.Minute == 0:
+		return fmt.Sprintf("%d * time.Minute", duration/time.Minute)
+	default:
+		return fmt.Sprintf("%d * time.Second", duration/time.Second)
+	}
+}
+
+var sourceTemplate = template.Must(template.New("source").Funcs(template.FuncMap{
+	"duration": durationLiteral,
+	"hex":      func(value interface{}) string { return fmt.Sprintf("%#x", value) },
+	"hasLocation": func(tables []table) bool {
+		for _, table := range tables {
+			if table.Location != "" {
+				return true
+			}
+		}
+		return false
+	},
+}).Parse(`// Code generated by availgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import "time"
+
+// {{.Type}} is a cron expression compiled ahead of time by availgen. Able and Next match the
+// same times as the avail Timeframe it was generated from.
+type {{.Type}} struct {
+	// Expression is the cron expression the {{.Type}} was generated from.
+	Expression string
+
+	location *time.Location
+	interval, precision time.Duration
+
+	seconds, minutes, hours, days, months, weekdays uint64
+	years [3]uint64
+	lastOffsets uint32
+	lastWeekday bool
+}
+{{range .Tables}}
+// {{.Name}} is able during the cron expression {{printf "%q" .Expression}}.
+var {{.Name}} = &{{$.Type}}{
+	Expression: {{printf "%q" .Expression}},
+{{- if .Location}}
+	location: mustLoadLocation{{$.Type}}({{printf "%q" .Location}}),
+{{- end}}
+	precision: {{duration .Precision}},
+{{- if .Interval}}
+	interval: {{duration .Interval}},
+{{- else}}
+	seconds: {{hex .Seconds}},
+	minutes: {{hex .Minutes}},
+	hours: {{hex .Hours}},
+	days: {{hex .Days}},
+	months: {{hex .Months}},
+	weekdays: {{hex .Weekdays}},
+	years: [3]uint64{ {{- hex (index .Years 0)}}, {{hex (index .Years 1)}}, {{hex (index .Years 2) -}} },
+	lastOffsets: {{hex .LastOffsets}},
+	lastWeekday: {{.LastWeekday}},
+{{- end}}
+}
+{{end}}
+// Able will evaluate if the time given is within the cron expression.
+func (s *{{.Type}}) Able(t time.Time) bool {
+	if s.location != nil {
+		t = t.In(s.location)
+	}
+
+	if s.interval != 0 {
+		return t.Truncate(s.precision).Sub(time.Unix(0, 0))%s.interval == 0
+	}
+
+	if s.precision == time.Second && s.seconds&(1<<uint(t.Second())) == 0 {
+		return false
+	}
+	if s.minutes&(1<<uint(t.Minute())) == 0 || s.hours&(1<<uint(t.Hour())) == 0 {
+		return false
+	}
+	if s.months&(1<<uint(t.Month())) == 0 || !s.matchesYear(t) {
+		return false
+	}
+
+	return s.matchesDay(t)
+}
+
+// Next returns the first time strictly after the time given at which the cron expression is
+// able. The second return value is false if there is no such time within the years an
+// expression can express.
+func (s *{{.Type}}) Next(after time.Time) (time.Time, bool) {
+	if s.location != nil {
+		after = after.In(s.location)
+	}
+	t := after.Truncate(s.precision).Add(s.precision)
+
+	if s.interval != 0 {
+		if remainder := t.Sub(time.Unix(0, 0)) % s.interval; remainder > 0 {
+			t = t.Add(s.interval - remainder)
+		} else if remainder < 0 {
+			t = t.Add(-remainder)
+		}
+		return t, true
+	}
+
+	// Each field which does not match moves the time to the start of its next value, and the
+	// fields are checked again from the year down.
+	for t.Year() < 1970+3*64 {
+		year, month, day := t.Date()
+		location := t.Location()
+
+		var next time.Time
+		switch {
+		case t.Year() < 1970:
+			next = time.Date(1970, time.January, 1, 0, 0, 0, 0, location)
+		case !s.matchesYear(t):
+			next = time.Date(year+1, time.January, 1, 0, 0, 0, 0, location)
+		case s.months&(1<<uint(month)) == 0:
+			next = time.Date(year, month+1, 1, 0, 0, 0, 0, location)
+		case !s.matchesDay(t):
+			next = time.Date(year, month, day+1, 0, 0, 0, 0, location)
+		// Hours and minutes are advanced by adding durations rather than constructing times so
+		// that hours which are repeated when daylight saving time ends are visited.
+		case s.hours&(1<<uint(t.Hour())) == 0:
+			next = t.Add(-time.Duration(t.Second())*time.Second - time.Duration(t.Minute())*time.Minute).Add(time.Hour)
+		case s.minutes&(1<<uint(t.Minute())) == 0:
+			next = t.Add(-time.Duration(t.Second()) * time.Second).Add(time.Minute)
+		case s.precision == time.Second && s.seconds&(1<<uint(t.Second())) == 0:
+			next = t.Add(time.Second)
+		default:
+			return t, true
+		}
+
+		// Times made ambiguous by daylight saving transitions must not move the search backward.
+		if !next.After(t) {
+			next = t.Add(s.precision)
+		}
+		t = next
+	}
+
+	return time.Time{}, false
+}
+
+// matchesYear reports whether the year of the time given matches the expression.
+func (s *{{.Type}}) matchesYear(t time.Time) bool {
+	year := t.Year() - 1970
+	return year >= 0 && year < 3*64 && s.years[year/64]&(1<<uint(year%64)) != 0
+}
+
+// matchesDay reports whether the day of the month and weekday of the time given match the
+// expression.
+func (s *{{.Type}}) matchesDay(t time.Time) bool {
+	if s.weekdays&(1<<uint(t.Weekday())) == 0 {
+		return false
+	}
+
+	if s.days&(1<<uint(t.Day())) != 0 {
+		return true
+	}
+
+	last := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+	if offset := last.Day() - t.Day(); offset < 32 && s.lastOffsets&(1<<uint(offset)) != 0 {
+		return true
+	}
+
+	if s.lastWeekday {
+		for last.Weekday() == time.Saturday || last.Weekday() == time.Sunday {
+			last = last.AddDate(0, 0, -1)
+		}
+		return t.Day() == last.Day()
+	}
+
+	return false
+}
+{{if .Tables | hasLocation}}
+func mustLoadLocation{{.Type}}(name string) *time.Location {
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
+{{end}}`))
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/clintjedwards/avail/v2"
+)
+
+func TestGenerateUnparseable(t *testing.T) {
+	tests := map[string][]string{
+		"no arguments":         {},
+		"missing expression":   {"BusinessHours"},
+		"unexported name":      {"businessHours=* * * * * *"},
+		"duplicate name":       {"Always=* * * * * *", "Always=* * * * * *"},
+		"invalid expression":   {"Never=* * * * *"},
+		"collides with type":   {"Schedule=* * * * * *"},
+		"invalid identifier":   {"9am=0 9 * * * *"},
+		"out of range value":   {"Late=0 25 * * * *"},
+		"invalid interval":     {"Often=@every 1s"},
+		"unknown timezone":     {"Far=CRON_TZ=Nowhere/Special * * * * * *"},
+		"misplaced last value": {"Last=L * * * * *"},
+		"combined expression":  {"Either=0 9 * * * * || 0 10 * * * *"},
+	}
+
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := generate(config{pkg: "main", typeName: "Schedule"}, args)
+			if err == nil {
+				t.Errorf("expected error for arguments %q", args)
+			}
+		})
+	}
+}
+
+// TestGenerate compiles the generated source and checks that it matches the same times as
+// avail across a range of times.
+func TestGenerate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping compilation of generated source in short mode")
+	}
+
+	goBinary, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go binary not found")
+	}
+
+	expressions := []string{
+		"BusinessHours=0 9-17 * * mon-fri *",
+		"Christmas=* * 25 12 * *",
+		"LastDay=30 23 L * * *",
+		"EndOfMonth=0 0 L-3 * * 2021-2023",
+		"Payroll=CRON_TZ=America/New_York 0 9 LW * * *",
+		"Maintenance=@every 90m",
+		"Hashed=H H(0-5) * * * *",
+		"FallBack=CRON_TZ=America/New_York 30 1 * * * *",
+	}
+
+	source, err := generate(config{pkg: "main", typeName: "Schedule"}, expressions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	step := 17 * time.Minute
+	count := 200000
+
+	// Next is checked less often, at times which fall both on and off minute boundaries, and
+	// around the hour New York repeats when daylight saving time ends.
+	nextStep := 7*time.Hour + 13*time.Minute + 31*time.Second
+	nextTimes := []time.Time{}
+	for i := 0; i < 5000; i++ {
+		nextTimes = append(nextTimes, start.Add(time.Duration(i)*nextStep))
+	}
+	nextTimes = append(nextTimes,
+		time.Date(2020, 11, 1, 5, 0, 0, 0, time.UTC),
+		time.Date(2020, 11, 1, 5, 30, 0, 0, time.UTC),
+		time.Date(2020, 11, 1, 5, 45, 0, 0, time.UTC),
+		time.Date(2020, 11, 1, 6, 15, 0, 0, time.UTC),
+		time.Date(2021, 11, 7, 5, 30, 0, 0, time.UTC),
+	)
+
+	nextUnix := []string{}
+	for _, next := range nextTimes {
+		nextUnix = append(nextUnix, strconv.FormatInt(next.Unix(), 10))
+	}
+
+	names := []string{}
+	for _, expression := range expressions {
+		names = append(names, strings.SplitN(expression, "=", 2)[0])
+	}
+
+	harness := fmt.Sprintf(`package main
+
+import (
+	"fmt"
+	"time"
+)
+
+func main() {
+	schedules := []*Schedule{%s}
+	t := time.Unix(%d, 0)
+	for i := 0; i < %d; i++ {
+		for _, schedule := range schedules {
+			if schedule.Able(t) {
+				fmt.Print("1")
+			} else {
+				fmt.Print("0")
+			}
+		}
+		t = t.Add(%d)
+	}
+	fmt.Println()
+
+	for _, unix := range []int64{%s} {
+		for _, schedule := range schedules {
+			next, ok := schedule.Next(time.Unix(unix, 0))
+			if ok {
+				fmt.Print(next.Unix(), " ")
+			} else {
+				fmt.Print("none ")
+			}
+		}
+		fmt.Println()
+	}
+}
+`, strings.Join(names, ", "), start.Unix(), count, step, strings.Join(nextUnix, ", "))
+
+	dir, err := ioutil.TempDir("", "availgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"go.mod":       "module generated\n\ngo 1.14\n",
+		"schedules.go": string(source),
+		"main.go":      harness,
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command(goBinary, "run", ".")
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("could not run generated source: %v; %s", err, stderr.String())
+	}
+
+	timeframes := []avail.Timeframe{}
+	for i, expression := range expressions {
+		timeframe, err := avail.New(strings.SplitN(expression, "=", 2)[1], avail.WithHashKey(names[i]))
+		if err != nil {
+			t.Fatal(err)
+		}
+		timeframes = append(timeframes, timeframe)
+	}
+
+	parts := strings.SplitN(string(output), "\n", 2)
+	if len(parts) != 2 {
+		t.Fatalf("incorrect output; want Able and Next results, got %q", output)
+	}
+	able, nexts := parts[0], strings.Split(strings.TrimSuffix(parts[1], "\n"), "\n")
+
+	if len(able) != count*len(timeframes) {
+		t.Fatalf("incorrect output length; want %d, got %d", count*len(timeframes), len(able))
+	}
+
+	current := start
+	for i := 0; i < count; i++ {
+		for j := range timeframes {
+			want := timeframes[j].Able(current)
+			got := able[i*len(timeframes)+j] == '1'
+			if want != got {
+				t.Fatalf("%s at %s; want %t, got %t", names[j], current, want, got)
+			}
+		}
+		current = current.Add(step)
+	}
+
+	if len(nexts) != len(nextTimes) {
+		t.Fatalf("incorrect Next output length; want %d, got %d", len(nextTimes), len(nexts))
+	}
+
+	for i, current := range nextTimes {
+		got := strings.Fields(nexts[i])
+		for j := range timeframes {
+			want := "none"
+			if next, err := timeframes[j].Next(current); err == nil {
+				want = strconv.FormatInt(next.Unix(), 10)
+			}
+			if want != got[j] {
+				t.Fatalf("%s next after %s; want %s, got %s", names[j], current, want, got[j])
+			}
+		}
+	}
+}
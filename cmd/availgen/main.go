@@ -0,0 +1,54 @@
+// Command availgen compiles cron expressions ahead of time into Go source. The generated code
+// contains only precomputed bit tables and the few comparisons needed to check them, so it
+// needs neither the avail package nor any parsing or regular expressions at runtime. This
+// suits firmware and agents where startup cost and binary size matter.
+//
+// Each argument is a name and an expression separated by "=":
+//
+//    //go:generate availgen -package schedules -o schedules_gen.go "BusinessHours=0 9-17 * * 1-5 *"
+//
+// produces a package level variable BusinessHours whose Able and Next methods match the same
+// times as the avail Timeframe for "0 9-17 * * 1-5 *". All of a package's expressions should be
+// generated by a single invocation, since each generated file declares the same matcher type.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+func main() {
+	pkg := flag.String("package", "main", "the package name of the generated file")
+	typeName := flag.String("type", "Schedule", "the name of the generated matcher type")
+	output := flag.String("o", "", "the file to write to; defaults to stdout")
+	seconds := flag.Bool("seconds", false, "parse expressions with a leading seconds term")
+	optionalYear := flag.Bool("optional-year", false, "allow the trailing year term to be omitted")
+	hashKey := flag.String("hash-key", "", "the key used to resolve H terms; defaults to each expression's name")
+	flag.Parse()
+
+	config := config{
+		pkg:          *pkg,
+		typeName:     *typeName,
+		seconds:      *seconds,
+		optionalYear: *optionalYear,
+		hashKey:      *hashKey,
+	}
+
+	source, err := generate(config, flag.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "availgen: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(source)
+		return
+	}
+
+	if err := ioutil.WriteFile(*output, source, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "availgen: %v\n", err)
+		os.Exit(1)
+	}
+}
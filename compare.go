@@ -0,0 +1,66 @@
+package avail
+
+import "time"
+
+// Comparison reports how a Timeframe and a reference implementation disagree over a range of
+// time. It is returned by CompareAgainst.
+type Comparison struct {
+	// Checked is the number of times that were evaluated.
+	Checked int
+	// Divergences are the spans of time during which the two disagreed, in chronological order.
+	Divergences []Divergence
+}
+
+// Divergence is a span of consecutive times over which a Timeframe and a reference
+// implementation gave the same differing answers. Start is inclusive and End is exclusive.
+type Divergence struct {
+	Start, End time.Time
+	// Avail is the result of the Timeframe's Able over the span.
+	Avail bool
+	// Reference is the result of the reference implementation over the span.
+	Reference bool
+}
+
+// Matches reports whether no divergences were found.
+func (c Comparison) Matches() bool {
+	return len(c.Divergences) == 0
+}
+
+// CompareAgainst evaluates both the Timeframe and the reference implementation at every
+// minute (or second, for expressions with seconds) from the start of the range up to, but not
+// including, its end and reports where they disagree. It is intended to prove parity with
+// another cron evaluator before migrating to avail.
+func (a *Timeframe) CompareAgainst(reference func(time.Time) bool, from, to time.Time) Comparison {
+	precision := a.options.precision()
+	comparison := Comparison{}
+
+	var current *Divergence
+	for t := from.Truncate(precision); t.Before(to); t = t.Add(precision) {
+		if t.Before(from) {
+			continue
+		}
+
+		comparison.Checked++
+
+		able, want := a.Able(t), reference(t)
+		if able == want {
+			current = nil
+			continue
+		}
+
+		if current != nil && current.Avail == able {
+			current.End = t.Add(precision)
+			continue
+		}
+
+		comparison.Divergences = append(comparison.Divergences, Divergence{
+			Start:     t,
+			End:       t.Add(precision),
+			Avail:     able,
+			Reference: want,
+		})
+		current = &comparison.Divergences[len(comparison.Divergences)-1]
+	}
+
+	return comparison
+}
@@ -0,0 +1,49 @@
+package avail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompareAgainst(t *testing.T) {
+	avail, err := New("* 9-16 * * 1-5 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	matching := func(t time.Time) bool {
+		return t.Weekday() != time.Saturday && t.Weekday() != time.Sunday && t.Hour() >= 9 && t.Hour() < 17
+	}
+
+	comparison := avail.CompareAgainst(matching, from, to)
+	if !comparison.Matches() {
+		t.Errorf("comparison should match; got divergences %v", comparison.Divergences)
+	}
+
+	if comparison.Checked != 7*24*60 {
+		t.Errorf("incorrect number of checks; want %d, got %d", 7*24*60, comparison.Checked)
+	}
+
+	// The reference is off by an hour at the end of every weekday.
+	offByOne := func(t time.Time) bool {
+		return t.Weekday() != time.Saturday && t.Weekday() != time.Sunday && t.Hour() >= 9 && t.Hour() < 18
+	}
+
+	comparison = avail.CompareAgainst(offByOne, from, to)
+	if len(comparison.Divergences) != 5 {
+		t.Fatalf("incorrect number of divergences; want 5, got %d", len(comparison.Divergences))
+	}
+
+	want := Divergence{
+		Start:     time.Date(2020, 6, 8, 17, 0, 0, 0, time.UTC),
+		End:       time.Date(2020, 6, 8, 18, 0, 0, 0, time.UTC),
+		Avail:     false,
+		Reference: true,
+	}
+	if comparison.Divergences[0] != want {
+		t.Errorf("incorrect divergence; want %v, got %v", want, comparison.Divergences[0])
+	}
+}
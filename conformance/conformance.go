@@ -0,0 +1,97 @@
+// Package conformance holds test vectors describing which times expressions in each supported
+// dialect match, so that wrappers and alternate evaluators can be validated against the same
+// truth set as avail itself.
+package conformance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/clintjedwards/avail/v2"
+)
+
+// Vector is a single expectation: the expression, written in the dialect, matches the time
+// if and only if Want is set.
+type Vector struct {
+	Dialect    avail.Dialect
+	Expression string
+	Time       time.Time
+	Want       bool
+}
+
+// Schedule is anything which can report whether it matches a time, such as avail.Timeframe.
+type Schedule interface {
+	Able(time.Time) bool
+}
+
+// Parser parses an expression written in the dialect into a Schedule.
+type Parser func(dialect avail.Dialect, expression string) (Schedule, error)
+
+// Failure is a vector which a Schedule did not satisfy.
+type Failure struct {
+	Vector Vector
+	// Got is the result the Schedule returned. It is unset if the expression could not be
+	// parsed.
+	Got bool
+	// Err is set if the expression could not be parsed.
+	Err error
+}
+
+func (f Failure) String() string {
+	if f.Err != nil {
+		return fmt.Sprintf("%s %q: could not parse: %v", f.Vector.Dialect, f.Vector.Expression, f.Err)
+	}
+	return fmt.Sprintf("%s %q at %s: want %t, got %t", f.Vector.Dialect, f.Vector.Expression,
+		f.Vector.Time.Format(time.RFC3339), f.Vector.Want, f.Got)
+}
+
+// Run checks every vector of the given dialects against the Schedules returned by parse and
+// returns the vectors which were not satisfied. If no dialects are given every dialect is run.
+func Run(parse Parser, dialects ...avail.Dialect) []Failure {
+	if len(dialects) == 0 {
+		dialects = Dialects()
+	}
+
+	failures := []Failure{}
+	for _, dialect := range dialects {
+		for _, vector := range Vectors(dialect) {
+			schedule, err := parse(vector.Dialect, vector.Expression)
+			if err != nil {
+				failures = append(failures, Failure{Vector: vector, Err: err})
+				continue
+			}
+
+			if got := schedule.Able(vector.Time); got != vector.Want {
+				failures = append(failures, Failure{Vector: vector, Got: got})
+			}
+		}
+	}
+
+	return failures
+}
+
+// AvailParser parses expressions with avail.New. It can be used to run the vectors against
+// avail itself or wrapped to run them against something built on top of it.
+func AvailParser(dialect avail.Dialect, expression string) (Schedule, error) {
+	timeframe, err := avail.New(expression, avail.WithDialect(dialect))
+	if err != nil {
+		return nil, err
+	}
+	return &timeframe, nil
+}
+
+// Dialects returns the dialects which have vectors.
+func Dialects() []avail.Dialect {
+	return []avail.Dialect{avail.Native, avail.Quartz, avail.AWSEventBridge, avail.NCRONTAB, avail.Crontab}
+}
+
+// Vectors returns a copy of the vectors for the dialect.
+func Vectors(dialect avail.Dialect) []Vector {
+	vectors := []Vector{}
+	for _, vector := range allVectors {
+		if vector.Dialect == dialect {
+			vectors = append(vectors, vector)
+		}
+	}
+	return vectors
+}
@@ -0,0 +1,48 @@
+package conformance
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/clintjedwards/avail/v2"
+)
+
+func TestAvailConforms(t *testing.T) {
+	for _, failure := range Run(AvailParser) {
+		t.Error(failure)
+	}
+}
+
+func TestVectorsCoverEveryDialect(t *testing.T) {
+	for _, dialect := range Dialects() {
+		if len(Vectors(dialect)) == 0 {
+			t.Errorf("dialect %s has no vectors", dialect)
+		}
+	}
+}
+
+// alwaysSchedule matches every time; it is used to check that Run reports failures.
+type alwaysSchedule struct{}
+
+func (alwaysSchedule) Able(time.Time) bool { return true }
+
+func TestRunReportsFailures(t *testing.T) {
+	failures := Run(func(dialect avail.Dialect, expression string) (Schedule, error) {
+		if dialect == avail.Crontab {
+			return nil, fmt.Errorf("unsupported")
+		}
+		return alwaysSchedule{}, nil
+	}, avail.Native, avail.Crontab)
+
+	want := len(Vectors(avail.Crontab))
+	for _, vector := range Vectors(avail.Native) {
+		if !vector.Want {
+			want++
+		}
+	}
+
+	if len(failures) != want {
+		t.Errorf("incorrect number of failures; want %d, got %d", want, len(failures))
+	}
+}
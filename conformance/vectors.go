@@ -0,0 +1,84 @@
+package conformance
+
+import (
+	"time"
+
+	"github.com/clintjedwards/avail/v2"
+)
+
+// at returns the time in UTC.
+func at(year int, month time.Month, day, hour, minute, second int) time.Time {
+	return time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+}
+
+// allVectors holds the vectors of every dialect. The dates used fall on well known weekdays:
+// 2020-06-08 is a Monday and 2020-02-29 a Saturday in a leap year.
+var allVectors = []Vector{
+	// Native
+	{avail.Native, "* * * * * *", at(2020, 6, 8, 12, 0, 0), true},
+	{avail.Native, "0 9 * * 1-5 *", at(2020, 6, 8, 9, 0, 0), true},
+	{avail.Native, "0 9 * * 1-5 *", at(2020, 6, 8, 9, 0, 59), true},
+	{avail.Native, "0 9 * * 1-5 *", at(2020, 6, 7, 9, 0, 0), false},
+	{avail.Native, "0 9 * * MON-FRI *", at(2020, 6, 8, 9, 0, 0), true},
+	{avail.Native, "0,30 * * * * *", at(2020, 6, 8, 9, 30, 0), true},
+	{avail.Native, "0,30 * * * * *", at(2020, 6, 8, 9, 31, 0), false},
+	{avail.Native, "* * 29 2 * *", at(2020, 2, 29, 0, 0, 0), true},
+	{avail.Native, "* * L * * *", at(2020, 2, 29, 23, 59, 0), true},
+	{avail.Native, "* * L * * *", at(2020, 2, 28, 0, 0, 0), false},
+	{avail.Native, "* * L-1 * * *", at(2020, 6, 29, 0, 0, 0), true},
+	{avail.Native, "* * LW * * *", at(2020, 5, 29, 0, 0, 0), true},
+	{avail.Native, "* * LW * * *", at(2020, 5, 31, 0, 0, 0), false},
+	{avail.Native, "* * * * * 2021", at(2020, 12, 31, 23, 59, 0), false},
+	{avail.Native, "@daily", at(2020, 6, 8, 0, 0, 0), true},
+	{avail.Native, "@daily", at(2020, 6, 8, 0, 1, 0), false},
+	{avail.Native, "@every 90m", at(1970, 1, 1, 1, 30, 0), true},
+	{avail.Native, "@every 90m", at(1970, 1, 1, 2, 0, 0), false},
+	{avail.Native, "CRON_TZ=America/New_York 0 9 * * * *", at(2020, 6, 8, 13, 0, 0), true},
+	{avail.Native, "CRON_TZ=America/New_York 0 9 * * * *", at(2020, 1, 8, 14, 0, 0), true},
+	{avail.Native, "CRON_TZ=America/New_York 0 9 * * * *", at(2020, 6, 8, 9, 0, 0), false},
+	{avail.Native, "0 9 * * 1-5 * || 0 10 * * 0,6 *", at(2020, 6, 7, 10, 0, 0), true},
+	{avail.Native, "0 9 * * weekdays *", at(2020, 6, 6, 9, 0, 0), false},
+
+	// Quartz
+	{avail.Quartz, "0 0 12 ? * MON-FRI", at(2020, 6, 8, 12, 0, 0), true},
+	{avail.Quartz, "0 0 12 ? * MON-FRI", at(2020, 6, 8, 12, 0, 1), false},
+	{avail.Quartz, "0 0 12 ? * 1", at(2020, 6, 7, 12, 0, 0), true},
+	{avail.Quartz, "0 0/15 * * * ?", at(2020, 6, 8, 12, 45, 0), true},
+	{avail.Quartz, "0 0/15 * * * ?", at(2020, 6, 8, 12, 50, 0), false},
+	{avail.Quartz, "0 0 22-2 * * ?", at(2020, 6, 8, 1, 0, 0), true},
+	{avail.Quartz, "0 0 12 L * ?", at(2020, 6, 30, 12, 0, 0), true},
+	{avail.Quartz, "0 0 12 LW * ?", at(2020, 5, 29, 12, 0, 0), true},
+	{avail.Quartz, "0 0 12 15W * ?", at(2020, 8, 14, 12, 0, 0), true},
+	{avail.Quartz, "0 0 12 1W * ?", at(2020, 8, 3, 12, 0, 0), true},
+	{avail.Quartz, "0 0 12 1W * ?", at(2020, 8, 1, 12, 0, 0), false},
+	{avail.Quartz, "0 0 12 ? * 6#3", at(2020, 6, 19, 12, 0, 0), true},
+	{avail.Quartz, "0 0 12 ? * 6#3", at(2020, 6, 12, 12, 0, 0), false},
+	{avail.Quartz, "0 0 12 ? * 6L", at(2020, 6, 26, 12, 0, 0), true},
+	{avail.Quartz, "0 0 12 * * ? 2020", at(2021, 6, 26, 12, 0, 0), false},
+
+	// AWS EventBridge
+	{avail.AWSEventBridge, "cron(0 12 * * ? *)", at(2020, 6, 8, 12, 0, 0), true},
+	{avail.AWSEventBridge, "cron(0 12 * * ? *)", at(2020, 6, 8, 12, 0, 30), true},
+	{avail.AWSEventBridge, "cron(0/15 9-17 ? * MON-FRI *)", at(2020, 6, 8, 17, 45, 0), true},
+	{avail.AWSEventBridge, "cron(0/15 9-17 ? * MON-FRI *)", at(2020, 6, 7, 17, 45, 0), false},
+	{avail.AWSEventBridge, "cron(0 8 ? * 2 *)", at(2020, 6, 8, 8, 0, 0), true},
+	{avail.AWSEventBridge, "cron(0 8 ? * 2#1 *)", at(2020, 6, 1, 8, 0, 0), true},
+	{avail.AWSEventBridge, "cron(0 8 ? * 6L *)", at(2020, 6, 26, 8, 0, 0), true},
+	{avail.AWSEventBridge, "cron(0 8 L * ? 2020-2021)", at(2022, 6, 30, 8, 0, 0), false},
+
+	// NCRONTAB
+	{avail.NCRONTAB, "0 */5 * * * *", at(2020, 6, 8, 12, 25, 0), true},
+	{avail.NCRONTAB, "0 */5 * * * *", at(2020, 6, 8, 12, 25, 1), false},
+	{avail.NCRONTAB, "0 30 9 * * 1-5", at(2020, 6, 8, 9, 30, 0), true},
+	{avail.NCRONTAB, "0 30 9 * * 1-5", at(2020, 6, 7, 9, 30, 0), false},
+	{avail.NCRONTAB, "0 0 0 1 Jan,Jul *", at(2020, 7, 1, 0, 0, 0), true},
+
+	// Crontab
+	{avail.Crontab, "*/10 * * * *", at(2020, 6, 8, 12, 20, 0), true},
+	{avail.Crontab, "*/10 * * * *", at(2020, 6, 8, 12, 21, 0), false},
+	{avail.Crontab, "0 9 * * 7", at(2020, 6, 7, 9, 0, 0), true},
+	{avail.Crontab, "0 9 * * 0", at(2020, 6, 7, 9, 0, 0), true},
+	{avail.Crontab, "0 9-11,14-16 * * *", at(2020, 6, 8, 12, 0, 0), false},
+	{avail.Crontab, "0 9 1 * 1", at(2020, 6, 1, 9, 0, 0), true},
+	{avail.Crontab, "0 9 1 * 1", at(2020, 7, 1, 9, 0, 0), false},
+}
@@ -0,0 +1,225 @@
+package avail
+
+import (
+	"time"
+)
+
+// secondsPerDay is the number of seconds in a day without a daylight saving time transition.
+const secondsPerDay = 24 * 60 * 60
+
+// Contains reports whether the Timeframe is able at every time the other is able, such as a
+// requested deploy schedule falling within approved change windows. Ex. "* 9-17 * * 1-5 *"
+// contains "0,30 10-12 * * 1-3 *" but not "0 8 * * 1-5 *". A Timeframe which is never able is
+// contained by every other.
+//
+// Both are compared a day at a time over the years the other can express, taking
+// alternatives and exclusions into account, so the answer holds for all time. Timeframes
+// with intervals, or whose terms are evaluated in different locations, are instead compared
+// over the year following the current time, as given by the Timeframe's clock.
+func (a Timeframe) Contains(other Timeframe) bool {
+	found, ok := findDay(&a, &other, func(own, others secondsOfDay) bool {
+		return !others.subsetOf(own)
+	})
+	if !ok {
+		return a.containsWithinYear(&other)
+	}
+	return !found
+}
+
+// findDay evaluates both Timeframes a day at a time over the years the other can express and
+// reports whether found is true of the seconds at which each is able on any day. The second
+// return value is false if the Timeframes cannot be evaluated a day at a time, because they
+// include intervals or are evaluated in different locations.
+func findDay(a, other *Timeframe, found func(own, others secondsOfDay) bool) (bool, bool) {
+	leaves := []dayLeaf{}
+	if !collectDayLeaves(a, nil, &leaves) {
+		return false, false
+	}
+	ownLeaves := len(leaves)
+	if !collectDayLeaves(other, nil, &leaves) {
+		return false, false
+	}
+	for _, leaf := range leaves {
+		if leaf.location != leaves[0].location {
+			return false, false
+		}
+	}
+
+	evaluator := dayEvaluator{matched: map[*Timeframe]bool{}, seconds: map[*Timeframe]secondsOfDay{}}
+	for _, leaf := range leaves {
+		evaluator.seconds[leaf.timeframe] = leaf.timeframe.secondsOfDay()
+	}
+
+	years := map[int]struct{}{}
+	for _, leaf := range leaves[ownLeaves:] {
+		for year := range leaf.timeframe.ParsedExpression.Years.values() {
+			years[year] = struct{}{}
+		}
+	}
+
+	// Each day is decided by which of the expressions match its date, so days matching the
+	// same expressions as an earlier day need not be evaluated again.
+	checked := map[string]struct{}{}
+	signature := make([]byte, len(leaves))
+	for _, year := range sortedValues(years) {
+		for date := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); date.Year() == year; date = date.AddDate(0, 0, 1) {
+			for i, leaf := range leaves {
+				matched := leaf.timeframe.matchesDate(date)
+				evaluator.matched[leaf.timeframe] = matched
+				signature[i] = 0
+				if matched {
+					signature[i] = 1
+				}
+			}
+
+			if _, ok := checked[string(signature)]; ok {
+				continue
+			}
+			checked[string(signature)] = struct{}{}
+
+			if found(evaluator.evaluate(a), evaluator.evaluate(other)) {
+				return true, true
+			}
+		}
+	}
+
+	return false, true
+}
+
+// containsWithinYear reports whether the Timeframe is able throughout every window of the
+// other within the year following the current time.
+func (a *Timeframe) containsWithinYear(other *Timeframe) bool {
+	start := a.Now()
+	end := start.AddDate(1, 0, 0)
+
+	for _, window := range other.Windows(start, end) {
+		covering := a.Windows(window.Start, window.End)
+		if len(covering) != 1 || !covering[0].Start.Equal(window.Start) || !covering[0].End.Equal(window.End) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dayLeaf is one of the plain expressions a Timeframe is made up of, alongside the name of the
+// location it is evaluated in.
+type dayLeaf struct {
+	timeframe *Timeframe
+	location  string
+}
+
+// collectDayLeaves appends the plain expressions making up the Timeframe to the leaves. It
+// returns false if any is an interval or is bounded by WithActiveBetween, as those cannot be
+// evaluated a day at a time.
+func collectDayLeaves(a *Timeframe, inherited *time.Location, leaves *[]dayLeaf) bool {
+	if a.Interval != 0 || a.options.bounded() {
+		return false
+	}
+
+	location := a.Location
+	if location == nil {
+		location = inherited
+	}
+
+	if a.alternatives != nil {
+		for i := range a.alternatives {
+			if !collectDayLeaves(&a.alternatives[i], location, leaves) {
+				return false
+			}
+		}
+	} else {
+		*leaves = append(*leaves, dayLeaf{timeframe: a, location: locationName(location)})
+	}
+
+	// Exclusions are evaluated in the location of the time given rather than inheriting one.
+	for i := range a.exclusions {
+		if !collectDayLeaves(&a.exclusions[i], nil, leaves) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dayEvaluator evaluates Timeframes on a single date, given which of their plain expressions
+// match it.
+type dayEvaluator struct {
+	matched map[*Timeframe]bool
+	seconds map[*Timeframe]secondsOfDay
+}
+
+// evaluate returns the seconds of the day at which the Timeframe is able.
+func (e *dayEvaluator) evaluate(a *Timeframe) secondsOfDay {
+	able := secondsOfDay{}
+	switch {
+	case a.alternatives != nil:
+		for i := range a.alternatives {
+			able = able.union(e.evaluate(&a.alternatives[i]))
+		}
+	case e.matched[a]:
+		able = e.seconds[a]
+	}
+
+	for i := range a.exclusions {
+		able = able.difference(e.evaluate(&a.exclusions[i]))
+	}
+
+	return able
+}
+
+// secondsOfDay is a set of the seconds since midnight, held as a bitset.
+type secondsOfDay [secondsPerDay / 64]uint64
+
+// secondsOfDay returns the seconds since midnight at which the plain Timeframe is able on the
+// dates it matches. Every second of a matching minute is able in expressions without seconds.
+func (a *Timeframe) secondsOfDay() secondsOfDay {
+	parsed := a.ParsedExpression
+	seconds := parsed.Seconds.Values
+	if !a.options.seconds {
+		seconds = generateSequentialSet(0, 59)
+	}
+
+	set := secondsOfDay{}
+	for hour := range parsed.Hours.Values {
+		for minute := range parsed.Minutes.Values {
+			for second := range seconds {
+				offset := hour*3600 + minute*60 + second
+				set[offset/64] |= 1 << uint(offset%64)
+			}
+		}
+	}
+	return set
+}
+
+func (s secondsOfDay) union(other secondsOfDay) secondsOfDay {
+	for i := range s {
+		s[i] |= other[i]
+	}
+	return s
+}
+
+func (s secondsOfDay) difference(other secondsOfDay) secondsOfDay {
+	for i := range s {
+		s[i] &^= other[i]
+	}
+	return s
+}
+
+func (s secondsOfDay) intersects(other secondsOfDay) bool {
+	for i := range s {
+		if s[i]&other[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s secondsOfDay) subsetOf(other secondsOfDay) bool {
+	for i := range s {
+		if s[i]&^other[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package avail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestContains(t *testing.T) {
+	business := MustNew("* 9-17 * * 1-5 *")
+
+	tests := map[string]struct {
+		timeframe Timeframe
+		other     Timeframe
+		want      bool
+	}{
+		"within":             {business, MustNew("0,30 10-12 * * 1-3 *"), true},
+		"itself":             {business, business, true},
+		"outside hours":      {business, MustNew("0 8 * * 1-5 *"), false},
+		"weekend":            {business, MustNew("0 10 * * 6 *"), false},
+		"superset":           {MustNew("0 10 * * 1-3 *"), business, false},
+		"seconds":            {business, MustNew("30 15 10 * * 1 *", WithSeconds()), true},
+		"seconds outside":    {MustNew("0 0 10 * * * *", WithSeconds()), MustNew("0 10 * * * *"), false},
+		"never":              {business, MustNew("0 10 31 2 * *"), true},
+		"months":             {MustNew("* * * 1-6 * *"), MustNew("0 0 L 2 * *"), true},
+		"weekday dates":      {business, MustNew("0 12 LW * * *"), true},
+		"last day":           {MustNew("0 12 28-31 * * *"), MustNew("0 12 L * * *"), true},
+		"last day outside":   {MustNew("0 12 30,31 * * *"), MustNew("0 12 L * * *"), false},
+		"years":              {MustNew("* * * * * 2020-2030"), MustNew("0 0 * * * 2025"), true},
+		"years outside":      {MustNew("* * * * * 2020-2030"), MustNew("0 0 * * * 2031"), false},
+		"alternatives":       {MustNew("* 9-12 * * * * || * 13-17 * * 1-5 *"), business, true},
+		"alternatives miss":  {MustNew("* 9-12 * * * * || * 13-16 * * 1-5 *"), business, false},
+		"other alternatives": {business, MustNew("0 9 * * 1 * || 0 17 * * 5 *"), true},
+		"exclusion":          {business.Except(MustNew("* 12 * * * *")), MustNew("0 12 * * 1 *"), false},
+		"excluded other":     {business.Except(MustNew("* 12 * * * *")), MustNew("* 11-13 * * 1 *").Except(MustNew("* 12 * * * *")), true},
+		"location":           {MustNew("CRON_TZ=Europe/Berlin * 9-17 * * * *"), MustNew("CRON_TZ=Europe/Berlin 0 10 * * * *"), true},
+		"different location": {MustNew("CRON_TZ=Europe/Berlin * 9-17 * * * *"), MustNew("CRON_TZ=America/New_York 0 12 * * * *"), false},
+		"overlapping zones":  {MustNew("CRON_TZ=Europe/Berlin * 9-17 * * * *"), MustNew("CRON_TZ=Europe/London 0 10 * * * *"), true},
+		"interval":           {MustNew("* * * * * *"), MustNew("@every 90m"), true},
+		"interval outside":   {business, MustNew("@every 90m"), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.timeframe.Contains(tc.other); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestContainsWithinYear(t *testing.T) {
+	clock := WithClock(func() time.Time { return time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC) })
+	changes := MustNew("* * * 1-6 * *", clock)
+
+	if !changes.Contains(MustNew("@every 1h").Except(MustNew("* * * 7-12 * *"))) {
+		t.Errorf("want %t, got %t", true, false)
+	}
+}
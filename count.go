@@ -0,0 +1,134 @@
+package avail
+
+import "time"
+
+// Count returns the number of times within the half-open interval [start, end) at which the
+// Timeframe is able, counting each minute (or each second for expressions with seconds) once.
+//
+// Expressions made up of plain fields are counted a day at a time by multiplying the sizes of
+// their hour, minute and second sets, so counting a decade is fast. Other expressions, and the
+// partial days at either end of the interval, are counted by walking their occurrences.
+func (a *Timeframe) Count(start, end time.Time) int {
+	start, end = a.options.clampActive(start, end)
+	if !start.Before(end) {
+		return 0
+	}
+
+	if a.Interval != 0 && a.alternatives == nil && len(a.exclusions) == 0 {
+		return int(ceilDivide(end.Sub(intervalEpoch), a.Interval) - ceilDivide(start.Sub(intervalEpoch), a.Interval))
+	}
+
+	if a.alternatives != nil || len(a.exclusions) > 0 {
+		return a.countOccurrences(start, end, start.Location())
+	}
+
+	location := start.Location()
+	if a.Location != nil {
+		location = a.Location
+	}
+
+	parsed := a.ParsedExpression
+	perDay := len(parsed.Hours.Values) * len(parsed.Minutes.Values) * a.perMinute()
+
+	count := 0
+
+	day := dayStart(start, location)
+	for day.Before(end) {
+		next := nextDayStart(day, location)
+
+		if a.matchesDate(day) {
+			// Days which are cut short by the interval, or whose length is changed by a daylight
+			// saving time transition, are walked instead.
+			if day.Before(start) || next.After(end) || next.Sub(day) != 24*time.Hour {
+				from, to := day, next
+				if from.Before(start) {
+					from = start
+				}
+				if to.After(end) {
+					to = end
+				}
+				count += a.countOccurrences(from, to, location)
+			} else {
+				count += perDay
+			}
+		}
+
+		day = next
+	}
+
+	return count
+}
+
+// perMinute returns how many times, at the Timeframe's precision, it is able within each minute
+// its plain expression matches.
+func (a *Timeframe) perMinute() int {
+	switch {
+	case a.options.seconds:
+		return len(a.ParsedExpression.Seconds.Values)
+	case a.options.precision() == time.Second:
+		return 60
+	}
+	return 1
+}
+
+// matchesDate reports whether the date's year, month, day and weekday match the expression.
+func (a *Timeframe) matchesDate(date time.Time) bool {
+	parsed := a.ParsedExpression
+
+	if !parsed.Years.Has(date.Year()) {
+		return false
+	}
+	if _, ok := parsed.Months.Values[int(date.Month())]; !ok {
+		return false
+	}
+
+	return a.matchesDay(date)
+}
+
+// matchesDay reports whether the date's day and weekday match the expression, requiring both
+// unless WithDomDowOr was given and both terms are restricted, and whether its holiday
+// provider allows the date.
+func (a *Timeframe) matchesDay(date time.Time) bool {
+	if !a.options.matchesHolidays(date) {
+		return false
+	}
+
+	parsed := &a.ParsedExpression
+	days := parsed.Days.matches(date.Day(), date)
+	weekdays := parsed.Weekdays.matches(int(date.Weekday()), date)
+
+	if a.eitherDay() {
+		return days || weekdays
+	}
+	return days && weekdays
+}
+
+// eitherDay reports whether dates match when they satisfy either the day or weekday term.
+func (a *Timeframe) eitherDay() bool {
+	return a.options.domDowOr && !a.ParsedExpression.Days.isWildcard() && !a.ParsedExpression.Weekdays.isWildcard()
+}
+
+// countOccurrences counts the Timeframe's occurrences within [start, end) by walking them.
+func (a *Timeframe) countOccurrences(start, end time.Time, location *time.Location) int {
+	count := 0
+
+	// Occurrences strictly after the instant before start include one at start itself.
+	current := start.Add(-time.Nanosecond)
+	for {
+		next, err := a.nextFrom(current, location)
+		if err != nil || !next.Before(end) {
+			return count
+		}
+		count++
+		current = next
+	}
+}
+
+// ceilDivide returns the smallest whole number of divisors that is at least the duration.
+func ceilDivide(d, divisor time.Duration) time.Duration {
+	quotient := d / divisor
+	if d%divisor > 0 {
+		quotient++
+	}
+	return quotient
+}
@@ -0,0 +1,61 @@
+package avail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCount(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+		start      time.Time
+		end        time.Time
+		want       int
+	}{
+		"one day":          {"0,30 9-17 * * * *", nil, time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC), time.Date(2020, 6, 9, 0, 0, 0, 0, time.UTC), 18},
+		"partial day":      {"0,30 9-17 * * * *", nil, time.Date(2020, 6, 8, 12, 15, 0, 0, time.UTC), time.Date(2020, 6, 8, 13, 30, 0, 0, time.UTC), 2},
+		"a decade":         {"0 0 * * * *", nil, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), 3653},
+		"weekdays":         {"* * * * 1-5 *", nil, time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC), 5 * 1440},
+		"seconds":          {"0,30 * * * * * *", []Option{WithSeconds()}, time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC), time.Date(2020, 6, 8, 1, 0, 0, 0, time.UTC), 120},
+		"interval":         {"@every 90m", nil, time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(1970, 1, 2, 0, 0, 0, 0, time.UTC), 16},
+		"unaligned":        {"@every 90m", nil, time.Date(1970, 1, 1, 0, 0, 1, 0, time.UTC), time.Date(1970, 1, 1, 3, 0, 1, 0, time.UTC), 2},
+		"combined overlap": {"0 9 * * 1-5 * || 0 9 * * * *", nil, time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC), 7},
+		"fall back":        {"CRON_TZ=America/New_York 30 * * * * *", nil, time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 11, 3, 0, 0, 0, 0, time.UTC), 48},
+		"spring forward": {"CRON_TZ=America/New_York 30 * * * * *", nil, time.Date(2020, 3, 8, 5, 0, 0, 0, time.UTC),
+			time.Date(2020, 3, 9, 4, 0, 0, 0, time.UTC), 23},
+		"empty": {"* * * * * *", nil, time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC), time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC), 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := avail.Count(tc.start, tc.end); got != tc.want {
+				t.Errorf("want %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCountExcept(t *testing.T) {
+	base, err := New("0 9 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	weekends, err := New("* * * * 0,6 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	avail := base.Except(weekends)
+
+	got := avail.Count(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC))
+	if got != 22 {
+		t.Errorf("want %d, got %d", 22, got)
+	}
+}
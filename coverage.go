@@ -0,0 +1,124 @@
+package avail
+
+import "time"
+
+// Coverage returns the fraction of the half-open interval [start, end) during which the
+// Timeframe is able, from 0 to 1. Ex. "* 9-16 * * 1-5 *" is able for 40 of the 168 hours in a
+// week, a coverage of about 0.24.
+//
+// The time able is measured with Count, so expressions made up of plain fields are measured a
+// day at a time from the sizes of their sets rather than by scanning the interval.
+func (a *Timeframe) Coverage(start, end time.Time) float64 {
+	if !start.Before(end) {
+		return 0
+	}
+	return coverageFraction(a.ableDuration(start, end), end.Sub(start))
+}
+
+// WeekdayCoverage returns the fraction of each weekday within [start, end) during which the
+// Timeframe is able. Days are those of the Timeframe's location, or of start's if it has
+// none. Weekdays which do not occur within the interval are left out.
+func (a *Timeframe) WeekdayCoverage(start, end time.Time) map[time.Weekday]float64 {
+	able := map[time.Weekday]time.Duration{}
+	total := map[time.Weekday]time.Duration{}
+
+	location := a.coverageLocation(start)
+	for _, day := range coverageDays(start, end, location) {
+		weekday := day.Start.In(location).Weekday()
+		able[weekday] += a.ableDuration(day.Start, day.End)
+		total[weekday] += day.Duration()
+	}
+
+	coverage := map[time.Weekday]float64{}
+	for weekday, duration := range total {
+		coverage[weekday] = coverageFraction(able[weekday], duration)
+	}
+	return coverage
+}
+
+// HourlyCoverage returns the fraction of each hour of the day within [start, end) during
+// which the Timeframe is able, indexed by hour. Ex. "* 9-16 * * 1-5 *" over a week covers
+// 5/7 of hour 9 and none of hour 8. Hours are those of the Timeframe's location, or of
+// start's if it has none.
+func (a *Timeframe) HourlyCoverage(start, end time.Time) [24]float64 {
+	var able, total [24]time.Duration
+
+	location := a.coverageLocation(start)
+	plain := a.Interval == 0 && a.alternatives == nil && len(a.exclusions) == 0 && !a.options.bounded()
+
+	for _, day := range coverageDays(start, end, location) {
+		local := day.Start.In(location)
+		midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+
+		// Whole days of plain expressions are able for the same time in each of their hours.
+		if plain && local.Equal(midnight) && day.Duration() == 24*time.Hour {
+			if a.matchesDate(local) {
+				perHour := time.Duration(len(a.ParsedExpression.Minutes.Values)*a.perMinute()) * a.options.precision()
+				for hour := range a.ParsedExpression.Hours.Values {
+					able[hour] += perHour
+				}
+			}
+			for hour := range total {
+				total[hour] += time.Hour
+			}
+			continue
+		}
+
+		for from := day.Start; from.Before(day.End); {
+			// The next hour is found by elapsed time, as the wall clock hour after it may not exist
+			// on days with a daylight saving time transition.
+			local := from.In(location)
+			to := from.Add(time.Hour - time.Duration(local.Minute())*time.Minute -
+				time.Duration(local.Second())*time.Second - time.Duration(local.Nanosecond()))
+			if to.After(day.End) {
+				to = day.End
+			}
+
+			able[local.Hour()] += a.ableDuration(from, to)
+			total[local.Hour()] += to.Sub(from)
+			from = to
+		}
+	}
+
+	var coverage [24]float64
+	for hour := range coverage {
+		coverage[hour] = coverageFraction(able[hour], total[hour])
+	}
+	return coverage
+}
+
+// ableDuration returns how long the Timeframe is able within [start, end).
+func (a *Timeframe) ableDuration(start, end time.Time) time.Duration {
+	return time.Duration(a.Count(start, end)) * a.options.precision()
+}
+
+// coverageLocation returns the location whose days and hours coverage is broken down by.
+func (a *Timeframe) coverageLocation(start time.Time) *time.Location {
+	if a.Location != nil {
+		return a.Location
+	}
+	return start.Location()
+}
+
+// coverageDays splits [start, end) at each midnight in the location.
+func coverageDays(start, end time.Time, location *time.Location) []Window {
+	days := []Window{}
+	for from := start; from.Before(end); {
+		to := nextDayStart(from, location)
+		if to.After(end) {
+			to = end
+		}
+
+		days = append(days, Window{Start: from, End: to})
+		from = to
+	}
+	return days
+}
+
+// coverageFraction returns the fraction of the total that is able.
+func coverageFraction(able, total time.Duration) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(able) / float64(total)
+}
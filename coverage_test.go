@@ -0,0 +1,137 @@
+package avail
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCoverage(t *testing.T) {
+	monday := time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+		start, end time.Time
+		want       float64
+	}{
+		"business week": {"* 9-16 * * 1-5 *", nil, monday, monday.AddDate(0, 0, 7), 40.0 / 168},
+		"always":        {"* * * * * *", nil, monday, monday.AddDate(1, 0, 0), 1},
+		"never":         {"0 0 31 2 * *", nil, monday, monday.AddDate(1, 0, 0), 0},
+		"partial hour":  {"* 9 * * * *", nil, monday.Add(9*time.Hour + 30*time.Minute), monday.Add(10*time.Hour + 30*time.Minute), 0.5},
+		"seconds":       {"0-14 * * * * * *", []Option{WithSeconds()}, monday, monday.AddDate(0, 0, 1), 0.25},
+		"interval":      {"@every 2m", nil, monday, monday.AddDate(0, 0, 1), 0.5},
+		"empty":         {"* * * * * *", nil, monday, monday, 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			timeframe := MustNew(tc.expression, tc.opts...)
+			if got := timeframe.Coverage(tc.start, tc.end); math.Abs(got-tc.want) > 1e-9 {
+				t.Errorf("want %f, got %f", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWeekdayCoverage(t *testing.T) {
+	monday := time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC)
+	timeframe := MustNew("* 9-16 * * 1-5 *")
+
+	got := timeframe.WeekdayCoverage(monday, monday.AddDate(0, 0, 14))
+	want := map[time.Weekday]float64{
+		time.Sunday: 0, time.Monday: 1.0 / 3, time.Tuesday: 1.0 / 3, time.Wednesday: 1.0 / 3,
+		time.Thursday: 1.0 / 3, time.Friday: 1.0 / 3, time.Saturday: 0,
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("want %d weekdays, got %d", len(want), len(got))
+	}
+	for weekday, coverage := range want {
+		if math.Abs(got[weekday]-coverage) > 1e-9 {
+			t.Errorf("%s: want %f, got %f", weekday, coverage, got[weekday])
+		}
+	}
+
+	partial := timeframe.WeekdayCoverage(monday, monday.AddDate(0, 0, 2))
+	if len(partial) != 2 {
+		t.Errorf("want %d weekdays, got %d", 2, len(partial))
+	}
+}
+
+func TestHourlyCoverage(t *testing.T) {
+	monday := time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC)
+	timeframe := MustNew("0,30 9-16 * * 1-5 *")
+	got := timeframe.HourlyCoverage(monday, monday.AddDate(0, 0, 7))
+
+	for hour, coverage := range got {
+		want := 0.0
+		if hour >= 9 && hour <= 16 {
+			want = 5.0 / 7 * 2 / 60
+		}
+		if math.Abs(coverage-want) > 1e-9 {
+			t.Errorf("hour %d: want %f, got %f", hour, want, coverage)
+		}
+	}
+}
+
+// TestCoverageMatchesAble checks the coverage of expressions which are measured by walking
+// them, over days with a daylight saving time transition, against checking every minute.
+func TestCoverageMatchesAble(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2020, 3, 7, 5, 30, 0, 0, newYork)
+	end := time.Date(2020, 3, 10, 14, 0, 0, 0, newYork)
+
+	timeframes := map[string]Timeframe{
+		"plain":     MustNew("CRON_TZ=America/New_York * 1-3 * * * *"),
+		"exclusion": MustNew("CRON_TZ=America/New_York * 1-3 * * * *").Except(MustNew("* 2 * * * *")),
+		"union":     MustNew("CRON_TZ=America/New_York 0,30 * * * * * || CRON_TZ=America/New_York * 2 * * * *"),
+	}
+
+	for name, timeframe := range timeframes {
+		t.Run(name, func(t *testing.T) {
+			var able, total [24]int
+			weekdayAble, weekdayTotal := map[time.Weekday]int{}, map[time.Weekday]int{}
+			for minute := start; minute.Before(end); minute = minute.Add(time.Minute) {
+				local := minute.In(newYork)
+				total[local.Hour()]++
+				weekdayTotal[local.Weekday()]++
+				if timeframe.Able(minute) {
+					able[local.Hour()]++
+					weekdayAble[local.Weekday()]++
+				}
+			}
+
+			sum := 0
+			for _, count := range able {
+				sum += count
+			}
+			want := float64(sum) / end.Sub(start).Minutes()
+			if got := timeframe.Coverage(start, end); math.Abs(got-want) > 1e-9 {
+				t.Errorf("want %f, got %f", want, got)
+			}
+
+			hourly := timeframe.HourlyCoverage(start, end)
+			for hour := range hourly {
+				want := 0.0
+				if total[hour] > 0 {
+					want = float64(able[hour]) / float64(total[hour])
+				}
+				if math.Abs(hourly[hour]-want) > 1e-9 {
+					t.Errorf("hour %d: want %f, got %f", hour, want, hourly[hour])
+				}
+			}
+
+			for weekday, coverage := range timeframe.WeekdayCoverage(start, end) {
+				want := float64(weekdayAble[weekday]) / float64(weekdayTotal[weekday])
+				if math.Abs(coverage-want) > 1e-9 {
+					t.Errorf("%s: want %f, got %f", weekday, want, coverage)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Package crontab parses crontab files into entries carrying avail Timeframes, so that
+// existing crontabs can be linted and analyzed with avail's semantics.
+//
+// Entries use the Crontab dialect: five schedule terms (or a macro like "@daily") followed
+// by the command. Blank lines and lines beginning with "#" are ignored and lines of the form
+// NAME=value set an environment variable for the entries which follow. Setting CRON_TZ or TZ
+// also evaluates the following entries in that timezone. As in cron itself, entries whose day
+// of month and day of week terms are both restricted run on dates matching either.
+package crontab
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/clintjedwards/avail/v2"
+)
+
+// assignmentRegex matches environment variable assignments. Ex. "MAILTO=ops@example.com".
+var assignmentRegex = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*=\s*(.*)$`)
+
+// locationVariables are the environment variables which set the timezone of later entries.
+var locationVariables = []string{"CRON_TZ", "TZ"}
+
+// Crontab is a parsed crontab file.
+type Crontab struct {
+	Entries []Entry
+	// Environment holds every variable assigned within the file, with later assignments
+	// taking precedence.
+	Environment map[string]string
+}
+
+// Entry is a single schedule and command within a crontab file.
+type Entry struct {
+	// Line is the line number of the entry within the file, starting at 1.
+	Line int
+	// Expression is the schedule of the entry as written. Ex. "*/5 * * * *".
+	Expression string
+	Command    string
+	// Reboot is set for "@reboot" entries, which run at startup rather than on a schedule
+	// and so have an empty Timeframe.
+	Reboot    bool
+	Timeframe avail.Timeframe
+	// Environment holds the variables assigned before the entry.
+	Environment map[string]string
+}
+
+// Parse reads a crontab file. It returns an error, including the offending line number, for
+// the first line which cannot be parsed.
+func Parse(r io.Reader) (*Crontab, error) {
+	crontab := &Crontab{Environment: map[string]string{}}
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		if match := assignmentRegex.FindStringSubmatch(text); match != nil {
+			crontab.Environment[match[1]] = unquote(match[2])
+			continue
+		}
+
+		entry, err := parseEntry(text, crontab.Environment)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse line %d: %w", line, err)
+		}
+		entry.Line = line
+		crontab.Entries = append(crontab.Entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return crontab, nil
+}
+
+// parseEntry parses a line containing a schedule and a command.
+func parseEntry(text string, environment map[string]string) (Entry, error) {
+	termCount := 5
+	if strings.HasPrefix(text, "@") {
+		termCount = 1
+	}
+
+	expression, command := splitFields(text, termCount)
+	if command == "" {
+		return Entry{}, fmt.Errorf("entry must have a schedule followed by a command")
+	}
+
+	entry := Entry{
+		Expression:  expression,
+		Command:     command,
+		Environment: copyEnvironment(environment),
+	}
+
+	if strings.EqualFold(expression, "@reboot") {
+		entry.Reboot = true
+		return entry, nil
+	}
+
+	prefix := ""
+	for _, variable := range locationVariables {
+		if zone, ok := environment[variable]; ok && zone != "" {
+			prefix = "CRON_TZ=" + zone + " "
+			break
+		}
+	}
+
+	opts := []avail.Option{avail.WithDialect(avail.Crontab), avail.WithDomDowOr()}
+	if termCount == 1 {
+		// Macros are only understood by the native grammar.
+		opts = opts[1:]
+	}
+
+	timeframe, err := avail.New(prefix+expression, opts...)
+	if err != nil {
+		return Entry{}, err
+	}
+	entry.Timeframe = timeframe
+
+	return entry, nil
+}
+
+// splitFields splits the first count whitespace separated fields from the rest of the text,
+// which is returned with its spacing intact.
+func splitFields(text string, count int) (string, string) {
+	fields := []string{}
+	rest := text
+	for len(fields) < count {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			break
+		}
+
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		if end == -1 {
+			end = len(rest)
+		}
+		fields = append(fields, rest[:end])
+		rest = rest[end:]
+	}
+
+	return strings.Join(fields, " "), strings.TrimSpace(rest)
+}
+
+// unquote removes a single pair of matching quotes from around the value.
+func unquote(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+func copyEnvironment(environment map[string]string) map[string]string {
+	copied := make(map[string]string, len(environment))
+	for name, value := range environment {
+		copied[name] = value
+	}
+	return copied
+}
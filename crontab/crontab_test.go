@@ -0,0 +1,142 @@
+package crontab
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testCrontab = `# Nightly jobs
+MAILTO="ops@example.com"
+SHELL=/bin/bash
+
+*/5 * * * *   /usr/bin/healthcheck --quiet
+0 2 * * 1-5   /usr/bin/backup  > /var/log/backup.log 2>&1
+
+CRON_TZ=America/New_York
+30 9 * * Mon  /usr/bin/report
+@daily        /usr/bin/rotate
+@reboot       /usr/bin/start
+`
+
+func TestParse(t *testing.T) {
+	crontab, err := Parse(strings.NewReader(testCrontab))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type summary struct {
+		Line       int
+		Expression string
+		Command    string
+		Reboot     bool
+	}
+
+	got := []summary{}
+	for _, entry := range crontab.Entries {
+		got = append(got, summary{entry.Line, entry.Expression, entry.Command, entry.Reboot})
+	}
+
+	want := []summary{
+		{5, "*/5 * * * *", "/usr/bin/healthcheck --quiet", false},
+		{6, "0 2 * * 1-5", "/usr/bin/backup  > /var/log/backup.log 2>&1", false},
+		{9, "30 9 * * Mon", "/usr/bin/report", false},
+		{10, "@daily", "/usr/bin/rotate", false},
+		{11, "@reboot", "/usr/bin/start", true},
+	}
+
+	diff := cmp.Diff(want, got)
+	if diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+
+	wantEnvironment := map[string]string{
+		"MAILTO":  "ops@example.com",
+		"SHELL":   "/bin/bash",
+		"CRON_TZ": "America/New_York",
+	}
+
+	diff = cmp.Diff(wantEnvironment, crontab.Environment)
+	if diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+
+	if _, ok := crontab.Entries[0].Environment["CRON_TZ"]; ok {
+		t.Error("entries should only see variables assigned before them")
+	}
+}
+
+func TestParseTimeframes(t *testing.T) {
+	crontab, err := Parse(strings.NewReader(testCrontab))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		entry int
+		time  time.Time
+		want  bool
+	}{
+		"step":                  {0, time.Date(2020, 6, 8, 12, 35, 0, 0, time.UTC), true},
+		"step miss":             {0, time.Date(2020, 6, 8, 12, 36, 0, 0, time.UTC), false},
+		"weekday range":         {1, time.Date(2020, 6, 8, 2, 0, 0, 0, time.UTC), true},
+		"weekday range miss":    {1, time.Date(2020, 6, 7, 2, 0, 0, 0, time.UTC), false},
+		"timezone":              {2, time.Date(2020, 6, 8, 13, 30, 0, 0, time.UTC), true},
+		"timezone miss":         {2, time.Date(2020, 6, 8, 9, 30, 0, 0, time.UTC), false},
+		"macro in the timezone": {3, time.Date(2020, 6, 8, 4, 0, 0, 0, time.UTC), true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			timeframe := crontab.Entries[tc.entry].Timeframe
+			if timeframe.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+}
+
+func TestParseSundayAsSeven(t *testing.T) {
+	crontab, err := Parse(strings.NewReader("0 9 * * 7 /usr/bin/weekly\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !crontab.Entries[0].Timeframe.Able(time.Date(2020, 6, 7, 9, 0, 0, 0, time.UTC)) {
+		t.Error("7 should be treated as Sunday")
+	}
+}
+
+func TestParseDayOrWeekday(t *testing.T) {
+	crontab, err := Parse(strings.NewReader("0 9 1 * 1 /usr/bin/report\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timeframe := crontab.Entries[0].Timeframe
+	if !timeframe.Able(time.Date(2020, 6, 1, 9, 0, 0, 0, time.UTC)) || !timeframe.Able(time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC)) {
+		t.Error("entries should run on dates matching either the day or weekday term")
+	}
+	if timeframe.Able(time.Date(2020, 6, 9, 9, 0, 0, 0, time.UTC)) {
+		t.Error("entries should not run on dates matching neither the day nor weekday term")
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := map[string]string{
+		"missing command":  "0 9 * * *\n",
+		"invalid schedule": "0 25 * * * /usr/bin/late\n",
+		"too few terms":    "0 9 * /usr/bin/short\n",
+		"unknown timezone": "CRON_TZ=Nowhere/Special\n0 9 * * * /usr/bin/lost\n",
+	}
+
+	for name, file := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(file)); err == nil {
+				t.Errorf("expected error for crontab %q", file)
+			}
+		})
+	}
+}
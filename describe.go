@@ -0,0 +1,425 @@
+package avail
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// maxListedTimes is the largest number of times of day Describe will list individually. Ex.
+// "At 09:00, 12:00 and 17:00". Beyond it the minutes and hours are described separately.
+const maxListedTimes = 6
+
+// Describe returns a summary of the Timeframe in English. Ex. "0 12 * 1 * *" is described as
+// "At 12:00 every day in January" and "0 */15 9-17 ? * MON-FRI" in the Quartz dialect as
+// "Every 15 minutes during 09:00-17:59 on Monday through Friday".
+//
+// Descriptions are made from the values each term matches rather than how the term was
+// written, so equivalent expressions are described the same way. DescribeIn renders the
+// description in other languages.
+func (a *Timeframe) Describe() string {
+	return a.describe(describer{translations: englishPhrases})
+}
+
+// describer renders the phrases that make up a description using a set of translations,
+// falling back to English for any phrase they do not translate.
+type describer struct {
+	translations Translations
+}
+
+// phrase formats the phrase with the given key.
+func (d describer) phrase(key string, args ...interface{}) string {
+	template, ok := d.translations.Phrase(key)
+	if !ok {
+		template = englishPhrases[key]
+	}
+	return fmt.Sprintf(template, args...)
+}
+
+// list joins the items with the translation's list separators. Ex. "a, b and c".
+func (d describer) list(items []string) string {
+	if len(items) == 1 {
+		return items[0]
+	}
+	return d.phrase("and", strings.Join(items[:len(items)-1], d.phrase("separator")), items[len(items)-1])
+}
+
+// ordinal formats the number as an ordinal. Ex. "2nd".
+func (d describer) ordinal(n int) string {
+	if ordinal, ok := d.translations.Phrase("ordinal." + strconv.Itoa(n)); ok {
+		return ordinal
+	}
+	if template, ok := d.translations.Phrase("ordinal"); ok {
+		return fmt.Sprintf(template, n)
+	}
+	return englishOrdinal(n)
+}
+
+func (d describer) weekday(weekday int) string {
+	return d.phrase("weekday." + strconv.Itoa(weekday))
+}
+
+func (d describer) month(month int) string {
+	return d.phrase("month." + strconv.Itoa(month))
+}
+
+// duration describes the duration in days, hours, minutes and seconds, leaving out those which
+// are zero. Ex. "1 hour 30 minutes".
+func (d describer) duration(duration time.Duration) string {
+	units := []struct {
+		name   string
+		length time.Duration
+	}{
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+		{"second", time.Second},
+	}
+
+	parts := []string{}
+	for _, unit := range units {
+		count := int(duration / unit.length)
+		duration %= unit.length
+
+		switch {
+		case count == 1:
+			parts = append(parts, d.phrase("duration."+unit.name, count))
+		case count > 1:
+			parts = append(parts, d.phrase("durationN."+unit.name, count))
+		}
+	}
+	return strings.Join(parts, d.phrase("durationSeparator"))
+}
+
+func (a *Timeframe) describe(d describer) string {
+	var description string
+	switch {
+	case a.alternatives != nil:
+		description = a.alternatives[0].describe(d)
+		for i := 1; i < len(a.alternatives); i++ {
+			description = d.phrase("or", description, lowerFirst(a.alternatives[i].describe(d)))
+		}
+	case a.Interval != 0:
+		description = d.phrase("interval", d.duration(a.Interval))
+	default:
+		times, listed := a.describeTime(d)
+		description = times + a.describeDate(d, listed)
+
+		switch {
+		case a.options.holidays != nil && a.options.onlyHolidays:
+			description = d.phrase("onlyHolidays", description)
+		case a.options.holidays != nil:
+			description = d.phrase("exceptHolidays", description)
+		}
+	}
+
+	exclusions := []string{}
+	for i := range a.exclusions {
+		exclusion := &a.exclusions[i]
+		if exclusion.coversDays() && exclusion.Location == nil {
+			exclusions = append(exclusions, strings.TrimSpace(exclusion.describeDate(d, false)))
+			continue
+		}
+		exclusions = append(exclusions, lowerFirst(exclusion.describe(d)))
+	}
+	if len(exclusions) > 0 {
+		description = d.phrase("except", description, d.list(exclusions))
+	}
+
+	if a.Location != nil && a.alternatives == nil {
+		description = d.phrase("location", description, a.Location.String())
+	}
+
+	return description
+}
+
+// describeTime describes the times of day the expression matches. It reports whether the
+// times were listed individually, in which case the description reads as "At 09:00".
+func (a *Timeframe) describeTime(d describer) (string, bool) {
+	parsed := a.ParsedExpression
+	seconds := a.options.seconds && !isOnly(parsed.Seconds.Values, 0)
+
+	if times, ok := a.listTimes(); ok {
+		return upperFirst(d.phrase("atTimes", d.list(times))), true
+	}
+
+	secondsPart := ""
+	if seconds {
+		secondsPart = describeUnit(d, parsed.Seconds, "second")
+	}
+
+	minutesPart := describeUnit(d, parsed.Minutes, "minute")
+	if seconds && parsed.Minutes.isWildcard() {
+		minutesPart = ""
+	}
+
+	hoursPart := ""
+	switch start, step, ok := stepOf(parsed.Hours); {
+	case parsed.Hours.isWildcard():
+		// Only particular minutes need the hour spelled out. Ex. "at minute 5 past every hour".
+		if _, _, stepped := stepOf(parsed.Minutes); !parsed.Minutes.isWildcard() && !stepped {
+			hoursPart = d.phrase("pastEveryHour")
+		}
+	case ok:
+		hoursPart = d.phrase("duringEveryNthHour", d.ordinal(step))
+		if start != parsed.Hours.Min {
+			hoursPart = d.phrase("fromTime", hoursPart, formatClockTime(start*60))
+		}
+	default:
+		spans := []string{}
+		for _, run := range valueRuns(parsed.Hours.Values, 0) {
+			spans = append(spans, formatClockTime(run[0]*60)+"-"+formatClockTime(run[1]*60+59))
+		}
+		hoursPart = d.phrase("during", d.list(spans))
+	}
+
+	parts := []string{}
+	for _, part := range []string{minutesPart, hoursPart} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	description := strings.Join(parts, " ")
+
+	if secondsPart != "" {
+		switch {
+		case description == "":
+			description = secondsPart
+		case minutesPart == "":
+			description = secondsPart + " " + description
+		default:
+			description = secondsPart + ", " + description
+		}
+	}
+
+	return upperFirst(description), false
+}
+
+// listTimes returns the times of day the expression matches formatted as clock times, if each
+// of its time terms is limited to a few values.
+func (a *Timeframe) listTimes() ([]string, bool) {
+	parsed := a.ParsedExpression
+
+	seconds := []int{0}
+	if a.options.seconds {
+		seconds = sortedValues(parsed.Seconds.Values)
+	}
+	hours := sortedValues(parsed.Hours.Values)
+	minutes := sortedValues(parsed.Minutes.Values)
+
+	if len(hours)*len(minutes)*len(seconds) > maxListedTimes {
+		return nil, false
+	}
+
+	times := []string{}
+	for _, hour := range hours {
+		for _, minute := range minutes {
+			for _, second := range seconds {
+				clock := formatClockTime(hour*60 + minute)
+				if a.options.seconds && !isOnly(parsed.Seconds.Values, 0) {
+					clock += fmt.Sprintf(":%02d", second)
+				}
+				times = append(times, clock)
+			}
+		}
+	}
+	return times, true
+}
+
+// describeUnit describes the values of a seconds or minutes field. Ex. "every minute",
+// "every 15 minutes" or "at minutes 0 and 30".
+func describeUnit(d describer, field Field, unit string) string {
+	if field.isWildcard() {
+		return d.phrase("every." + unit)
+	}
+
+	if start, step, ok := stepOf(field); ok {
+		description := d.phrase("everyN."+unit, step)
+		if start != field.Min {
+			description = d.phrase("from."+unit, description, start)
+		}
+		return description
+	}
+
+	if len(field.Values) == 1 {
+		return d.phrase("at."+unit, sortedValues(field.Values)[0])
+	}
+	return d.phrase("atList."+unit, describeRuns(d, field.Values, strconv.Itoa, false))
+}
+
+// describeDate describes the dates the expression matches. Each part begins with a space.
+// When everyDay is true and the expression matches every day, " every day" is returned.
+func (a *Timeframe) describeDate(d describer, everyDay bool) string {
+	parsed := a.ParsedExpression
+	description := ""
+
+	days := describeDays(d, parsed.Days)
+	weekdays := describeWeekdays(d, parsed.Weekdays)
+
+	switch {
+	case days == "" && weekdays == "":
+		if everyDay {
+			description += " " + d.phrase("everyDay")
+		}
+	case weekdays == "":
+		description += " " + d.phrase("on", days)
+	case days == "":
+		description += " " + d.phrase("on", weekdays)
+	case a.eitherDay():
+		description += " " + d.phrase("onEither", days, weekdays)
+	default:
+		description += " " + d.phrase("onIf", days, weekdays)
+	}
+
+	if !parsed.Months.isWildcard() {
+		description += " " + d.phrase("in", describeRuns(d, parsed.Months.Values, d.month, true))
+	}
+
+	if !parsed.Years.isWildcard() {
+		description += " " + d.phrase("in", describeRuns(d, parsed.Years.Values, strconv.Itoa, true))
+	}
+
+	return description
+}
+
+// describeDays describes the days of the month matched by the field, or returns an empty
+// string if it matches every day.
+func describeDays(d describer, field Field) string {
+	if field.isWildcard() {
+		return ""
+	}
+
+	items := []string{}
+	if len(field.Values) == 1 {
+		items = append(items, d.phrase("day", sortedValues(field.Values)[0]))
+	} else if len(field.Values) > 1 {
+		items = append(items, d.phrase("days", describeRuns(d, field.Values, strconv.Itoa, false)))
+	}
+
+	for _, relative := range field.relative {
+		items = append(items, describeDayRelative(d, relative))
+	}
+
+	return d.list(items)
+}
+
+// describeWeekdays describes the days of the week matched by the field, or returns an empty
+// string if it matches every day of the week.
+func describeWeekdays(d describer, field Field) string {
+	if field.isWildcard() {
+		return ""
+	}
+
+	items := []string{}
+	if len(field.Values) > 0 {
+		items = append(items, describeRuns(d, field.Values, d.weekday, true))
+	}
+
+	for _, relative := range field.relative {
+		items = append(items, describeDayRelative(d, relative))
+	}
+
+	return d.list(items)
+}
+
+// describeDayRelative describes a day which depends on the month it falls in.
+func describeDayRelative(d describer, relative relativeValue) string {
+	switch relative.kind {
+	case lastWeekday:
+		return d.phrase("lastWeekday")
+	case nearestWeekday:
+		return d.phrase("nearestWeekday", relative.day)
+	case nthDayOfWeek:
+		return d.phrase("nthDayOfWeek", d.phrase("nth."+strconv.Itoa(relative.nth)), d.weekday(int(relative.weekday)))
+	case lastDayOfWeek:
+		return d.phrase("lastDayOfWeek", d.weekday(int(relative.weekday)))
+	}
+
+	if relative.offset == 0 {
+		return d.phrase("lastDay")
+	}
+	return d.phrase("nthToLastDay", d.ordinal(relative.offset+1))
+}
+
+// describeRuns describes a set of values as a list of single values and runs of consecutive
+// values. Ex. "Monday through Friday" or "1-5 and 10". Runs are joined with the "through"
+// phrase when spelled is true and with a dash otherwise.
+func describeRuns(d describer, values map[int]struct{}, name func(int) string, spelled bool) string {
+	items := []string{}
+	for _, run := range valueRuns(values, 0) {
+		switch {
+		case run[0] == run[1]:
+			items = append(items, name(run[0]))
+		case run[0]+1 == run[1]:
+			items = append(items, name(run[0]), name(run[1]))
+		case spelled:
+			items = append(items, d.phrase("through", name(run[0]), name(run[1])))
+		default:
+			items = append(items, name(run[0])+"-"+name(run[1]))
+		}
+	}
+	return d.list(items)
+}
+
+// stepOf reports whether the field's values are evenly spaced throughout its range, as they
+// are for terms like "*/15" or "5/10", returning the first value and the spacing.
+func stepOf(field Field) (int, int, bool) {
+	values := make([]int, 0, len(field.Values))
+	for value := range field.Values {
+		values = append(values, value)
+	}
+	sort.Ints(values)
+
+	if len(field.relative) > 0 || len(values) < 2 {
+		return 0, 0, false
+	}
+
+	step := values[1] - values[0]
+	if step < 2 || values[0]-field.Min >= step || values[len(values)-1]+step <= field.Max {
+		return 0, 0, false
+	}
+
+	for i := 2; i < len(values); i++ {
+		if values[i]-values[i-1] != step {
+			return 0, 0, false
+		}
+	}
+
+	return values[0], step, true
+}
+
+// englishOrdinal returns the number formatted as an English ordinal. Ex. "2nd".
+func englishOrdinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,83 @@
+package avail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+		want       string
+	}{
+		"single time":     {"0 12 * 1 * *", nil, "At 12:00 every day in January"},
+		"wildcard":        {"* * * * * *", nil, "Every minute"},
+		"listed times":    {"0 9,10 * * * *", nil, "At 09:00 and 10:00 every day"},
+		"weekday range":   {"0 9 * * 1-5 *", nil, "At 09:00 on Monday through Friday"},
+		"weekday names":   {"0 9 * * weekends *", nil, "At 09:00 on Sunday and Saturday"},
+		"minute":          {"5 * * * * *", nil, "At minute 5 past every hour"},
+		"minute list":     {"0,1,2,3,20 * * * * *", nil, "At minutes 0-3 and 20 past every hour"},
+		"hour list":       {"* 9,12,15 * * * *", nil, "Every minute during 09:00-09:59, 12:00-12:59 and 15:00-15:59"},
+		"hour step":       {"0 */2 * * *", []Option{WithDialect(Crontab)}, "At minute 0 during every 2nd hour"},
+		"minute step":     {"0 */15 9-17 ? * MON-FRI", []Option{WithDialect(Quartz)}, "Every 15 minutes during 09:00-17:59 on Monday through Friday"},
+		"offset step":     {"0 5/20 * * * ?", []Option{WithDialect(Quartz)}, "Every 20 minutes from minute 5"},
+		"days":            {"0 9 1,15 * * *", nil, "At 09:00 on days 1 and 15 of the month"},
+		"day and weekday": {"0 9 13 * 5 *", nil, "At 09:00 on day 13 of the month if it falls on Friday"},
+		"either day":      {"0 9 13 * 5 *", []Option{WithDomDowOr()}, "At 09:00 on day 13 of the month or on Friday"},
+		"months":          {"0 9 1 1,2,3,6 * *", nil, "At 09:00 on day 1 of the month in January through March and June"},
+		"years":           {"0 9 LW * * 2020", nil, "At 09:00 on the last weekday of the month in 2020"},
+		"last day":        {"0 0 L * * *", nil, "At 00:00 on the last day of the month"},
+		"last day offset": {"0 0 L-3 * * *", nil, "At 00:00 on the 4th to last day of the month"},
+		"nth weekday":     {"0 0 9 ? * 6#2", []Option{WithDialect(Quartz)}, "At 09:00 on the second Friday of the month"},
+		"last weekday":    {"0 0 9 ? * 6L", []Option{WithDialect(Quartz)}, "At 09:00 on the last Friday of the month"},
+		"nearest weekday": {"0 0 9 15W * ?", []Option{WithDialect(Quartz)}, "At 09:00 on the weekday nearest day 15 of the month"},
+		"seconds":         {"30 0 12 * * * *", []Option{WithSeconds()}, "At 12:00:30 every day"},
+		"second step":     {"0/10 * * * * ?", []Option{WithDialect(Quartz)}, "Every 10 seconds"},
+		"interval":        {"@every 1h30m", nil, "Every 1 hour 30 minutes"},
+		"minute interval": {"@every 90m", nil, "Every 1 hour 30 minutes"},
+		"short interval":  {"@every 15m", nil, "Every 15 minutes"},
+		"long interval":   {"@every 49h1m", nil, "Every 2 days 1 hour 1 minute"},
+		"second interval": {"@every 1m30s", []Option{WithGranularity(time.Second)}, "Every 1 minute 30 seconds"},
+		"location":        {"CRON_TZ=America/New_York 0 9 * * * *", nil, "At 09:00 every day (America/New_York)"},
+		"combined": {"0 9 * * 1-5 * || 0 10 * * 0,6 *", nil,
+			"At 09:00 on Monday through Friday, or at 10:00 on Sunday and Saturday"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := avail.Describe(); got != tc.want {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDescribeExcept(t *testing.T) {
+	base, err := New("0 9 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	christmas, err := New("* * 25 12 * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lunch, err := New("* 12 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	avail := base.Except(christmas).Except(lunch)
+
+	want := "At 09:00 every day, except on day 25 of the month in December and every minute during 12:00-12:59"
+	if got := avail.Describe(); got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
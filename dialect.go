@@ -0,0 +1,452 @@
+package avail
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dialect is a cron grammar which New can parse expressions in when given WithDialect.
+type Dialect string
+
+const (
+	// Native is avail's own grammar as described in the package documentation. It is used when
+	// no dialect is given.
+	Native Dialect = "native"
+	// Quartz is the grammar of the Quartz Java scheduler. Expressions have a mandatory seconds
+	// term followed by the minute, hour, day of month, month, day of week and an optional year
+	// term. Exactly one of the day terms must be "?", days of the week run from 1-7 with SUN=1,
+	// and steps (*/15, 5/10), "W" (nearest weekday), "#" (nth day of the week) and "L" in both
+	// day terms are supported.
+	Quartz Dialect = "quartz"
+	// AWSEventBridge is the grammar of Amazon EventBridge (and CloudWatch Events) cron rules.
+	// Expressions have minute, hour, day of month, month, day of week and year terms and may
+	// be wrapped as "cron(...)". Like Quartz, exactly one of the day terms must be "?", days of
+	// the week run from 1-7 with SUN=1 and steps, "W", "#" and "L" are supported.
+	AWSEventBridge Dialect = "aws"
+	// NCRONTAB is the grammar of Azure Functions timer triggers. Expressions have a leading
+	// seconds term followed by the minute, hour, day of month, month and day of week terms.
+	// Days of the week run from 0-6 with SUN=0 and steps are supported, but "?", "L", "W" and
+	// "#" are not.
+	NCRONTAB Dialect = "ncrontab"
+	// Crontab is the grammar of crontab files. Expressions have minute, hour, day of month,
+	// month and day of week terms. Days of the week run from 0-7 where both 0 and 7 are SUN
+	// and steps are supported, but "?", "L", "W" and "#" are not. Like every other dialect, the
+	// day of month and day of week terms must both match unless WithDomDowOr is given, which
+	// crontab files expect.
+	Crontab Dialect = "crontab"
+)
+
+// yearTerm describes whether a dialect's expressions end with a year term.
+type yearTerm int
+
+const (
+	yearNone yearTerm = iota
+	yearOptional
+	yearRequired
+)
+
+// dialectSpec describes how a dialect differs from the native grammar.
+type dialectSpec struct {
+	// seconds reports whether expressions begin with a seconds term.
+	seconds bool
+	year    yearTerm
+	// minYear and maxYear are the earliest and latest years the dialect accepts.
+	minYear, maxYear int
+	// sunday is the value of Sunday in the day of week term; the other days follow it.
+	sunday int
+	// questionMark requires exactly one of the day of month and day of week terms to be "?".
+	questionMark bool
+	// wrapper is the function-like name expressions may be wrapped in. Ex. "cron(...)".
+	wrapper string
+	// relative allows the "L", "W" and "#" terms.
+	relative bool
+	// sundayAsSeven allows Sunday to also be given as the day after Saturday.
+	sundayAsSeven bool
+}
+
+// dialects holds the specification of every supported dialect.
+var dialects = map[Dialect]dialectSpec{
+	Quartz: {
+		seconds:      true,
+		year:         yearOptional,
+		minYear:      1970,
+		maxYear:      2099,
+		sunday:       1,
+		questionMark: true,
+		relative:     true,
+	},
+	AWSEventBridge: {
+		year:         yearRequired,
+		minYear:      1970,
+		maxYear:      2199,
+		sunday:       1,
+		questionMark: true,
+		wrapper:      "cron",
+		relative:     true,
+	},
+	NCRONTAB: {
+		seconds: true,
+		year:    yearNone,
+		minYear: 1970,
+		maxYear: 2100,
+		sunday:  0,
+	},
+	Crontab: {
+		year:          yearNone,
+		minYear:       1970,
+		maxYear:       2100,
+		sunday:        0,
+		sundayAsSeven: true,
+	},
+}
+
+// List of regexs that match the relative terms dialects allow.
+var (
+	nearestWeekdayRegex = regexp.MustCompile(`(?i)^([0-9]+)W$`)
+	lastDayOfWeekRegex  = regexp.MustCompile(`(?i)^([0-9]+)L$`)
+	nthDayOfWeekRegex   = regexp.MustCompile(`^([0-9]+)#([0-9]+)$`)
+)
+
+// WithDialect parses the expression using the grammar of another cron implementation rather
+// than avail's own. Ex. New("0 0 12 ? * MON-FRI", WithDialect(Quartz)). The dialect determines
+// the number of terms, so WithSeconds and WithOptionalYear have no effect and neither macros
+// nor intervals are accepted. Timezone prefixes and combined expressions may still be used.
+func WithDialect(dialect Dialect) Option {
+	return func(o *options) {
+		o.dialect = dialect
+	}
+}
+
+// parseDialect parses an expression written in the given dialect into its native
+// representation.
+func parseDialect(spec dialectSpec, schedule string) (ParsedExpression, error) {
+	offset := 0
+	if spec.wrapper != "" {
+		unwrapped := unwrap(schedule, spec.wrapper)
+		if unwrapped != schedule {
+			offset = len(spec.wrapper) + 1
+		}
+		schedule = unwrapped
+	}
+
+	terms := strings.Fields(schedule)
+
+	termCount := 5
+	if spec.seconds {
+		termCount++
+	}
+
+	switch {
+	case spec.year != yearNone && len(terms) == termCount+1:
+	case spec.year != yearRequired && len(terms) == termCount:
+		terms = append(terms, "*")
+	default:
+		switch spec.year {
+		case yearOptional:
+			return ParsedExpression{}, termCountError(len(terms), termCount, "must have %d or %d terms", termCount, termCount+1)
+		case yearRequired:
+			return ParsedExpression{}, termCountError(len(terms), termCount+1, "must have %d terms", termCount+1)
+		}
+		return ParsedExpression{}, termCountError(len(terms), termCount, "must have %d terms", termCount)
+	}
+
+	parsedExpression := ParsedExpression{}
+
+	if spec.seconds {
+		seconds, err := spec.parseField(second, terms[0], 0, 59)
+		if err != nil {
+			return ParsedExpression{}, locateTerm(err, schedule, 0, offset)
+		}
+		parsedExpression.Seconds = seconds
+		terms = terms[1:]
+	}
+
+	if spec.questionMark && (terms[2] == "?") == (terms[4] == "?") {
+		return ParsedExpression{}, fmt.Errorf("exactly one of the day of month and day of week terms must be ?")
+	}
+
+	fields := []struct {
+		kind     FieldType
+		min, max int
+		field    *Field
+	}{
+		{minute, 0, 59, &parsedExpression.Minutes},
+		{hour, 0, 23, &parsedExpression.Hours},
+		{day, 1, 31, &parsedExpression.Days},
+		{month, 1, 12, &parsedExpression.Months},
+		{weekday, 0, 6, &parsedExpression.Weekdays},
+		{year, spec.minYear, spec.maxYear, &parsedExpression.Years},
+	}
+
+	for i, field := range fields {
+		parsed, err := spec.parseField(field.kind, terms[i], field.min, field.max)
+		if err != nil {
+			if spec.seconds {
+				i++
+			}
+			return ParsedExpression{}, locateTerm(err, schedule, i, offset)
+		}
+		*field.field = parsed
+	}
+
+	return parsedExpression, nil
+}
+
+// unwrap removes the wrapper from around the expression if it is present, ignoring case.
+// Ex. "cron(0 12 * * ? *)" becomes "0 12 * * ? *".
+func unwrap(expression, wrapper string) string {
+	prefix := wrapper + "("
+	if len(expression) < len(prefix) || !strings.EqualFold(expression[:len(prefix)], prefix) ||
+		!strings.HasSuffix(expression, ")") {
+		return expression
+	}
+
+	return expression[len(prefix) : len(expression)-1]
+}
+
+// parseField parses a single term of the dialect into a native field. Min and max are the
+// native bounds of the field.
+func (spec dialectSpec) parseField(kind FieldType, term string, min, max int) (Field, error) {
+	field := Field{
+		Kind: kind,
+		Term: term,
+		Min:  min,
+		Max:  max,
+	}
+
+	if err := spec.parseTerm(&field); err != nil {
+		return Field{}, field.parseError(err)
+	}
+
+	return field, nil
+}
+
+// parseTerm populates the field's values from its term.
+func (spec dialectSpec) parseTerm(f *Field) error {
+	term := spec.resolveNames(f)
+
+	// The day of week term is parsed in the dialect's numbering and shifted afterwards.
+	min, max, shift := f.Min, f.Max, 0
+	if f.Kind == weekday {
+		min, max, shift = spec.sunday, spec.sunday+6, spec.sunday
+		if spec.sundayAsSeven {
+			max++
+		}
+	}
+
+	if term == "*" && f.Kind == year {
+		f.setWildcard()
+		return nil
+	}
+
+	if term == "?" {
+		if !spec.questionMark || (f.Kind != day && f.Kind != weekday) {
+			return reasonf(ErrInvalidTerm, "term ? is only allowed in the day and weekday fields")
+		}
+		f.Values = generateSequentialSet(f.Min, f.Max)
+		return nil
+	}
+
+	switch {
+	case !spec.relative:
+	case f.Kind == day:
+		relative, ok, err := spec.parseRelativeDay(f, term)
+		if err != nil || ok {
+			f.Values, f.relative = map[int]struct{}{}, relative
+			return err
+		}
+	case f.Kind == weekday:
+		relative, ok, err := spec.parseRelativeWeekday(term, min, max)
+		if err != nil || ok {
+			f.Values, f.relative = map[int]struct{}{}, relative
+			return err
+		}
+		// A lone "L" is the last day of the week.
+		if strings.EqualFold(term, "L") {
+			f.Values = map[int]struct{}{int(time.Saturday): {}}
+			return nil
+		}
+	}
+
+	values, err := parseSteppedList(term, min, max)
+	if err != nil {
+		return err
+	}
+
+	f.Values = map[int]struct{}{}
+	for value := range values {
+		value -= shift
+		if f.Kind == weekday {
+			// Only dialects which allow Sunday as seven have values past Saturday.
+			value %= 7
+		}
+		f.Values[value] = struct{}{}
+	}
+
+	return nil
+}
+
+// parseRelativeDay parses the relative terms allowed in the day of month field. It returns
+// false if the term is not relative.
+func (spec dialectSpec) parseRelativeDay(f *Field, term string) ([]relativeValue, bool, error) {
+	switch identifyTermKind(term) {
+	case last:
+		relative, err := f.parseLastField(term)
+		return relative, true, err
+	case lastWeekday:
+		relative, err := f.parseLastWeekdayField(term)
+		return relative, true, err
+	}
+
+	match := nearestWeekdayRegex.FindStringSubmatch(term)
+	if match == nil {
+		return nil, false, nil
+	}
+
+	day, err := strconv.Atoi(match[1])
+	if err != nil {
+		return nil, true, reasonf(ErrInvalidTerm, "could not parse value %s: %v", match[1], err)
+	}
+	if day < f.Min || day > f.Max {
+		return nil, true, reasonf(ErrOutOfBounds, "value(%d) must be within %d-%d", day, f.Min, f.Max)
+	}
+
+	return []relativeValue{{kind: nearestWeekday, day: day}}, true, nil
+}
+
+// parseRelativeWeekday parses the relative terms allowed in the day of week field given the
+// dialect's bounds for it. It returns false if the term is not relative.
+func (spec dialectSpec) parseRelativeWeekday(term string, min, max int) ([]relativeValue, bool, error) {
+	weekdayOf := func(rawValue string) (time.Weekday, error) {
+		value, err := strconv.Atoi(rawValue)
+		if err != nil {
+			return 0, reasonf(ErrInvalidTerm, "could not parse value %s: %v", rawValue, err)
+		}
+		if value < min || value > max {
+			return 0, reasonf(ErrOutOfBounds, "value(%d) must be within %d-%d", value, min, max)
+		}
+		return time.Weekday(value - spec.sunday), nil
+	}
+
+	if match := lastDayOfWeekRegex.FindStringSubmatch(term); match != nil {
+		weekday, err := weekdayOf(match[1])
+		if err != nil {
+			return nil, true, err
+		}
+		return []relativeValue{{kind: lastDayOfWeek, weekday: weekday}}, true, nil
+	}
+
+	if match := nthDayOfWeekRegex.FindStringSubmatch(term); match != nil {
+		weekday, err := weekdayOf(match[1])
+		if err != nil {
+			return nil, true, err
+		}
+
+		nth, err := strconv.Atoi(match[2])
+		if err != nil {
+			return nil, true, reasonf(ErrInvalidTerm, "could not parse value %s: %v", match[2], err)
+		}
+		if nth < 1 || nth > 5 {
+			return nil, true, reasonf(ErrOutOfBounds, "occurrence(%d) must be within 1-5", nth)
+		}
+
+		return []relativeValue{{kind: nthDayOfWeek, weekday: weekday, nth: nth}}, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// resolveNames returns the field's term with any named values replaced by their value in the
+// dialect's numbering.
+func (spec dialectSpec) resolveNames(f *Field) string {
+	names, ok := fieldNames[f.Kind]
+	if !ok {
+		return f.Term
+	}
+
+	shift := 0
+	if f.Kind == weekday {
+		shift = spec.sunday
+	}
+
+	return nameRegex.ReplaceAllStringFunc(f.Term, func(name string) string {
+		value, ok := names[strings.ToLower(name)]
+		if !ok {
+			return name
+		}
+		return strconv.Itoa(value + shift)
+	})
+}
+
+// parseSteppedList parses a comma separated list where each entry is a wildcard, a value or a
+// range, optionally followed by a step. Ex. "*/15", "5/10", "1-5,10-20/2". A value with a step
+// runs until the end of the field and ranges whose start is after their end wrap around.
+func parseSteppedList(term string, min, max int) (map[int]struct{}, error) {
+	values := map[int]struct{}{}
+	size := max - min + 1
+
+	for _, entry := range strings.Split(term, ",") {
+		base, step, hasStep := entry, 1, false
+		if separator := strings.Index(entry, "/"); separator != -1 {
+			base, hasStep = entry[:separator], true
+
+			value, err := strconv.Atoi(entry[separator+1:])
+			if err != nil {
+				return nil, reasonf(ErrInvalidTerm, "could not parse step %s: %v", entry[separator+1:], err)
+			}
+			if value < 1 {
+				return nil, reasonf(ErrInvalidTerm, "step(%d) cannot be less than 1", value)
+			}
+			step = value
+		}
+
+		var start, end int
+		switch {
+		case base == "*":
+			start, end = min, max
+		case strings.Contains(base, "-"):
+			bounds := strings.SplitN(base, "-", 2)
+			first, err := strconv.Atoi(bounds[0])
+			if err != nil {
+				return nil, reasonf(ErrInvalidTerm, "could not parse value %s: %v", bounds[0], err)
+			}
+			second, err := strconv.Atoi(bounds[1])
+			if err != nil {
+				return nil, reasonf(ErrInvalidTerm, "could not parse value %s: %v", bounds[1], err)
+			}
+			start, end = first, second
+		default:
+			value, err := strconv.Atoi(base)
+			if err != nil {
+				return nil, reasonf(ErrInvalidTerm, "could not parse value %s: %v", base, err)
+			}
+			start, end = value, value
+			if hasStep {
+				end = max
+			}
+		}
+
+		for _, value := range []int{start, end} {
+			if value < min {
+				return nil, reasonf(ErrOutOfBounds, "value(%d) cannot be less than min(%d)", value, min)
+			}
+			if value > max {
+				return nil, reasonf(ErrOutOfBounds, "value(%d) cannot be more than max(%d)", value, max)
+			}
+		}
+
+		length := end - start
+		if length < 0 {
+			length += size
+		}
+
+		for i := 0; i <= length; i += step {
+			values[min+(start-min+i)%size] = struct{}{}
+		}
+	}
+
+	return values, nil
+}
@@ -0,0 +1,231 @@
+package avail
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestQuartz(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		time       time.Time
+		want       bool
+	}{
+		"weekdays at noon":        {"0 0 12 ? * MON-FRI", time.Date(2020, 6, 8, 12, 0, 0, 0, time.UTC), true},
+		"weekdays at noon miss":   {"0 0 12 ? * MON-FRI", time.Date(2020, 6, 7, 12, 0, 0, 0, time.UTC), false},
+		"sunday is one":           {"0 0 12 ? * 1", time.Date(2020, 6, 7, 12, 0, 0, 0, time.UTC), true},
+		"saturday is seven":       {"0 0 12 ? * 7", time.Date(2020, 6, 6, 12, 0, 0, 0, time.UTC), true},
+		"seconds are compared":    {"30 0 12 * * ?", time.Date(2020, 6, 8, 12, 0, 0, 0, time.UTC), false},
+		"wildcard step":           {"0 */15 * * * ?", time.Date(2020, 6, 8, 12, 45, 0, 0, time.UTC), true},
+		"wildcard step miss":      {"0 */15 * * * ?", time.Date(2020, 6, 8, 12, 50, 0, 0, time.UTC), false},
+		"offset step":             {"0 5/20 * * * ?", time.Date(2020, 6, 8, 12, 45, 0, 0, time.UTC), true},
+		"range step":              {"0 0 9-17/4 * * ?", time.Date(2020, 6, 8, 17, 0, 0, 0, time.UTC), true},
+		"wrapping range":          {"0 0 22-2 * * ?", time.Date(2020, 6, 8, 1, 0, 0, 0, time.UTC), true},
+		"last day":                {"0 0 12 L * ?", time.Date(2020, 6, 30, 12, 0, 0, 0, time.UTC), true},
+		"last day offset":         {"0 0 12 L-2 * ?", time.Date(2020, 6, 28, 12, 0, 0, 0, time.UTC), true},
+		"last weekday":            {"0 0 12 LW * ?", time.Date(2020, 5, 29, 12, 0, 0, 0, time.UTC), true},
+		"nearest weekday":         {"0 0 12 15W * ?", time.Date(2020, 8, 14, 12, 0, 0, 0, time.UTC), true},
+		"nearest weekday on day":  {"0 0 12 15W * ?", time.Date(2020, 8, 15, 12, 0, 0, 0, time.UTC), false},
+		"nearest weekday forward": {"0 0 12 1W * ?", time.Date(2020, 8, 3, 12, 0, 0, 0, time.UTC), true},
+		"nearest weekday back":    {"0 0 12 31W * ?", time.Date(2020, 5, 29, 12, 0, 0, 0, time.UTC), true},
+		"nearest weekday missing": {"0 0 12 31W * ?", time.Date(2020, 6, 30, 12, 0, 0, 0, time.UTC), false},
+		"nth day of week":         {"0 0 12 ? * 6#3", time.Date(2020, 6, 19, 12, 0, 0, 0, time.UTC), true},
+		"nth day of week miss":    {"0 0 12 ? * 6#3", time.Date(2020, 6, 12, 12, 0, 0, 0, time.UTC), false},
+		"named nth day of week":   {"0 0 12 ? * FRI#3", time.Date(2020, 6, 19, 12, 0, 0, 0, time.UTC), true},
+		"last day of week":        {"0 0 12 ? * 6L", time.Date(2020, 6, 26, 12, 0, 0, 0, time.UTC), true},
+		"last day of week miss":   {"0 0 12 ? * 6L", time.Date(2020, 6, 19, 12, 0, 0, 0, time.UTC), false},
+		"lone last day of week":   {"0 0 12 ? * L", time.Date(2020, 6, 6, 12, 0, 0, 0, time.UTC), true},
+		"named month":             {"0 0 12 1 JAN,jul ?", time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC), true},
+		"year":                    {"0 0 12 * * ? 2020-2022", time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC), false},
+		"timezone prefix": {"CRON_TZ=America/New_York 0 0 9 ? * MON-FRI",
+			time.Date(2020, 6, 8, 13, 0, 0, 0, time.UTC), true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression, WithDialect(Quartz))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if avail.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+}
+
+func TestQuartzUnparseable(t *testing.T) {
+	tests := map[string]string{
+		"missing seconds":         "0 12 ? * MON-FRI",
+		"too many terms":          "0 0 12 ? * MON-FRI 2020 1",
+		"both days given":         "0 0 12 1 * MON",
+		"neither day given":       "0 0 12 ? * ?",
+		"question mark in minute": "0 ? 12 * * ?",
+		"zero weekday":            "0 0 12 ? * 0",
+		"zero step":               "0 */0 * * * ?",
+		"out of range range":      "0 0 12-24 * * ?",
+		"out of range occurrence": "0 0 12 ? * 6#6",
+		"nearest weekday of zero": "0 0 12 0W * ?",
+		"year past max":           "0 0 12 * * ? 2100",
+		"interval":                "@every 1h",
+		"macro":                   "@daily",
+	}
+
+	for name, expression := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := New(expression, WithDialect(Quartz)); err == nil {
+				t.Errorf("expected error for expression %q", expression)
+			}
+		})
+	}
+
+	if _, err := New("* * * * * *", WithDialect("unknown")); err == nil {
+		t.Error("expected error for unknown dialect")
+	}
+}
+
+func TestParseSteppedList(t *testing.T) {
+	tests := map[string]struct {
+		term string
+		want []int
+	}{
+		"value":          {"5", []int{5}},
+		"wildcard step":  {"*/20", []int{0, 20, 40}},
+		"value step":     {"45/5", []int{45, 50, 55}},
+		"range step":     {"10-20/5", []int{10, 15, 20}},
+		"wrapping range": {"58-1", []int{58, 59, 0, 1}},
+		"list":           {"1,5-6,*/30", []int{0, 1, 5, 6, 30}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseSteppedList(tc.term, 0, 59)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := map[int]struct{}{}
+			for _, value := range tc.want {
+				want[value] = struct{}{}
+			}
+
+			diff := cmp.Diff(want, got)
+			if diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAWSEventBridge(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		time       time.Time
+		want       bool
+	}{
+		"wrapped":                {"cron(0 12 * * ? *)", time.Date(2020, 6, 8, 12, 0, 0, 0, time.UTC), true},
+		"unwrapped":              {"0 12 * * ? *", time.Date(2020, 6, 8, 12, 0, 0, 0, time.UTC), true},
+		"seconds are ignored":    {"cron(0 12 * * ? *)", time.Date(2020, 6, 8, 12, 0, 30, 0, time.UTC), true},
+		"weekdays":               {"cron(0/15 9-17 ? * MON-FRI *)", time.Date(2020, 6, 8, 9, 45, 0, 0, time.UTC), true},
+		"weekdays miss":          {"cron(0/15 9-17 ? * MON-FRI *)", time.Date(2020, 6, 7, 9, 45, 0, 0, time.UTC), false},
+		"numbered weekday":       {"cron(0 8 ? * 2 *)", time.Date(2020, 6, 8, 8, 0, 0, 0, time.UTC), true},
+		"last friday":            {"cron(0 8 ? * 6L *)", time.Date(2020, 6, 26, 8, 0, 0, 0, time.UTC), true},
+		"first monday":           {"cron(0 8 ? * 2#1 *)", time.Date(2020, 6, 1, 8, 0, 0, 0, time.UTC), true},
+		"nearest weekday":        {"cron(0 8 1W * ? *)", time.Date(2020, 8, 3, 8, 0, 0, 0, time.UTC), true},
+		"beyond the native year": {"cron(0 8 1 1 ? 2150)", time.Date(2150, 1, 1, 8, 0, 0, 0, time.UTC), true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression, WithDialect(AWSEventBridge))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if avail.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+
+	for _, expression := range []string{"cron(0 12 * * ?)", "cron(0 0 12 * * ? *)", "cron(0 12 1 * MON *)"} {
+		if _, err := New(expression, WithDialect(AWSEventBridge)); err == nil {
+			t.Errorf("expected error for expression %q", expression)
+		}
+	}
+}
+
+func TestNCRONTAB(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		time       time.Time
+		want       bool
+	}{
+		"every five minutes":        {"0 */5 * * * *", time.Date(2020, 6, 8, 12, 25, 0, 0, time.UTC), true},
+		"every five minutes miss":   {"0 */5 * * * *", time.Date(2020, 6, 8, 12, 26, 0, 0, time.UTC), false},
+		"seconds are compared":      {"0 */5 * * * *", time.Date(2020, 6, 8, 12, 25, 10, 0, time.UTC), false},
+		"sunday is zero":            {"0 30 9 * * 0", time.Date(2020, 6, 7, 9, 30, 0, 0, time.UTC), true},
+		"named weekdays":            {"0 30 9 * * Mon-Fri", time.Date(2020, 6, 8, 9, 30, 0, 0, time.UTC), true},
+		"named months":              {"0 0 0 1 Jan,Jul *", time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), true},
+		"both days must match":      {"0 0 0 1 * 1", time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), true},
+		"both days must match miss": {"0 0 0 1 * 1", time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression, WithDialect(NCRONTAB))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if avail.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+
+	for _, expression := range []string{"*/5 * * * *", "0 */5 * * * * 2020", "0 0 12 ? * *", "0 0 12 L * *", "0 0 12 * * 7"} {
+		if _, err := New(expression, WithDialect(NCRONTAB)); err == nil {
+			t.Errorf("expected error for expression %q", expression)
+		}
+	}
+}
+
+func TestCrontab(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		time       time.Time
+		want       bool
+	}{
+		"step":                {"*/10 * * * *", time.Date(2020, 6, 8, 12, 20, 0, 0, time.UTC), true},
+		"seconds are ignored": {"*/10 * * * *", time.Date(2020, 6, 8, 12, 20, 30, 0, time.UTC), true},
+		"sunday as zero":      {"0 9 * * 0", time.Date(2020, 6, 7, 9, 0, 0, 0, time.UTC), true},
+		"sunday as seven":     {"0 9 * * 7", time.Date(2020, 6, 7, 9, 0, 0, 0, time.UTC), true},
+		"range to seven":      {"0 9 * * 5-7", time.Date(2020, 6, 7, 9, 0, 0, 0, time.UTC), true},
+		"range to seven miss": {"0 9 * * 5-7", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), false},
+		"list of ranges":      {"0 9-11,14-16 * * *", time.Date(2020, 6, 8, 15, 0, 0, 0, time.UTC), true},
+		"list of ranges miss": {"0 9-11,14-16 * * *", time.Date(2020, 6, 8, 12, 0, 0, 0, time.UTC), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression, WithDialect(Crontab))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if avail.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+
+	for _, expression := range []string{"0 9 * * * *", "0 9 L * *", "0 9 ? * *", "0 9 * * 8"} {
+		if _, err := New(expression, WithDialect(Crontab)); err == nil {
+			t.Errorf("expected error for expression %q", expression)
+		}
+	}
+}
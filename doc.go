@@ -30,11 +30,94 @@ Avail implements/uses a stripped down version of the cron expression syntax as d
 
     Minutes         0-59            * , -
     Hours           0-23            * , -
-    Day of month    1-31            * , -
+    Day of month    1-31            * , - L LW
     Month           1-12            * , -
     Day of week     0-6             * , -
     Year            1970-2100       * , -
 
+The years allowed can be changed with the WithYearRange option. Ex. WithYearRange(2020, 2030)
+limits the year term to the decade ahead, and WithYearRange(1970, 2500) allows later years.
+
+The following predefined macros may be used in place of a full expression:
+
+    Macro                   Equivalent to
+
+    @yearly (@annually)     0 0 1 1 * *
+    @monthly                0 0 1 * * *
+    @weekly                 0 0 * * 0 *
+    @daily (@midnight)      0 0 * * * *
+    @hourly                 0 * * * * *
+
+Additional macros can be defined for all expressions with RegisterMacro, or for a single
+expression with the WithMacros option. Ex. RegisterMacro("@payday", "0 9 L * * *").
+
+Interval expressions in the form of "@every <duration>" (ex. "@every 1h30m") are also accepted.
+The duration must be a whole number of minutes and matches every time that is a whole multiple
+of the duration since the unix epoch.
+
+Expressions may optionally contain a leading seconds term (0-59) by passing the WithSeconds
+option to New, making them 7 terms long. Ex. "30 0 12 * * * *" matches 12:00:30 every day.
+Passing WithGranularity(time.Second) instead evaluates expressions without a seconds term to
+the second, so occurrences step a second at a time and intervals may be given in seconds.
+
+Standard 5 term crontab expressions can be used by passing the WithOptionalYear option to New,
+which treats an omitted year term as a wildcard.
+
+When both the day of month and day of week terms are restricted a date must match both. Classic
+cron instead matches dates satisfying either, which the WithDomDowOr option enables. Ex. with it
+"0 0 1,15 * 1 *" matches the 1st and 15th of every month as well as every Monday.
+
+Any expression may be prefixed with a timezone in the form of "CRON_TZ=<zone>" or "TZ=<zone>"
+(ex. "CRON_TZ=America/New_York 0 9 * * * *"). Times are then evaluated in that zone regardless
+of the location they were created in. The WithLocation option evaluates expressions without a
+prefix in a location the same way.
+If the zone cannot be loaded, which happens when the zone database is missing, New returns a
+*ZoneError unless a location to use instead is given with WithZoneFallback.
+
+Wall clock times which a daylight saving time transition skips never match and times it repeats
+match twice. The WithDSTPolicy option changes this: DSTFirst moves skipped occurrences to the
+first time after clocks go forward and matches repeated times only once, while DSTBoth moves
+skipped occurrences but matches repeated times both times.
+
+A Timeframe can be limited to a period with the WithActiveBetween option, outside of which it
+is never able. Ex. "0 9 * * 5 *" active between the start of July and the start of October
+2025 occurs every Friday in Q3 2025, after which Next returns ErrNoOccurrence.
+
+Holidays reported by a HolidayProvider can be excluded with the WithHolidaysExcluded option, or
+made the only days a Timeframe is able on with WithHolidaysOnly. NewStaticHolidays provides one
+backed by a list of dates. Ex. "* 9-16 * * 1-5 *" excluding a company's holidays is able during
+business hours on every business day.
+
+Terms may be separated by any amount of whitespace. Months (JAN-DEC) and days of the week
+(SUN-SAT) may be given by name, and names, macros and prefixes are all case-insensitive.
+The day of week field also accepts the keywords "weekdays" (MON-FRI) and "weekends" (SAT,SUN).
+
+The day of month field also accepts "L" for the last day of the month and "L-<offset>" for
+offset days before the last day of the month. Ex. "L-3" in a 31 day month is the 28th.
+"LW" may be used for the last weekday (Monday to Friday) of the month.
+
+Any field may use a hashed "H" term ("H", "H(0-29)", "H/15" or "H(0-29)/10") when a hash key
+is given with the WithHashKey option. The term resolves to a stable value derived from the key,
+allowing many jobs sharing an expression to be spread out according to their names.
+
+Several expressions may be combined by separating them with "||". Ex.
+"0 9 * * 1-5 * || 0 10 * * 6,0 *" is able at 9:00 on weekdays and 10:00 on weekends. A leading
+timezone prefix applies to every expression.
+
+When a term cannot be parsed, the error returned wraps a *ParseError naming the field, the term and
+its position within the expression, so that it can be highlighted without parsing the error text.
+Errors also wrap sentinels such as ErrOutOfBounds and ErrTooFewFields for use with errors.Is.
+
+Expressions written for other cron implementations can be parsed by passing the WithDialect
+option to New. Ex. New("0 0 12 ? * MON-FRI", WithDialect(Quartz)) accepts the full Quartz
+grammar, including its mandatory seconds term, "?", steps, "W", "#" and days of the week
+numbered 1-7 from Sunday. AWSEventBridge accepts EventBridge rules like "cron(0 12 ? * MON-FRI *)" and NCRONTAB
+accepts the seconds first expressions of Azure Functions timer triggers like "0 30 9 * * 1-5".
+Crontab accepts the five term expressions of crontab files, and the crontab subpackage parses
+whole crontab files into entries.
+A Timeframe can be converted between dialects with Format. Ex. the rule above formatted as
+Native is "0 12 * * 1-5 *".
+
 Avail accepts a cron expression in the format above, splits it into separate fields, parses it,
 and generates map backed sets for each field in order to allow speedy checking of value existence.
 
@@ -58,5 +141,18 @@ Then call `able` with a specified go time object.
     fmt.Println(avail.Able(now))
     // Output: true
 
+The next time a timeframe is able can be found with Next, which returns ErrNoOccurrence when
+the expression never matches again within its years. Prev finds the last time it was able.
+
+    next, err := avail.Next(now)
+    prev, err := avail.Prev(now)
+
+Describe summarizes a timeframe in English for display to users. Ex. "0 12 * 1 * *" is described
+as "At 12:00 every day in January". Descriptions in other languages are rendered by DescribeIn from
+translations registered with RegisterLocale.
+
+Schedules can also be written in a small subset of plain English with ParsePhrase. Ex.
+ParsePhrase("every weekday at 9am") is equivalent to New("0 9 * * 1-5 *").
+
 */
 package avail
@@ -0,0 +1,227 @@
+package avail
+
+import "time"
+
+// DSTPolicy determines how a Timeframe treats the wall clock times which daylight saving time
+// transitions in its location skip, when clocks go forward, and repeat, when they go back.
+type DSTPolicy int
+
+const (
+	// DSTWallClock matches times by their wall clock time alone, so times which are skipped
+	// never occur and times which are repeated occur twice. It is the default.
+	DSTWallClock DSTPolicy = iota
+	// DSTFirst moves occurrences which are skipped to the first time after clocks go forward,
+	// and matches times which are repeated only the first time they occur. Ex. in
+	// America/New_York "30 2 * * * *" occurs at 3:00 on the day clocks go forward and
+	// "30 1 * * * *" only at 1:30 EDT on the day they go back.
+	DSTFirst
+	// DSTBoth moves occurrences which are skipped as DSTFirst does, but matches times which are
+	// repeated both times they occur.
+	DSTBoth
+)
+
+// WithDSTPolicy sets how times which are skipped or repeated by daylight saving time
+// transitions are treated by Able, Next, Prev and everything built on them. Intervals are not
+// affected, as they count elapsed time rather than wall clock time.
+func WithDSTPolicy(policy DSTPolicy) Option {
+	return func(o *options) {
+		o.dstPolicy = policy
+	}
+}
+
+// matchesAcrossTransitions evaluates if the time given, which must be in the Timeframe's
+// location, matches the plain expression under its daylight saving time policy.
+func (a *Timeframe) matchesAcrossTransitions(t time.Time) bool {
+	if a.matchesWallClock(t) {
+		return a.options.dstPolicy == DSTBoth || !repeated(t)
+	}
+	return a.matchesSkipped(t)
+}
+
+// matchesSkipped reports whether the time is the first, at the Timeframe's precision, after
+// clocks went forward and the expression matches any of the wall clock times they skipped.
+func (a *Timeframe) matchesSkipped(t time.Time) bool {
+	precision := a.options.precision()
+	previous := t.Add(-precision)
+
+	_, offset := t.Zone()
+	_, previousOffset := previous.Zone()
+	if previousOffset >= offset {
+		return false
+	}
+
+	// The skipped times are walked in UTC, where none of them are skipped.
+	for wall := wallClock(previous).Add(precision); wall.Before(wallClock(t)); wall = wall.Add(precision) {
+		if a.matchesWallClock(wall) {
+			return true
+		}
+	}
+	return false
+}
+
+// repeated reports whether the wall clock time of the time given already occurred earlier,
+// because clocks went back after it.
+func repeated(t time.Time) bool {
+	_, offset := t.Zone()
+	_, earlierOffset := t.Add(-24 * time.Hour).Zone()
+	if earlierOffset <= offset {
+		return false
+	}
+
+	// The same wall clock time under the earlier offset is before the transition if it was
+	// repeated.
+	_, previousOffset := t.Add(-time.Duration(earlierOffset-offset) * time.Second).Zone()
+	return previousOffset == earlierOffset
+}
+
+// wallClock returns the time in UTC with the same wall clock time as the time given.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// nextAcrossTransitions returns the next time the plain expression matches under its daylight
+// saving time policy.
+func (a *Timeframe) nextAcrossTransitions(after time.Time, location *time.Location) (time.Time, error) {
+	for {
+		next, err := a.nextWallClock(after, location)
+
+		limit := next
+		if err != nil {
+			limit = time.Date(a.ParsedExpression.Years.Max+1, time.January, 1, 0, 0, 0, 0, location)
+		}
+		if skipped, ok := a.nextSkipped(after, limit, location); ok {
+			return skipped, nil
+		}
+
+		if err != nil {
+			return time.Time{}, err
+		}
+		if a.options.dstPolicy == DSTFirst && repeated(next) {
+			after = next
+			continue
+		}
+		return next, nil
+	}
+}
+
+// prevAcrossTransitions returns the previous time the plain expression matches under its
+// daylight saving time policy.
+func (a *Timeframe) prevAcrossTransitions(before time.Time, location *time.Location) (time.Time, error) {
+	for {
+		prev, err := a.prevWallClock(before, location)
+
+		limit := prev
+		if err != nil {
+			limit = time.Date(a.ParsedExpression.Years.Min, time.January, 1, 0, 0, 0, 0, location)
+		}
+		if skipped, ok := a.prevSkipped(limit, before, location); ok {
+			return skipped, nil
+		}
+
+		if err != nil {
+			return time.Time{}, err
+		}
+		if a.options.dstPolicy == DSTFirst && repeated(prev) {
+			before = prev
+			continue
+		}
+		return prev, nil
+	}
+}
+
+// nextSkipped returns the earliest time within (after, limit) at which clocks went forward
+// past a time the expression matches.
+func (a *Timeframe) nextSkipped(after, limit time.Time, location *time.Location) (time.Time, bool) {
+	local := after.In(location)
+
+	// Dates are walked in UTC, where each is exactly a day after the one before it.
+	for date := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC); ; date = date.AddDate(0, 0, 1) {
+		transition, ok := forwardTransition(date, location, a.options.precision())
+		if !ok {
+			if !time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location).Before(limit) {
+				return time.Time{}, false
+			}
+			continue
+		}
+		if !transition.Before(limit) {
+			return time.Time{}, false
+		}
+		if transition.After(after) && a.matchesSkipped(transition) {
+			return transition, true
+		}
+	}
+}
+
+// prevSkipped returns the latest time within (limit, before) at which clocks went forward past
+// a time the expression matches.
+func (a *Timeframe) prevSkipped(limit, before time.Time, location *time.Location) (time.Time, bool) {
+	local := before.In(location)
+
+	for date := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC); ; date = date.AddDate(0, 0, -1) {
+		transition, ok := forwardTransition(date, location, a.options.precision())
+		if !ok {
+			if !time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, location).After(limit) {
+				return time.Time{}, false
+			}
+			continue
+		}
+		if !transition.After(limit) {
+			return time.Time{}, false
+		}
+		if transition.Before(before) && a.matchesSkipped(transition) {
+			return transition, true
+		}
+	}
+}
+
+// dayStart returns the first instant of the time's date in the location. It is midnight unless
+// clocks went forward at midnight, in which case the day begins when they did.
+func dayStart(t time.Time, location *time.Location) time.Time {
+	local := t.In(location)
+	return startOfDate(time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC), location)
+}
+
+// nextDayStart returns the first instant of the date after the time's date in the location.
+func nextDayStart(t time.Time, location *time.Location) time.Time {
+	local := t.In(location)
+	return startOfDate(time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, time.UTC), location)
+}
+
+// startOfDate returns the first instant of the date, given in UTC, in the location.
+func startOfDate(date time.Time, location *time.Location) time.Time {
+	// Constructing a midnight which was skipped can give a time on the day before.
+	midnight := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
+	if midnight.Day() != date.Day() {
+		midnight, _ = forwardTransition(date, location, time.Nanosecond)
+	}
+	return midnight
+}
+
+// forwardTransition returns the first time, at the precision given, after clocks in the
+// location went forward on the date. It returns false if they did not.
+func forwardTransition(date time.Time, location *time.Location, precision time.Duration) (time.Time, bool) {
+	// Midnight may itself be skipped, so the day is bounded by the second before each midnight.
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location).Add(-time.Second)
+	end := time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, location).Add(-time.Second)
+
+	_, startOffset := start.Zone()
+	_, endOffset := end.Zone()
+	if endOffset <= startOffset {
+		return time.Time{}, false
+	}
+
+	// The transition is the first instant with the later offset.
+	for end.Sub(start) > time.Nanosecond {
+		middle := start.Add(end.Sub(start) / 2)
+		if _, offset := middle.Zone(); offset == startOffset {
+			start = middle
+		} else {
+			end = middle
+		}
+	}
+
+	if truncated := end.Truncate(precision); !truncated.Equal(end) {
+		end = truncated.Add(precision)
+	}
+	return end.In(location), true
+}
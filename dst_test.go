@@ -0,0 +1,164 @@
+package avail
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDSTPolicy(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		expression string
+		policy     DSTPolicy
+		after      time.Time
+		want       []time.Time
+	}{
+		"skipped wall clock": {"30 2 * * * *", DSTWallClock, time.Date(2020, 3, 7, 12, 0, 0, 0, location), []time.Time{
+			time.Date(2020, 3, 9, 2, 30, 0, 0, location),
+		}},
+		"skipped first": {"30 2 * * * *", DSTFirst, time.Date(2020, 3, 7, 12, 0, 0, 0, location), []time.Time{
+			time.Date(2020, 3, 8, 3, 0, 0, 0, location),
+			time.Date(2020, 3, 9, 2, 30, 0, 0, location),
+		}},
+		"skipped both": {"30 2 * * * *", DSTBoth, time.Date(2020, 3, 7, 12, 0, 0, 0, location), []time.Time{
+			time.Date(2020, 3, 8, 3, 0, 0, 0, location),
+			time.Date(2020, 3, 9, 2, 30, 0, 0, location),
+		}},
+		"skipped and at transition": {"0,30 2,3 * * * *", DSTFirst, time.Date(2020, 3, 8, 1, 0, 0, 0, location), []time.Time{
+			time.Date(2020, 3, 8, 3, 0, 0, 0, location),
+			time.Date(2020, 3, 8, 3, 30, 0, 0, location),
+		}},
+		"skipped only date": {"30 2 8 3 * 2020", DSTFirst, time.Date(2020, 1, 1, 0, 0, 0, 0, location), []time.Time{
+			time.Date(2020, 3, 8, 3, 0, 0, 0, location),
+		}},
+		"repeated wall clock": {"30 1 * * * *", DSTWallClock, time.Date(2020, 10, 31, 12, 0, 0, 0, location), []time.Time{
+			time.Date(2020, 11, 1, 5, 30, 0, 0, time.UTC),
+			time.Date(2020, 11, 1, 6, 30, 0, 0, time.UTC),
+			time.Date(2020, 11, 2, 6, 30, 0, 0, time.UTC),
+		}},
+		"repeated first": {"30 1 * * * *", DSTFirst, time.Date(2020, 10, 31, 12, 0, 0, 0, location), []time.Time{
+			time.Date(2020, 11, 1, 5, 30, 0, 0, time.UTC),
+			time.Date(2020, 11, 2, 6, 30, 0, 0, time.UTC),
+		}},
+		"repeated both": {"30 1 * * * *", DSTBoth, time.Date(2020, 10, 31, 12, 0, 0, 0, location), []time.Time{
+			time.Date(2020, 11, 1, 5, 30, 0, 0, time.UTC),
+			time.Date(2020, 11, 1, 6, 30, 0, 0, time.UTC),
+			time.Date(2020, 11, 2, 6, 30, 0, 0, time.UTC),
+		}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New("CRON_TZ=America/New_York "+tc.expression, WithDSTPolicy(tc.policy))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := avail.NextN(tc.after, len(tc.want))
+			if diff := cmp.Diff(tc.want, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+
+			for _, want := range tc.want {
+				if !avail.Able(want) {
+					t.Errorf("should be able at %s", want)
+				}
+			}
+
+			// Prev walks back through the same occurrences.
+			last := tc.want[len(tc.want)-1]
+			for i := len(tc.want) - 2; i >= 0; i-- {
+				prev, err := avail.Prev(last)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !prev.Equal(tc.want[i]) {
+					t.Errorf("incorrect previous time before %s; want %s, got %s", last, tc.want[i], prev)
+				}
+				last = prev
+			}
+		})
+	}
+}
+
+// TestDSTPolicyBruteForce checks Next, Prev and Count against checking every minute of the
+// days with transitions in several locations.
+func TestDSTPolicyBruteForce(t *testing.T) {
+	days := map[string]time.Time{
+		"new york spring": time.Date(2021, 3, 14, 0, 0, 0, 0, time.UTC),
+		"new york fall":   time.Date(2021, 11, 7, 0, 0, 0, 0, time.UTC),
+		"lord howe":       time.Date(2021, 4, 4, 0, 0, 0, 0, time.UTC),
+		"lord howe fall":  time.Date(2021, 10, 3, 0, 0, 0, 0, time.UTC),
+		"santiago":        time.Date(2021, 9, 5, 0, 0, 0, 0, time.UTC),
+	}
+	zones := map[string]string{
+		"new york spring": "America/New_York",
+		"new york fall":   "America/New_York",
+		"lord howe":       "Australia/Lord_Howe",
+		"lord howe fall":  "Australia/Lord_Howe",
+		"santiago":        "America/Santiago",
+	}
+	expressions := []string{"*/15 * * * *", "30 1,2 * * *", "0 0 * * *", "45 23 * * *", "15 2 * * 0"}
+
+	for name, day := range days {
+		location, err := time.LoadLocation(zones[name])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, expression := range expressions {
+			for _, policy := range []DSTPolicy{DSTWallClock, DSTFirst, DSTBoth} {
+				avail, err := New(expression, WithDialect(Crontab), WithDSTPolicy(policy))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				start := time.Date(day.Year(), day.Month(), day.Day()-1, 12, 0, 0, 0, location)
+				end := start.Add(48 * time.Hour)
+
+				want := []time.Time{}
+				for current := start; current.Before(end); current = current.Add(time.Minute) {
+					if avail.Able(current) {
+						want = append(want, current)
+					}
+				}
+
+				got := []time.Time{}
+				for current := start.Add(-time.Minute); ; {
+					next, err := avail.Next(current)
+					if err != nil || !next.Before(end) {
+						break
+					}
+					got = append(got, next)
+					current = next
+				}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf("%s %q policy %d: next is different than expected(-want +got):\n%s", name, expression, policy, diff)
+				}
+
+				if count := avail.Count(start, end); count != len(want) {
+					t.Errorf("%s %q policy %d: want count %d, got %d", name, expression, policy, len(want), count)
+				}
+
+				if len(want) > 0 {
+					prev, err := avail.Prev(end)
+					if err != nil || !prev.Equal(want[len(want)-1]) {
+						t.Errorf("%s %q policy %d: want prev %s, got %s (%v)", name, expression, policy, want[len(want)-1], prev, err)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDSTPolicyInvalid(t *testing.T) {
+	if _, err := New("* * * * * *", WithDSTPolicy(DSTBoth+1)); err == nil {
+		t.Errorf("unknown policy should not be parsed successfully")
+	}
+}
@@ -0,0 +1,113 @@
+package avail
+
+import "time"
+
+// Equal reports whether the Timeframes match the same times, comparing the values each term
+// resolves to rather than how it was written. Ex. "0-4 * * * * *" and "0,1,2,3,4 * * * * *"
+// are equal, as are expressions in different dialects which resolve to the same values.
+// Locations are compared by name, and alternatives and exclusions must be equal in any order,
+// ignoring duplicates.
+//
+// Timeframes which match the same times through different terms, such as "@every 1h" and
+// "0 * * * * *", are not recognised as equal.
+func (a Timeframe) Equal(other Timeframe) bool {
+	return a.equal(&other)
+}
+
+func (a *Timeframe) equal(other *Timeframe) bool {
+	if locationName(a.Location) != locationName(other.Location) || a.Interval != other.Interval ||
+		!a.options.sameActive(other.options) || !a.options.sameHolidays(other.options) {
+		return false
+	}
+
+	if !equalTimeframes(a.alternatives, other.alternatives) || !equalTimeframes(a.exclusions, other.exclusions) {
+		return false
+	}
+
+	if a.alternatives != nil {
+		return true
+	}
+
+	if a.Interval != 0 {
+		return a.options.precision() == other.options.precision()
+	}
+
+	// Without a seconds term every second of a matching minute is able.
+	seconds, otherSeconds := a.ParsedExpression.Seconds, other.ParsedExpression.Seconds
+	if !a.options.seconds {
+		seconds = Field{Values: generateSequentialSet(0, 59)}
+	}
+	if !other.options.seconds {
+		otherSeconds = Field{Values: generateSequentialSet(0, 59)}
+	}
+
+	parsed, otherParsed := a.ParsedExpression, other.ParsedExpression
+	return seconds.equal(otherSeconds) &&
+		parsed.Minutes.equal(otherParsed.Minutes) &&
+		parsed.Hours.equal(otherParsed.Hours) &&
+		parsed.Days.equal(otherParsed.Days) &&
+		parsed.Months.equal(otherParsed.Months) &&
+		parsed.Weekdays.equal(otherParsed.Weekdays) &&
+		parsed.Years.equal(otherParsed.Years) &&
+		a.eitherDay() == other.eitherDay() &&
+		a.options.dstPolicy == other.options.dstPolicy
+}
+
+// equal reports whether the fields match the same values and relative values.
+func (f Field) equal(other Field) bool {
+	values, otherValues := f.values(), other.values()
+	if len(values) != len(otherValues) {
+		return false
+	}
+	for value := range values {
+		if _, ok := otherValues[value]; !ok {
+			return false
+		}
+	}
+
+	relatives := map[relativeValue]struct{}{}
+	for _, relative := range f.relative {
+		relatives[relative] = struct{}{}
+	}
+	otherRelatives := map[relativeValue]struct{}{}
+	for _, relative := range other.relative {
+		if _, ok := relatives[relative]; !ok {
+			return false
+		}
+		otherRelatives[relative] = struct{}{}
+	}
+
+	return len(relatives) == len(otherRelatives)
+}
+
+// equalTimeframes reports whether each Timeframe has an equal counterpart in the other list
+// and the reverse. Duplicates match the same times, so they are not counted.
+func equalTimeframes(timeframes, others []Timeframe) bool {
+	return (len(timeframes) == 0) == (len(others) == 0) &&
+		containsEqual(timeframes, others) && containsEqual(others, timeframes)
+}
+
+// containsEqual reports whether each of the timeframes is equal to one of the others.
+func containsEqual(timeframes, others []Timeframe) bool {
+	for i := range timeframes {
+		found := false
+		for j := range others {
+			if timeframes[i].equal(&others[j]) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// locationName returns the name of the location, or an empty string if there is none.
+func locationName(location *time.Location) string {
+	if location == nil {
+		return ""
+	}
+	return location.String()
+}
@@ -0,0 +1,69 @@
+package avail
+
+import (
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+		other      string
+		otherOpts  []Option
+		want       bool
+	}{
+		"identical":          {"0 9 * * 1-5 *", nil, "0 9 * * 1-5 *", nil, true},
+		"span and list":      {"0-4 9 * * * *", nil, "0,1,2,3,4 9 * * * *", nil, true},
+		"names":              {"0 9 * JAN-MAR MON-FRI *", nil, "0 9 * 1-3 1-5 *", nil, true},
+		"whitespace":         {"0  9 * * *   *", nil, "0 9 * * * *", nil, true},
+		"macro":              {"@daily", nil, "0 0 * * * *", nil, true},
+		"dialect":            {"0 9 * * 1-5", []Option{WithDialect(Crontab)}, "0 9 * * 1-5 *", nil, true},
+		"implicit seconds":   {"* 0 9 * * * *", []Option{WithSeconds()}, "0 9 * * * *", nil, true},
+		"relative":           {"0 0 L * * *", nil, "0 0 l * * *", nil, true},
+		"alternatives order": {"0 9 * * 1-5 * || 0 10 * * 6,0 *", nil, "0 10 * * 0,6 * || 0 9 * * 1-5 *", nil, true},
+		"location":           {"CRON_TZ=UTC 0 9 * * * *", nil, "TZ=UTC 0 9 * * * *", nil, true},
+		"interval":           {"@every 60m", nil, "@every 1h", nil, true},
+		"different minute":   {"0 9 * * * *", nil, "1 9 * * * *", nil, false},
+		"different location": {"CRON_TZ=UTC 0 9 * * * *", nil, "0 9 * * * *", nil, false},
+		"explicit seconds":   {"0 0 9 * * * *", []Option{WithSeconds()}, "0 9 * * * *", nil, false},
+		"relative offset":    {"0 0 L * * *", nil, "0 0 L-1 * * *", nil, false},
+		"interval precision": {"@every 1h", []Option{WithSeconds()}, "@every 1h", nil, false},
+		"duplicates":         {"0 9 * * * * || 0 9 * * * *", nil, "0 9 * * * * || 0 9 * * * * || 0 9 * * * *", nil, true},
+		"alternative subset": {"0 9 * * * * || 0 10 * * * *", nil, "0 9 * * * *", nil, false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			timeframe, err := New(tc.expression, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			other, err := New(tc.other, tc.otherOpts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := timeframe.Equal(other); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+			if got := other.Equal(timeframe); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestEqualExclusions(t *testing.T) {
+	always := MustNew("* * * * * *")
+	christmas := MustNew("* * 25 12 * *")
+	newYears := MustNew("* * 1 1 * *")
+
+	if !always.Except(christmas).Except(newYears).Equal(always.Except(newYears).Except(christmas)) {
+		t.Errorf("want %t, got %t", true, false)
+	}
+
+	if always.Except(christmas).Equal(always) {
+		t.Errorf("want %t, got %t", false, true)
+	}
+}
@@ -0,0 +1,148 @@
+package avail
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// The errors returned by New and Validate wrap one of these where it applies, so the cause of
+// a failure can be checked with errors.Is.
+var (
+	// ErrTooManyFields is wrapped when an expression has more terms than its grammar allows.
+	ErrTooManyFields = errors.New("too many fields")
+	// ErrTooFewFields is wrapped when an expression has fewer terms than its grammar requires.
+	ErrTooFewFields = errors.New("too few fields")
+	// ErrOutOfBounds is wrapped when a value is outside of the range its field allows.
+	// Ex. minute 60.
+	ErrOutOfBounds = errors.New("value out of bounds")
+	// ErrInvalidTerm is wrapped when a term is malformed or not allowed in its field.
+	ErrInvalidTerm = errors.New("invalid term")
+	// ErrNeverMatches is wrapped when an expression parsed WithRejectImpossible can never match.
+	ErrNeverMatches = errors.New("expression can never match")
+)
+
+// ParseError describes a term of an expression which could not be parsed. The errors New and
+// Validate return wrap it, so that the offending term can be found with errors.As rather than
+// by parsing the error text. Ex.
+//
+//    var parseErr *avail.ParseError
+//    if errors.As(err, &parseErr) {
+//        highlight(parseErr.Pos, len(parseErr.Term))
+//    }
+type ParseError struct {
+	// Field is the name of the field the term belongs to. Ex. "minute".
+	Field string
+	// Term is the term as it was written.
+	Term string
+	// Pos is the byte offset of the term within the expression given to New, or -1 if the term
+	// does not appear in it, as when it was expanded from a macro.
+	Pos int
+	// Reason describes what is wrong with the term and wraps one of the package's sentinel
+	// errors where one applies.
+	Reason error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("could not parse %s: %s", e.Field, e.Reason)
+}
+
+// Unwrap returns the reason, so that errors.Is matches the sentinel error it wraps.
+func (e *ParseError) Unwrap() error {
+	return e.Reason
+}
+
+// reason is an error with its own message which wraps a sentinel error, so errors.Is can
+// match the sentinel without it appearing in the message.
+type reason struct {
+	message  string
+	sentinel error
+}
+
+func (r *reason) Error() string {
+	return r.message
+}
+
+func (r *reason) Unwrap() error {
+	return r.sentinel
+}
+
+// reasonf formats an error message which wraps the sentinel error.
+func reasonf(sentinel error, format string, args ...interface{}) error {
+	return &reason{message: fmt.Sprintf(format, args...), sentinel: sentinel}
+}
+
+// parseError returns a ParseError for the field's term, with its position unknown until the
+// term is located within the expression.
+func (f *Field) parseError(err error) error {
+	return &ParseError{Field: string(f.Kind), Term: f.Term, Pos: -1, Reason: err}
+}
+
+// termCountError returns the error for a schedule with the wrong number of terms.
+func termCountError(got, want int, format string, args ...interface{}) error {
+	if got > want {
+		return reasonf(ErrTooManyFields, format, args...)
+	}
+	return reasonf(ErrTooFewFields, format, args...)
+}
+
+// locateTerm sets the position of the ParseError wrapped by err to that of the term at index
+// among the whitespace separated terms of the schedule, plus the offset. The position is left
+// unknown if that term is not the one which failed, as happens when it came from a macro.
+func locateTerm(err error, schedule string, index, offset int) error {
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		return err
+	}
+
+	positions := termPositions(schedule)
+	if index < len(positions) && strings.HasPrefix(schedule[positions[index]:], parseErr.Term) {
+		parseErr.Pos = positions[index] + offset
+	}
+	return err
+}
+
+// termPositions returns the byte offsets at which each whitespace separated term of the
+// schedule begins.
+func termPositions(schedule string) []int {
+	positions := []int{}
+	inTerm := false
+	for i, r := range schedule {
+		if unicode.IsSpace(r) {
+			inTerm = false
+			continue
+		}
+		if !inTerm {
+			positions = append(positions, i)
+			inTerm = true
+		}
+	}
+	return positions
+}
+
+// shiftPosition moves the known position of the ParseError wrapped by err by the offset, for
+// errors from a part of a larger expression.
+func shiftPosition(err error, offset int) error {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) && parseErr.Pos >= 0 {
+		parseErr.Pos += offset
+	}
+	return err
+}
+
+// clearPosition marks the position of the ParseError wrapped by err as unknown.
+func clearPosition(err error) error {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		parseErr.Pos = -1
+	}
+	return err
+}
+
+// scheduleOffset returns the byte offset of the schedule within the expression it was taken
+// from. The schedule is what remains after the expression is trimmed and any prefix removed.
+func scheduleOffset(expression, schedule string) int {
+	trimmed := strings.TrimRightFunc(expression, unicode.IsSpace)
+	return len(trimmed) - len(schedule)
+}
@@ -0,0 +1,95 @@
+package avail
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+		field      string
+		term       string
+		pos        int
+		sentinel   error
+	}{
+		"value above max":   {"60 * * * * *", nil, "minute", "60", 0, ErrOutOfBounds},
+		"list above max":    {"0 9 * 1,13 * *", nil, "month", "1,13", 6, ErrOutOfBounds},
+		"span out of order": {"0  9-5 * * * *", nil, "hour", "9-5", 3, ErrInvalidTerm},
+		"unknown term":      {"0 9 * * * soon", nil, "year", "soon", 10, ErrInvalidTerm},
+		"last outside day":  {"0 9 * * L *", nil, "weekday", "L", 8, ErrInvalidTerm},
+		"location":          {"CRON_TZ=UTC 0 24 * * * *", nil, "hour", "24", 14, ErrOutOfBounds},
+		"alternative":       {"0 9 * * * * ||  0 9 32 * * *", nil, "day", "32", 20, ErrOutOfBounds},
+		"seconds":           {"0 60 9 * * * *", []Option{WithSeconds()}, "minute", "60", 2, ErrOutOfBounds},
+		"quartz":            {"0 0 9 ? * 9", []Option{WithDialect(Quartz)}, "weekday", "9", 10, ErrOutOfBounds},
+		"aws wrapper":       {"cron(0 25 * * ? *)", []Option{WithDialect(AWSEventBridge)}, "hour", "25", 7, ErrOutOfBounds},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(tc.expression, tc.opts...)
+			if tc.sentinel == nil {
+				return
+			}
+			if err == nil {
+				t.Fatalf("invalid expression should not be parsed successfully")
+			}
+
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("want a ParseError, got %v", err)
+			}
+
+			if parseErr.Field != tc.field {
+				t.Errorf("want %s, got %s", tc.field, parseErr.Field)
+			}
+			if parseErr.Term != tc.term {
+				t.Errorf("want %s, got %s", tc.term, parseErr.Term)
+			}
+			if parseErr.Pos != tc.pos {
+				t.Errorf("want %d, got %d", tc.pos, parseErr.Pos)
+			}
+			if !errors.Is(err, tc.sentinel) {
+				t.Errorf("want %v, got %v", tc.sentinel, err)
+			}
+
+			if validateErr := Validate(tc.expression, tc.opts...); !errors.As(validateErr, &parseErr) || parseErr.Pos != tc.pos {
+				t.Errorf("validate should locate the term at %d, got %v", tc.pos, validateErr)
+			}
+		})
+	}
+}
+
+func TestParseErrorMacroPosition(t *testing.T) {
+	_, err := New("@broken", WithMacros(map[string]string{"@broken": "0 9 * * * 1969"}))
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("want a ParseError, got %v", err)
+	}
+	if parseErr.Pos != -1 {
+		t.Errorf("want %d, got %d", -1, parseErr.Pos)
+	}
+}
+
+func TestTermCountErrors(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+		sentinel   error
+	}{
+		"too few":        {"0 9 * * *", nil, ErrTooFewFields},
+		"too many":       {"0 9 * * * * *", nil, ErrTooManyFields},
+		"quartz too few": {"0 9 * *", []Option{WithDialect(Quartz)}, ErrTooFewFields},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(tc.expression, tc.opts...)
+			if !errors.Is(err, tc.sentinel) {
+				t.Errorf("want %v, got %v", tc.sentinel, err)
+			}
+		})
+	}
+}
@@ -1,11 +1,37 @@
 package avail
 
 import (
-	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
+// fieldNames maps the names that can be used in place of numeric values for certain fields.
+// Names are case-insensitive. Ex. "MON-FRI" in the weekday field is equivalent to "1-5".
+var fieldNames = map[FieldType]map[string]int{
+	month: {
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	},
+	weekday: {
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	},
+}
+
+// fieldKeywords maps the keywords that can be used in place of a set of values for certain
+// fields. Like names, keywords are case-insensitive and may be combined with other values in a
+// list. Ex. "weekends,3" in the weekday field is equivalent to "0,6,3".
+var fieldKeywords = map[FieldType]map[string]string{
+	weekday: {
+		"weekday": "1,2,3,4,5", "weekdays": "1,2,3,4,5",
+		"weekend": "0,6", "weekends": "0,6",
+	},
+}
+
+// nameRegex matches the named values within a term.
+var nameRegex = regexp.MustCompile(`[a-zA-Z]+`)
+
 // Field represents a single value of a cron expression sometimes called a term
 // Ex. in the expression: "0 15 10 * * *", "15" would be a field.
 //
@@ -17,18 +43,28 @@ type Field struct {
 	// Example: kind, min, max values are set manually by the calling function, but almost
 	// never change and as such should be hardcoded somewhere instead and the call to
 	// create a field should just embed the type
-	Kind fieldType
+	Kind FieldType
 	// Term is a single field in a complete cron expression.
 	// Ex. in the expression: "0 15 10 * * *", "15" would be a term.
 	Term     string
 	Min, Max int // The maximum and minimum values for this specific field
 	// Values are sets made with structs because empty structs are 0 bytes.
 	// https://dave.cheney.net/2014/03/25/the-empty-struct
+	// A wildcard year, which can span thousands of years, is kept as its bounds alone and
+	// leaves Values empty; Has checks values of any field.
 	Values map[int]struct{}
+
+	// wildcard is set for a wildcard year, which matches every value from Min to Max without
+	// listing them in Values.
+	wildcard bool
+
+	// relative holds values that depend on the month being evaluated and so cannot be
+	// represented in Values. Ex. "L" (the last day of the month).
+	relative []relativeValue
 }
 
 // newField takes parameters for a given cron term and attempts to parse and returns values for it
-func newField(kind fieldType, term string, min, max int) (Field, error) {
+func newField(kind FieldType, term string, min, max int, options options) (Field, error) {
 	newField := Field{
 		Kind: kind,
 		Term: term,
@@ -36,7 +72,7 @@ func newField(kind fieldType, term string, min, max int) (Field, error) {
 		Max:  max,
 	}
 
-	err := newField.parse()
+	err := newField.parse(options)
 	if err != nil {
 		return Field{}, err
 	}
@@ -46,83 +82,228 @@ func newField(kind fieldType, term string, min, max int) (Field, error) {
 
 // parse returns a representation of the field as a set of values
 // Example: A term of "1-5" will produce "1,2,3,4,5"
-func (f *Field) parse() error {
-	switch identifyTermKind(f.Term) {
+func (f *Field) parse(options options) error {
+	term := f.resolveNames()
+
+	switch identifyTermKind(term) {
 	case wildcard:
-		f.Values = f.parseWildcardField()
+		f.setWildcard()
 		return nil
 	case span:
-		result, err := f.parseSpanField()
+		result, err := f.parseSpanField(term)
 		if err != nil {
-			return fmt.Errorf("could not parse %s: %w", f.Kind, err)
+			return f.parseError(err)
 		}
 		f.Values = result
 		return nil
 	case value:
-		result, err := f.parseValueField()
+		result, err := f.parseValueField(term)
 		if err != nil {
-			return fmt.Errorf("could not parse %s: %w", f.Kind, err)
+			return f.parseError(err)
 		}
 		f.Values = result
 		return nil
 	case list:
-		result, err := f.parseListField()
+		result, err := f.parseListField(term)
 		if err != nil {
-			return fmt.Errorf("could not parse %s: %w", f.Kind, err)
+			return f.parseError(err)
+		}
+		f.Values = result
+		return nil
+	case last:
+		result, err := f.parseLastField(term)
+		if err != nil {
+			return f.parseError(err)
+		}
+		f.Values = map[int]struct{}{}
+		f.relative = result
+		return nil
+	case lastWeekday:
+		result, err := f.parseLastWeekdayField(term)
+		if err != nil {
+			return f.parseError(err)
+		}
+		f.Values = map[int]struct{}{}
+		f.relative = result
+		return nil
+	case hash:
+		result, err := f.parseHashField(term, options.hashKey)
+		if err != nil {
+			return f.parseError(err)
 		}
 		f.Values = result
 		return nil
-	case unknown:
-		return fmt.Errorf("could not parse field: %s; expression: %s", f.Kind, f.Term)
 	}
 
-	return fmt.Errorf("could not parse field: %s; expression: %s", f.Kind, f.Term)
+	return f.parseError(reasonf(ErrInvalidTerm, "unrecognized term %s", f.Term))
 }
 
-func (f *Field) parseWildcardField() map[int]struct{} {
-	return generateSequentialSet(f.Min, f.Max)
-}
+// check returns the error parse would, without building the field's set of values. Relative
+// and hashed terms are parsed, since they match few values.
+func (f *Field) check(options options) error {
+	term := f.resolveNames()
 
-func (f *Field) parseSpanField() (map[int]struct{}, error) {
-	values := strings.Split(f.Term, "-")
+	var err error
+	switch identifyTermKind(term) {
+	case wildcard:
+		return nil
+	case span:
+		_, _, err = f.spanBounds(term)
+	case value:
+		_, err = f.boundedValue(term, term)
+	case list:
+		for _, rawValue := range strings.Split(term, ",") {
+			if _, err = f.boundedValue(rawValue, term); err != nil {
+				break
+			}
+		}
+	default:
+		return f.parse(options)
+	}
 
-	min, err := strconv.Atoi(values[0])
 	if err != nil {
-		return nil, fmt.Errorf("could not parse value %s: %v", values[0], err)
+		return f.parseError(err)
 	}
+	return nil
+}
 
-	max, err := strconv.Atoi(values[1])
-	if err != nil {
-		return nil, fmt.Errorf("could not parse value %s: %v", values[1], err)
+// Has reports whether the value is one of the field's values, including those of a wildcard
+// year, which are not listed in Values. Values relative to the month, such as "L", are not
+// considered.
+func (f *Field) Has(value int) bool {
+	if f.wildcard {
+		return value >= f.Min && value <= f.Max
+	}
+	_, ok := f.Values[value]
+	return ok
+}
+
+// values returns the field's values as a set, listing those of a wildcard year.
+func (f *Field) values() map[int]struct{} {
+	if f.wildcard {
+		return generateSequentialSet(f.Min, f.Max)
 	}
+	return f.Values
+}
 
-	if min >= max {
-		return nil, fmt.Errorf("first value(%d) cannot be greater/equal to second(%d)", min, max)
+// valueAfter returns the smallest of the field's values greater than the value given.
+func (f *Field) valueAfter(value int) (int, bool) {
+	if f.wildcard {
+		if value < f.Min {
+			return f.Min, true
+		}
+		return value + 1, value < f.Max
+	}
+	return nextValue(f.Values, value, f.Max)
+}
+
+// valueBefore returns the largest of the field's values smaller than the value given.
+func (f *Field) valueBefore(value int) (int, bool) {
+	if f.wildcard {
+		if value > f.Max {
+			return f.Max, true
+		}
+		return value - 1, value > f.Min
+	}
+	return prevValue(f.Values, value, f.Min)
+}
+
+// matches reports whether the value, taken from the time given, is within the field. The time
+// is needed to resolve relative values.
+func (f *Field) matches(value int, t time.Time) bool {
+	if f.Has(value) {
+		return true
+	}
+
+	for _, relative := range f.relative {
+		if relative.matches(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isWildcard reports whether the field matches every value within its bounds.
+func (f *Field) isWildcard() bool {
+	return f.wildcard || (len(f.relative) == 0 && len(f.Values) == f.Max-f.Min+1)
+}
+
+// resolveNames returns the field's term with any named values and keywords replaced by their
+// numeric equivalent. Names which are unknown to the field are left in place.
+func (f *Field) resolveNames() string {
+	names, hasNames := fieldNames[f.Kind]
+	keywords, hasKeywords := fieldKeywords[f.Kind]
+	if !hasNames && !hasKeywords {
+		return f.Term
 	}
 
-	if min < f.Min {
-		return nil, fmt.Errorf("value(%d) cannot be less than min(%d)", min, f.Min)
+	return nameRegex.ReplaceAllStringFunc(f.Term, func(name string) string {
+		if values, ok := keywords[strings.ToLower(name)]; ok {
+			return values
+		}
+
+		value, ok := names[strings.ToLower(name)]
+		if !ok {
+			return name
+		}
+		return strconv.Itoa(value)
+	})
+}
+
+// setWildcard makes the field match every value from Min to Max. Years are kept as a range,
+// since they can span thousands of values, and other fields list their values.
+func (f *Field) setWildcard() {
+	if f.Kind == year {
+		f.Values, f.wildcard = nil, true
+		return
 	}
+	f.Values = generateSequentialSet(f.Min, f.Max)
+}
 
-	if max > f.Max {
-		return nil, fmt.Errorf("value(%d) cannot be more than max(%d)", max, f.Max)
+func (f *Field) parseSpanField(term string) (map[int]struct{}, error) {
+	min, max, err := f.spanBounds(term)
+	if err != nil {
+		return nil, err
 	}
 
 	return generateSequentialSet(min, max), nil
 }
 
-func (f *Field) parseValueField() (map[int]struct{}, error) {
-	value, err := strconv.Atoi(f.Term)
+// spanBounds returns the first and last values of a span term, checking that they are in
+// order and within the field's bounds.
+func (f *Field) spanBounds(term string) (int, int, error) {
+	values := strings.Split(term, "-")
+
+	min, err := strconv.Atoi(values[0])
 	if err != nil {
-		return nil, fmt.Errorf("could not parse value %s: %v", f.Term, err)
+		return 0, 0, reasonf(ErrInvalidTerm, "could not parse value %s: %v", values[0], err)
 	}
 
-	if value < f.Min {
-		return nil, fmt.Errorf("value(%d) cannot be less than min(%d)", value, f.Min)
+	max, err := strconv.Atoi(values[1])
+	if err != nil {
+		return 0, 0, reasonf(ErrInvalidTerm, "could not parse value %s: %v", values[1], err)
 	}
 
-	if value > f.Max {
-		return nil, fmt.Errorf("value(%d) cannot be more than max(%d)", value, f.Max)
+	if min >= max {
+		return 0, 0, reasonf(ErrInvalidTerm, "first value(%d) cannot be greater/equal to second(%d)", min, max)
+	}
+
+	if err := f.checkBounds(min); err != nil {
+		return 0, 0, err
+	}
+
+	if err := f.checkBounds(max); err != nil {
+		return 0, 0, err
+	}
+
+	return min, max, nil
+}
+
+func (f *Field) parseValueField(term string) (map[int]struct{}, error) {
+	value, err := f.boundedValue(term, term)
+	if err != nil {
+		return nil, err
 	}
 
 	return map[int]struct{}{
@@ -130,22 +311,14 @@ func (f *Field) parseValueField() (map[int]struct{}, error) {
 	}, nil
 }
 
-func (f *Field) parseListField() (map[int]struct{}, error) {
+func (f *Field) parseListField(term string) (map[int]struct{}, error) {
 	set := map[int]struct{}{}
-	values := strings.Split(f.Term, ",")
+	values := strings.Split(term, ",")
 
 	for _, rawValue := range values {
-		value, err := strconv.Atoi(rawValue)
+		value, err := f.boundedValue(rawValue, term)
 		if err != nil {
-			return nil, fmt.Errorf("could not parse value %s: %v", f.Term, err)
-		}
-
-		if value < f.Min {
-			return nil, fmt.Errorf("value(%d) cannot be less than min(%d)", value, f.Min)
-		}
-
-		if value > f.Max {
-			return nil, fmt.Errorf("value(%d) cannot be more than max(%d)", value, f.Max)
+			return nil, err
 		}
 
 		set[value] = struct{}{}
@@ -153,3 +326,31 @@ func (f *Field) parseListField() (map[int]struct{}, error) {
 
 	return set, nil
 }
+
+// boundedValue parses a single value of the term, checking that it is within the field's
+// bounds.
+func (f *Field) boundedValue(rawValue, term string) (int, error) {
+	value, err := strconv.Atoi(rawValue)
+	if err != nil {
+		return 0, reasonf(ErrInvalidTerm, "could not parse value %s: %v", term, err)
+	}
+
+	if err := f.checkBounds(value); err != nil {
+		return 0, err
+	}
+
+	return value, nil
+}
+
+// checkBounds returns an error if the value is outside of the field's bounds.
+func (f *Field) checkBounds(value int) error {
+	if value < f.Min {
+		return reasonf(ErrOutOfBounds, "value(%d) cannot be less than min(%d)", value, f.Min)
+	}
+
+	if value > f.Max {
+		return reasonf(ErrOutOfBounds, "value(%d) cannot be more than max(%d)", value, f.Max)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package avail
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"time"
+)
+
+// Flag is a time gated feature flag. It is enabled whenever its Timeframe is able and can
+// optionally be rolled out to only a percentage of keys (ex. user IDs) within that time.
+// Ex. a Flag with the Timeframe "* 9-16 * * 1-5 *" and a percentage of 25 is enabled for a
+// stable quarter of all users during business hours.
+//
+// The percentage can also be ramped across each window the Timeframe is able in, as returned
+// by NextWindow, so that a rollout grows gradually. Ex. a ramp from 0 to 100 over
+// "* * 1-7 6 * 2020" enables the flag for a growing share of users through the first week of
+// June 2020, reaching all of them on its last day.
+type Flag struct {
+	timeframe Timeframe
+	// from and to are the percentages at the start and end of each window; they are equal for
+	// flags without a ramp.
+	from, to int
+}
+
+// NewFlag returns a Flag gated by the given Timeframe and enabled for the given percentage
+// (0-100) of keys while the Timeframe is able.
+func NewFlag(timeframe Timeframe, percentage int) (*Flag, error) {
+	return NewRampedFlag(timeframe, percentage, percentage)
+}
+
+// NewRampedFlag returns a Flag gated by the given Timeframe whose percentage (0-100) of keys
+// grows linearly from the first percentage at the start of each window to the second at its
+// last minute (or second, for expressions with seconds). A ramp may also shrink the percentage.
+func NewRampedFlag(timeframe Timeframe, from, to int) (*Flag, error) {
+	for _, percentage := range []int{from, to} {
+		if percentage < 0 || percentage > 100 {
+			return nil, fmt.Errorf("percentage(%d) must be between 0 and 100", percentage)
+		}
+	}
+
+	return &Flag{
+		timeframe: timeframe,
+		from:      from,
+		to:        to,
+	}, nil
+}
+
+// Enabled reports whether the Flag is enabled for any keys at the time given: whether its
+// Timeframe is able and its percentage at that time is above zero.
+func (f *Flag) Enabled(t time.Time) bool {
+	return f.Percentage(t) > 0
+}
+
+// Percentage returns the percentage of keys the Flag is enabled for at the time given. It is
+// zero whenever the Timeframe is not able.
+func (f *Flag) Percentage(t time.Time) int {
+	if !f.timeframe.Able(t) {
+		return 0
+	}
+	if f.from == f.to {
+		return f.from
+	}
+
+	location := t.Location()
+	if f.timeframe.Location != nil {
+		location = f.timeframe.Location
+	}
+	precision := f.timeframe.options.precision()
+
+	start := f.timeframe.windowStart(t, location)
+	end := f.timeframe.windowEnd(t.Truncate(precision), location)
+
+	// The ramp reaches its final percentage at the last step of the window, since the end of
+	// the window is the first time the Timeframe is no longer able.
+	span := end.Sub(start) - precision
+	if span <= 0 {
+		return f.to
+	}
+	fraction := float64(t.Sub(start)) / float64(span)
+	if fraction > 1 {
+		fraction = 1
+	}
+
+	return f.from + int(math.Round(fraction*float64(f.to-f.from)))
+}
+
+// EnabledFor reports whether the Flag is enabled for the given key at the time given. Keys
+// are hashed into stable buckets so a key that is enabled stays enabled for as long as the
+// Timeframe is able and the percentage is not lowered, including as a ramp grows.
+func (f *Flag) EnabledFor(key string, t time.Time) bool {
+	return bucket(key) < f.Percentage(t)
+}
+
+// bucket deterministically hashes a key into a value between 0 and 99.
+func bucket(key string) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(key))
+	return int(hash.Sum32() % 100)
+}
@@ -0,0 +1,163 @@
+package avail
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestFlagEnabled(t *testing.T) {
+	timeframe, err := New("* 9-16 * * 1-5 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flag, err := NewFlag(timeframe, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !flag.EnabledFor("user", time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC)) {
+		t.Error("flag should be enabled during business hours")
+	}
+
+	if flag.EnabledFor("user", time.Date(2020, 6, 7, 10, 0, 0, 0, time.UTC)) {
+		t.Error("flag should not be enabled on the weekend")
+	}
+}
+
+func TestFlagPercentage(t *testing.T) {
+	timeframe, err := New("* * * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		percentage int
+		wantMin    int
+		wantMax    int
+	}{
+		"none":    {0, 0, 0},
+		"quarter": {25, 200, 300},
+		"all":     {100, 1000, 1000},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			flag, err := NewFlag(timeframe, tc.percentage)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			now := time.Now()
+			enabled := 0
+			for i := 0; i < 1000; i++ {
+				key := fmt.Sprintf("user-%d", i)
+				if flag.EnabledFor(key, now) {
+					enabled++
+				}
+
+				if flag.EnabledFor(key, now) != flag.EnabledFor(key, now.Add(time.Hour)) {
+					t.Fatalf("key %s should be consistently bucketed", key)
+				}
+			}
+
+			if enabled < tc.wantMin || enabled > tc.wantMax {
+				t.Errorf("enabled keys(%d) not within expected range %d-%d", enabled, tc.wantMin, tc.wantMax)
+			}
+		})
+	}
+}
+
+func TestFlagRamp(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		time       time.Time
+		want       int
+	}{
+		"window start":      {"* 9-16 * * * *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), 0},
+		"window midpoint":   {"* 9-16 * * * *", time.Date(2020, 6, 8, 12, 59, 30, 0, time.UTC), 50},
+		"window end":        {"* 9-16 * * * *", time.Date(2020, 6, 8, 16, 59, 0, 0, time.UTC), 100},
+		"outside window":    {"* 9-16 * * * *", time.Date(2020, 6, 8, 17, 0, 0, 0, time.UTC), 0},
+		"week start":        {"* * 1-7 6 * 2020", time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), 0},
+		"week midpoint":     {"* * 1-7 6 * 2020", time.Date(2020, 6, 4, 11, 59, 30, 0, time.UTC), 50},
+		"week end":          {"* * 1-7 6 * 2020", time.Date(2020, 6, 7, 23, 59, 0, 0, time.UTC), 100},
+		"week quarter past": {"* * 1-7 6 * 2020", time.Date(2020, 6, 2, 17, 59, 45, 0, time.UTC), 25},
+		"combined start":    {"* 9-10 * * * * || * 10-11 * * * *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), 0},
+		"combined midpoint": {"* 9-10 * * * * || * 10-11 * * * *", time.Date(2020, 6, 8, 10, 29, 30, 0, time.UTC), 50},
+		"combined end":      {"* 9-10 * * * * || * 10-11 * * * *", time.Date(2020, 6, 8, 11, 59, 0, 0, time.UTC), 100},
+		"combined year":     {"* * * * * 2020 || 0 0 1 1 * *", time.Date(2020, 7, 2, 0, 0, 0, 0, time.UTC), 50},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			timeframe, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			flag, err := NewRampedFlag(timeframe, 0, 100)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := flag.Percentage(tc.time); got != tc.want {
+				t.Errorf("want %d, got %d", tc.want, got)
+			}
+
+			enabled := 0
+			for i := 0; i < 1000; i++ {
+				if flag.EnabledFor(fmt.Sprintf("user-%d", i), tc.time) {
+					enabled++
+				}
+			}
+			if want := tc.want * 10; enabled < want-100 || enabled > want+100 {
+				t.Errorf("enabled keys(%d) not within expected range %d-%d", enabled, want-100, want+100)
+			}
+
+			if flag.Enabled(tc.time) != (tc.want > 0) {
+				t.Errorf("want %t, got %t", tc.want > 0, flag.Enabled(tc.time))
+			}
+		})
+	}
+}
+
+func TestFlagRampStable(t *testing.T) {
+	timeframe, err := New("* 9-16 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flag, err := NewRampedFlag(timeframe, 10, 90)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Keys enabled earlier in the window stay enabled as the ramp grows.
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("user-%d", i)
+		enabled := false
+		for current := time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC); current.Hour() < 17; current = current.Add(time.Hour) {
+			got := flag.EnabledFor(key, current)
+			if enabled && !got {
+				t.Fatalf("key %s should stay enabled at %s", key, current)
+			}
+			enabled = got
+		}
+	}
+}
+
+func TestNewFlagInvalid(t *testing.T) {
+	timeframe, err := New("* * * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewFlag(timeframe, 101); err == nil {
+		t.Error("percentage over 100 should not be accepted")
+	}
+
+	if _, err := NewRampedFlag(timeframe, 0, -1); err == nil {
+		t.Error("ramp to a negative percentage should not be accepted")
+	}
+}
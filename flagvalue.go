@@ -0,0 +1,42 @@
+package avail
+
+import (
+	"flag"
+	"fmt"
+)
+
+// String returns the Timeframe's expression. Together with Set and Type it allows a *Timeframe
+// to be used as a flag.Value (or pflag.Value), so that expressions given on the command line
+// are validated as flags are parsed.
+func (a *Timeframe) String() string {
+	if a == nil {
+		return ""
+	}
+	return a.Expression
+}
+
+// Set parses the expression with New and replaces the Timeframe with the result.
+func (a *Timeframe) Set(expression string) error {
+	return a.UnmarshalText([]byte(expression))
+}
+
+// Type returns the name of the flag's type shown in pflag usage messages.
+func (a *Timeframe) Type() string {
+	return "timeframe"
+}
+
+// TimeframeFlag defines a Timeframe flag on the flag set with the given name, default
+// expression and usage, and returns the Timeframe the flag is parsed into. Ex.
+//
+//    schedule := avail.TimeframeFlag(flag.CommandLine, "schedule", "0 9 * * 1-5 *", "when to run")
+//
+// It panics if the default expression is invalid, since defaults are fixed by the program.
+func TimeframeFlag(flags *flag.FlagSet, name, expression, usage string) *Timeframe {
+	timeframe := &Timeframe{}
+	if err := timeframe.Set(expression); err != nil {
+		panic(fmt.Sprintf("avail: invalid default for flag %s: %v", name, err))
+	}
+
+	flags.Var(timeframe, name, usage)
+	return timeframe
+}
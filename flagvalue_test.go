@@ -0,0 +1,57 @@
+package avail
+
+import (
+	"flag"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestTimeframeFlag(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+		want string
+	}{
+		"default": {[]string{}, "0 9 * * 1-5 *"},
+		"given":   {[]string{"-schedule", "0 10 * * 0,6 *"}, "0 10 * * 0,6 *"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			schedule := TimeframeFlag(flags, "schedule", "0 9 * * 1-5 *", "when to run")
+
+			if err := flags.Parse(tc.args); err != nil {
+				t.Fatal(err)
+			}
+
+			if schedule.String() != tc.want {
+				t.Errorf("want %s, got %s", tc.want, schedule)
+			}
+		})
+	}
+}
+
+func TestTimeframeFlagInvalid(t *testing.T) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	schedule := TimeframeFlag(flags, "schedule", "0 9 * * 1-5 *", "when to run")
+
+	if err := flags.Parse([]string{"-schedule", "0 9 * *"}); err == nil {
+		t.Errorf("invalid expression should not be parsed successfully")
+	}
+
+	if !schedule.Able(time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("invalid expression should leave the default in place")
+	}
+}
+
+func TestTimeframeFlagInvalidDefault(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("invalid default should panic")
+		}
+	}()
+
+	TimeframeFlag(flag.NewFlagSet("test", flag.ContinueOnError), "schedule", "0 9 * *", "when to run")
+}
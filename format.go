@@ -0,0 +1,324 @@
+package avail
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Format returns the Timeframe as an expression in the given dialect, allowing expressions to
+// be converted between dialects. Ex. New("cron(0 12 ? * MON-FRI *)", WithDialect(AWSEventBridge))
+// formatted as Native is "0 12 * * 1-5 *".
+//
+// Native expressions are formatted with a leading seconds term, and so must be parsed using
+// WithSeconds, only when the seconds are not exactly 0. An error is returned if the Timeframe
+// uses something the dialect cannot express, like a day of month and a day of week together
+// in a dialect which requires one of them to be "?", or if it has exclusions. The alternatives
+// of combined expressions are formatted individually.
+func (a *Timeframe) Format(dialect Dialect) (string, error) {
+	return a.format(dialect, false)
+}
+
+// Normalize returns the Timeframe's expression in a canonical form of the dialect it was
+// parsed in, so that expressions written differently but resolving to the same values can be
+// stored, hashed and diffed as the same string. Ex. "0,1,2,3,4 9 * JAN-DEC mon-fri *" is
+// normalized to "0-4 9 * * 1-5 *".
+//
+// Terms matching their whole range become "*", names become numbers and values are sorted,
+// with consecutive values written as ranges wherever the dialect allows. Alternatives are
+// sorted and duplicates removed. Normalized expressions can be parsed again with the options
+// the Timeframe was created with. The errors are those of Format.
+func (a *Timeframe) Normalize() (string, error) {
+	return a.format(a.options.dialect, true)
+}
+
+// format returns the Timeframe as an expression in the given dialect. When canonical is true
+// the alternatives of combined expressions are sorted and deduplicated, and native seconds
+// terms are kept.
+func (a *Timeframe) format(dialect Dialect, canonical bool) (string, error) {
+	if len(a.exclusions) > 0 {
+		return "", fmt.Errorf("could not format %s: exclusions cannot be formatted", a.Expression)
+	}
+
+	prefix := ""
+	if a.Location != nil {
+		prefix = locationPrefixes[0] + a.Location.String() + " "
+	}
+
+	if a.alternatives != nil {
+		alternatives := []string{}
+		for i := range a.alternatives {
+			alternative, err := a.alternatives[i].format(dialect, canonical)
+			if err != nil {
+				return "", err
+			}
+			alternatives = append(alternatives, alternative)
+		}
+		if canonical {
+			alternatives = sortedUnique(alternatives)
+		}
+		return prefix + strings.Join(alternatives, " "+alternativeSeparator+" "), nil
+	}
+
+	var expression string
+	var err error
+	if dialect == Native || dialect == "" {
+		expression, err = a.formatNative(canonical)
+	} else {
+		spec, ok := dialects[dialect]
+		if !ok {
+			return "", fmt.Errorf("unknown dialect %s", dialect)
+		}
+		spec.minYear, spec.maxYear = a.options.yearBounds(spec.minYear, spec.maxYear)
+		expression, err = a.formatDialect(spec)
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not format %s as %s: %w", a.Expression, dialect, err)
+	}
+
+	return prefix + expression, nil
+}
+
+// formatNative returns the Timeframe as a native expression. When keepSeconds is true a
+// Timeframe parsed with WithSeconds keeps its seconds term even if it is only 0, so the
+// expression can be parsed again with the same options.
+func (a *Timeframe) formatNative(keepSeconds bool) (string, error) {
+	if a.Interval != 0 {
+		return everyPrefix + " " + a.Interval.String(), nil
+	}
+
+	parsed := a.ParsedExpression
+	terms := []string{}
+
+	if a.options.seconds && (keepSeconds || !isOnly(parsed.Seconds.Values, 0)) {
+		terms = append(terms, formatNativeValues(parsed.Seconds.Values))
+	}
+
+	minYear, maxYear := a.options.yearBounds(1970, 2100)
+	fields := []struct {
+		field    Field
+		min, max int
+	}{
+		{parsed.Minutes, 0, 59},
+		{parsed.Hours, 0, 23},
+		{parsed.Days, 1, 31},
+		{parsed.Months, 1, 12},
+		{parsed.Weekdays, 0, 6},
+		{parsed.Years, minYear, maxYear},
+	}
+
+	for _, field := range fields {
+		term, err := formatFieldTerm(field.field, field.min, field.max, func(values map[int]struct{}) string {
+			return formatNativeValues(values)
+		}, func(relative relativeValue) (string, error) {
+			if field.field.Kind != day || (relative.kind != last && relative.kind != lastWeekday) {
+				return "", fmt.Errorf("term kind %s has no native equivalent", relative.kind)
+			}
+			return formatRelative(relative, 0), nil
+		})
+		if err != nil {
+			return "", err
+		}
+		terms = append(terms, term)
+	}
+
+	return strings.Join(terms, " "), nil
+}
+
+// formatDialect returns the Timeframe as an expression in the given dialect.
+func (a *Timeframe) formatDialect(spec dialectSpec) (string, error) {
+	if a.Interval != 0 {
+		return "", fmt.Errorf("intervals cannot be formatted")
+	}
+
+	parsed := a.ParsedExpression
+	terms := []string{}
+
+	switch {
+	case spec.seconds && a.options.seconds:
+		terms = append(terms, formatDialectValues(parsed.Seconds.Values, 0))
+	case spec.seconds:
+		terms = append(terms, "0")
+	case a.options.seconds && !isOnly(parsed.Seconds.Values, 0):
+		return "", fmt.Errorf("seconds cannot be formatted")
+	}
+
+	fields := []struct {
+		field    Field
+		min, max int
+		shift    int
+	}{
+		{parsed.Minutes, 0, 59, 0},
+		{parsed.Hours, 0, 23, 0},
+		{parsed.Days, 1, 31, 0},
+		{parsed.Months, 1, 12, 0},
+		{parsed.Weekdays, 0, 6, spec.sunday},
+		{parsed.Years, spec.minYear, spec.maxYear, 0},
+	}
+
+	for _, field := range fields {
+		shift := field.shift
+		term, err := formatFieldTerm(field.field, field.min, field.max, func(values map[int]struct{}) string {
+			return formatDialectValues(values, shift)
+		}, func(relative relativeValue) (string, error) {
+			if !spec.relative {
+				return "", fmt.Errorf("term kind %s cannot be formatted", relative.kind)
+			}
+			return formatRelative(relative, spec.sunday), nil
+		})
+		if err != nil {
+			return "", err
+		}
+		terms = append(terms, term)
+	}
+
+	// terms holds the seconds term, if any, followed by the six native terms.
+	fieldTerms := terms[len(terms)-6:]
+	if spec.questionMark {
+		switch {
+		case parsed.Weekdays.isWildcard():
+			fieldTerms[4] = "?"
+		case parsed.Days.isWildcard():
+			fieldTerms[2] = "?"
+		default:
+			return "", fmt.Errorf("a day of month and a day of week cannot both be given")
+		}
+	}
+
+	switch {
+	case spec.year == yearNone && fieldTerms[5] != "*":
+		return "", fmt.Errorf("years cannot be formatted")
+	case spec.year != yearRequired && fieldTerms[5] == "*":
+		terms = terms[:len(terms)-1]
+	}
+
+	expression := strings.Join(terms, " ")
+	if spec.wrapper != "" {
+		expression = spec.wrapper + "(" + expression + ")"
+	}
+
+	return expression, nil
+}
+
+// formatFieldTerm returns the term for the field given the bounds of the field in the target
+// grammar. Values are rendered with formatValues and relative values with formatRelative.
+func formatFieldTerm(field Field, min, max int, formatValues func(map[int]struct{}) string,
+	formatRelative func(relativeValue) (string, error)) (string, error) {
+	if field.isWildcard() {
+		return "*", nil
+	}
+
+	switch {
+	case len(field.relative) == 1 && len(field.Values) == 0:
+		return formatRelative(field.relative[0])
+	case len(field.relative) > 0:
+		return "", fmt.Errorf("relative and fixed values in the %s field cannot be combined", field.Kind)
+	case len(field.Values) == 0:
+		return "", fmt.Errorf("%s field has no values", field.Kind)
+	}
+
+	for value := range field.Values {
+		if value < min || value > max {
+			return "", fmt.Errorf("value(%d) must be within %d-%d", value, min, max)
+		}
+	}
+
+	return formatValues(field.Values), nil
+}
+
+// formatRelative returns the term for a relative value given the value of Sunday in the
+// target grammar's day of week numbering.
+func formatRelative(relative relativeValue, sunday int) string {
+	switch relative.kind {
+	case last:
+		if relative.offset == 0 {
+			return "L"
+		}
+		return fmt.Sprintf("L-%d", relative.offset)
+	case lastWeekday:
+		return "LW"
+	case nearestWeekday:
+		return fmt.Sprintf("%dW", relative.day)
+	case nthDayOfWeek:
+		return fmt.Sprintf("%d#%d", int(relative.weekday)+sunday, relative.nth)
+	case lastDayOfWeek:
+		return fmt.Sprintf("%dL", int(relative.weekday)+sunday)
+	}
+
+	return ""
+}
+
+// formatNativeValues returns the values as a native term. Native lists cannot contain ranges,
+// so only a single run of values is formatted as a range.
+func formatNativeValues(values map[int]struct{}) string {
+	runs := valueRuns(values, 0)
+	if len(runs) == 1 {
+		return formatRun(runs[0])
+	}
+
+	rendered := []string{}
+	for _, run := range runs {
+		for value := run[0]; value <= run[1]; value++ {
+			rendered = append(rendered, strconv.Itoa(value))
+		}
+	}
+	return strings.Join(rendered, ",")
+}
+
+// formatDialectValues returns the values, shifted by the given amount, as a list of values
+// and ranges. Ex. "1-5,7".
+func formatDialectValues(values map[int]struct{}, shift int) string {
+	rendered := []string{}
+	for _, run := range valueRuns(values, shift) {
+		rendered = append(rendered, formatRun(run))
+	}
+	return strings.Join(rendered, ",")
+}
+
+// valueRuns returns the values, shifted by the given amount, as sorted runs of consecutive
+// values. Each run holds its first and last value.
+func valueRuns(values map[int]struct{}, shift int) [][2]int {
+	sorted := make([]int, 0, len(values))
+	for value := range values {
+		sorted = append(sorted, value+shift)
+	}
+	sort.Ints(sorted)
+
+	runs := [][2]int{}
+	for _, value := range sorted {
+		if len(runs) > 0 && runs[len(runs)-1][1] == value-1 {
+			runs[len(runs)-1][1] = value
+			continue
+		}
+		runs = append(runs, [2]int{value, value})
+	}
+	return runs
+}
+
+// formatRun returns a run of values as a single value or a range.
+func formatRun(run [2]int) string {
+	if run[0] == run[1] {
+		return strconv.Itoa(run[0])
+	}
+	return fmt.Sprintf("%d-%d", run[0], run[1])
+}
+
+// sortedUnique returns the strings sorted with duplicates removed.
+func sortedUnique(items []string) []string {
+	sort.Strings(items)
+
+	unique := []string{}
+	for i, item := range items {
+		if i == 0 || item != items[i-1] {
+			unique = append(unique, item)
+		}
+	}
+	return unique
+}
+
+// isOnly reports whether the set contains exactly the given value.
+func isOnly(values map[int]struct{}, value int) bool {
+	_, ok := values[value]
+	return ok && len(values) == 1
+}
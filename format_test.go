@@ -0,0 +1,144 @@
+package avail
+
+import "testing"
+
+func TestFormat(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		from       Dialect
+		to         Dialect
+		want       string
+	}{
+		"native to native":         {"0  9-17 * * MON-FRI *", Native, Native, "0 9-17 * * 1-5 *"},
+		"native list":              {"0,15,30 * * * 0,6 *", Native, Native, "0,15,30 * * * 0,6 *"},
+		"native macro":             {"@daily", Native, Native, "0 0 * * * *"},
+		"native interval":          {"@every 90m", Native, Native, "@every 1h30m0s"},
+		"native last day":          {"0 0 L-3 * * *", Native, Native, "0 0 L-3 * * *"},
+		"native to aws":            {"0 12 * * 1-5 *", Native, AWSEventBridge, "cron(0 12 ? * 2-6 *)"},
+		"native days to aws":       {"0 12 1,15 * * 2021", Native, AWSEventBridge, "cron(0 12 1,15 * ? 2021)"},
+		"aws to native":            {"cron(0/30 9-17 ? * MON-FRI *)", AWSEventBridge, Native, "0,30 9-17 * * 1-5 *"},
+		"aws to quartz":            {"cron(0 8 ? * 6L *)", AWSEventBridge, Quartz, "0 0 8 ? * 6L"},
+		"quartz to aws":            {"0 0 12 15W * ? 2020", Quartz, AWSEventBridge, "cron(0 12 15W * ? 2020)"},
+		"quartz to native":         {"0 0 12 ? * 1,7", Quartz, Native, "0 12 * * 0,6 *"},
+		"quartz ranges":            {"0 0 12 ? * 2-4,6", Quartz, Quartz, "0 0 12 ? * 2-4,6"},
+		"quartz seconds to native": {"15 0 12 * * ?", Quartz, Native, "15 0 12 * * * *"},
+		"timezone": {"CRON_TZ=America/New_York 0 9 * * * *", Native, AWSEventBridge,
+			"CRON_TZ=America/New_York cron(0 9 * * ? *)"},
+		"ncrontab to native": {"0 */15 9-17 * * 1-5", NCRONTAB, Native, "0,15,30,45 9-17 * * 1-5 *"},
+		"native to ncrontab": {"0 9-17 * * 1-5 *", Native, NCRONTAB, "0 0 9-17 * * 1-5"},
+		"combined": {"0 9 * * 1-5 * || 0 10 * * 0,6 *", Native, AWSEventBridge,
+			"cron(0 9 ? * 2-6 *) || cron(0 10 ? * 1,7 *)"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression, WithDialect(tc.from))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := avail.Format(tc.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tc.want {
+				t.Errorf("incorrect expression; want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatInvalid(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		from       Dialect
+		to         Dialect
+	}{
+		"both days to aws":          {"0 12 1 * 1 *", Native, AWSEventBridge},
+		"interval to quartz":        {"@every 1h", Native, Quartz},
+		"seconds to aws":            {"15 0 12 * * ?", Quartz, AWSEventBridge},
+		"nth day of week to native": {"0 0 12 ? * 6#3", Quartz, Native},
+		"year beyond native range":  {"cron(0 12 * * ? 2150)", AWSEventBridge, Native},
+		"year to ncrontab":          {"0 12 * * * 2020", Native, NCRONTAB},
+		"last day to ncrontab":      {"0 12 L * * *", Native, NCRONTAB},
+		"unknown dialect":           {"* * * * * *", Native, "unknown"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression, WithDialect(tc.from))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := avail.Format(tc.to); err == nil {
+				t.Errorf("expected error formatting %q as %s", tc.expression, tc.to)
+			}
+		})
+	}
+
+	base, err := New("* * * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	christmas, err := New("* * 25 12 * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	avail := base.Except(christmas)
+	if _, err := avail.Format(Native); err == nil {
+		t.Error("exclusions should not be formatted")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+		want       string
+	}{
+		"full range":          {"0-59 0,1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20,21,22,23 * * * *", nil, "* * * * * *"},
+		"consecutive values":  {"0,1,2,3,4 9 * * * *", nil, "0-4 9 * * * *"},
+		"sorted list":         {"30,0,15 9 * * 6,0 *", nil, "0,15,30 9 * * 0,6 *"},
+		"names":               {"0 9 * jan-MAR Mon-Fri *", nil, "0 9 * 1-3 1-5 *"},
+		"keywords":            {"0 9 * * weekends *", nil, "0 9 * * 0,6 *"},
+		"relative":            {"0 0 l * * *", nil, "0 0 L * * *"},
+		"timezone":            {"tz=UTC  0 9 * * * *", nil, "CRON_TZ=UTC 0 9 * * * *"},
+		"alternatives":        {"0 10 * * 6,0 * || 0 9 * * MON-FRI * || 0 10 * * 0,6 *", nil, "0 10 * * 0,6 * || 0 9 * * 1-5 *"},
+		"seconds":             {"0 0 9 * * * *", []Option{WithSeconds()}, "0 0 9 * * * *"},
+		"quartz":              {"0 0 12 ? * MON,TUE,WED,FRI", []Option{WithDialect(Quartz)}, "0 0 12 ? * 2-4,6"},
+		"crontab":             {"0 9 * * 1,2,3,4,5", []Option{WithDialect(Crontab)}, "0 9 * * 1-5"},
+		"optional year":       {"0 9 * * 1-5", []Option{WithOptionalYear()}, "0 9 * * 1-5 *"},
+		"interval":            {"@every 60m", nil, "@every 1h0m0s"},
+		"hashed":              {"H 9 * * * *", []Option{WithHashKey("backup")}, ""},
+		"normalized is fixed": {"0 9 * * 1-5 *", nil, "0 9 * * 1-5 *"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			timeframe, err := New(tc.expression, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := timeframe.Normalize()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.want != "" && got != tc.want {
+				t.Errorf("incorrect expression; want %q, got %q", tc.want, got)
+			}
+
+			reparsed, err := New(got, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reparsed.Equal(timeframe) {
+				t.Errorf("normalized expression %q should be equal to %q", got, tc.expression)
+			}
+		})
+	}
+}
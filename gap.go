@@ -0,0 +1,77 @@
+package avail
+
+import "time"
+
+// Gap is the span between two consecutive occurrences of a Timeframe. Start and End are the
+// occurrences either side of it.
+type Gap struct {
+	Start, End time.Time
+}
+
+// Duration returns the length of the gap.
+func (g Gap) Duration() time.Duration {
+	return g.End.Sub(g.Start)
+}
+
+// MaxGap returns the longest gap between consecutive occurrences of the Timeframe within
+// [start, end), the earliest if there are several. It catches expressions which go quiet for
+// far longer than intended. Ex. "0 0 31 * 1 *" only occurs on a Monday the 31st, months apart.
+// The second return value is false if the Timeframe occurs fewer than twice within the range.
+//
+// Consecutive able minutes (or seconds) are walked as windows, so long stretches in which the
+// Timeframe is always able are cheap to cross.
+func (a *Timeframe) MaxGap(start, end time.Time) (Gap, bool) {
+	return a.findGap(start, end, func(gap, best Gap) bool {
+		return gap.Duration() > best.Duration()
+	})
+}
+
+// MinGap returns the shortest gap between consecutive occurrences of the Timeframe within
+// [start, end), the earliest if there are several. The second return value is false if the
+// Timeframe occurs fewer than twice within the range.
+func (a *Timeframe) MinGap(start, end time.Time) (Gap, bool) {
+	return a.findGap(start, end, func(gap, best Gap) bool {
+		return gap.Duration() < best.Duration()
+	})
+}
+
+// findGap returns the first gap within [start, end) for which better is true against every
+// gap before it.
+func (a *Timeframe) findGap(start, end time.Time, better func(gap, best Gap) bool) (Gap, bool) {
+	location := start.Location()
+	if a.Location != nil {
+		location = a.Location
+	}
+	precision := a.options.precision()
+
+	var best Gap
+	found := false
+	consider := func(gap Gap) {
+		if !found || better(gap, best) {
+			best, found = gap, true
+		}
+	}
+
+	// Occurrences strictly after the instant before start include one at start itself.
+	occurrence, err := a.nextFrom(start.Add(-time.Nanosecond), location)
+	for err == nil && occurrence.Before(end) {
+		// Every occurrence within a window is a precision apart from the one before it.
+		last := a.windowEnd(occurrence, location).Add(-precision)
+		if !last.Before(end) {
+			last = occurrence.Add((end.Add(-time.Nanosecond).Sub(occurrence) / precision) * precision)
+		}
+		if last.After(occurrence) {
+			consider(Gap{Start: occurrence, End: occurrence.Add(precision)})
+		}
+
+		next, nextErr := a.nextFrom(last, location)
+		if nextErr != nil || !next.Before(end) {
+			break
+		}
+		consider(Gap{Start: last, End: next})
+
+		occurrence, err = next, nextErr
+	}
+
+	return best, found
+}
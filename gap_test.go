@@ -0,0 +1,141 @@
+package avail
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGaps(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(1, 0, 0)
+
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+		end        time.Time
+		max, min   Gap
+	}{
+		"daily": {
+			"0 9 * * * *", nil, end,
+			Gap{time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC), time.Date(2020, 1, 2, 9, 0, 0, 0, time.UTC)},
+			Gap{time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC), time.Date(2020, 1, 2, 9, 0, 0, 0, time.UTC)},
+		},
+		"business hours": {
+			"* 9-17 * * 1-5 *", nil, end,
+			Gap{time.Date(2020, 1, 3, 17, 59, 0, 0, time.UTC), time.Date(2020, 1, 6, 9, 0, 0, 0, time.UTC)},
+			Gap{time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 9, 1, 0, 0, time.UTC)},
+		},
+		"monday the 31st": {
+			"0 0 31 * 1 *", nil, start.AddDate(3, 0, 0),
+			Gap{time.Date(2020, 8, 31, 0, 0, 0, 0, time.UTC), time.Date(2021, 5, 31, 0, 0, 0, 0, time.UTC)},
+			Gap{time.Date(2021, 5, 31, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC)},
+		},
+		"always": {
+			"* * * * * *", nil, end,
+			Gap{start, start.Add(time.Minute)},
+			Gap{start, start.Add(time.Minute)},
+		},
+		"always combined": {
+			"* * * * * * || 0 0 1 1 * *", nil, end,
+			Gap{start, start.Add(time.Minute)},
+			Gap{start, start.Add(time.Minute)},
+		},
+		"overlapping alternatives": {
+			"* 9-10 * * * * || * 10-11 * * * *", nil, end,
+			Gap{time.Date(2020, 1, 1, 11, 59, 0, 0, time.UTC), time.Date(2020, 1, 2, 9, 0, 0, 0, time.UTC)},
+			Gap{time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 9, 1, 0, 0, time.UTC)},
+		},
+		"always interval": {
+			"@every 1m", nil, end,
+			Gap{start, start.Add(time.Minute)},
+			Gap{start, start.Add(time.Minute)},
+		},
+		"seconds": {
+			"0,10 0 12 * * * *", []Option{WithSeconds()}, end,
+			Gap{time.Date(2020, 1, 1, 12, 0, 10, 0, time.UTC), time.Date(2020, 1, 2, 12, 0, 0, 0, time.UTC)},
+			Gap{time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC), time.Date(2020, 1, 1, 12, 0, 10, 0, time.UTC)},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			timeframe := MustNew(tc.expression, tc.opts...)
+
+			max, ok := timeframe.MaxGap(start, tc.end)
+			if !ok {
+				t.Fatalf("want %t, got %t", true, false)
+			}
+			if diff := cmp.Diff(tc.max, max); diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+
+			min, ok := timeframe.MinGap(start, tc.end)
+			if !ok {
+				t.Fatalf("want %t, got %t", true, false)
+			}
+			if diff := cmp.Diff(tc.min, min); diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGapsTooFewOccurrences(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeframe := MustNew("0 0 31 * 1 *")
+
+	if _, ok := timeframe.MaxGap(start, start.AddDate(1, 0, 0)); ok {
+		t.Errorf("want %t, got %t", false, ok)
+	}
+	if _, ok := timeframe.MinGap(start, start.AddDate(0, 6, 0)); ok {
+		t.Errorf("want %t, got %t", false, ok)
+	}
+}
+
+// TestGapsMatchNextN checks the gaps found by walking windows against those between each of
+// the occurrences NextN returns.
+func TestGapsMatchNextN(t *testing.T) {
+	start := time.Date(2020, 3, 6, 7, 30, 0, 0, time.UTC)
+	end := time.Date(2020, 3, 10, 11, 15, 0, 0, time.UTC)
+
+	timeframes := map[string]Timeframe{
+		"hours":        MustNew("* 8-11 * * 1-5 *"),
+		"exclusion":    MustNew("* 8-11 * * * *").Except(MustNew("0,30 10 * * * *")),
+		"alternatives": MustNew("0 8 * * * * || * 9 * * 6 *"),
+		"interval":     MustNew("@every 7h"),
+	}
+
+	for name, timeframe := range timeframes {
+		t.Run(name, func(t *testing.T) {
+			occurrences := []time.Time{}
+			for _, occurrence := range timeframe.NextN(start.Add(-time.Nanosecond), 10000) {
+				if occurrence.Before(end) {
+					occurrences = append(occurrences, occurrence)
+				}
+			}
+
+			var wantMax, wantMin Gap
+			for i := 1; i < len(occurrences); i++ {
+				gap := Gap{Start: occurrences[i-1], End: occurrences[i]}
+				if i == 1 || gap.Duration() > wantMax.Duration() {
+					wantMax = gap
+				}
+				if i == 1 || gap.Duration() < wantMin.Duration() {
+					wantMin = gap
+				}
+			}
+
+			max, _ := timeframe.MaxGap(start, end)
+			if diff := cmp.Diff(wantMax, max); diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+
+			min, _ := timeframe.MinGap(start, end)
+			if diff := cmp.Diff(wantMin, min); diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
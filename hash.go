@@ -0,0 +1,70 @@
+package avail
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+)
+
+// maxHashedDay is the maximum value a hashed day of the month can take so that it occurs in
+// every month.
+const maxHashedDay = 28
+
+// parseHashField parses terms of the form "H", "H(<min>-<max>)", "H/<step>" and
+// "H(<min>-<max>)/<step>" by deriving a stable offset within the range from the hash key.
+func (f *Field) parseHashField(term, key string) (map[int]struct{}, error) {
+	if key == "" {
+		return nil, fmt.Errorf("term %s requires a hash key to be set with WithHashKey", term)
+	}
+
+	min, max := f.Min, f.Max
+	if f.Kind == day && max > maxHashedDay {
+		max = maxHashedDay
+	}
+
+	matches := hashRegex.FindStringSubmatch(term)
+	if matches[1] != "" {
+		min, _ = strconv.Atoi(matches[2])
+		max, _ = strconv.Atoi(matches[3])
+
+		if min > max {
+			return nil, reasonf(ErrInvalidTerm, "first value(%d) cannot be greater than second(%d)", min, max)
+		}
+
+		if min < f.Min {
+			return nil, reasonf(ErrOutOfBounds, "value(%d) cannot be less than min(%d)", min, f.Min)
+		}
+
+		if max > f.Max {
+			return nil, reasonf(ErrOutOfBounds, "value(%d) cannot be more than max(%d)", max, f.Max)
+		}
+	}
+
+	size := max - min + 1
+	step := size
+	if matches[4] != "" {
+		step, _ = strconv.Atoi(matches[5])
+		if step < 1 {
+			return nil, reasonf(ErrInvalidTerm, "step(%d) must be at least 1", step)
+		}
+		if step > size {
+			step = size
+		}
+	}
+
+	set := map[int]struct{}{}
+	for value := min + hashOffset(key, f.Kind, step); value <= max; value += step {
+		set[value] = struct{}{}
+	}
+
+	return set, nil
+}
+
+// hashOffset deterministically derives an offset between 0 and size-1 from the key. The
+// field kind is included in the hash so different fields of the same key are independent.
+func hashOffset(key string, kind FieldType, size int) int {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(key))
+	_, _ = hash.Write([]byte(kind))
+	return int(hash.Sum64() % uint64(size))
+}
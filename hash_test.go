@@ -0,0 +1,111 @@
+package avail
+
+import (
+	"testing"
+)
+
+func TestHashTerms(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		key        string
+		field      func(ParsedExpression) Field
+		wantCount  int
+		wantMin    int
+		wantMax    int
+	}{
+		"single minute": {
+			"H * * * * *", "backup",
+			func(p ParsedExpression) Field { return p.Minutes }, 1, 0, 59,
+		},
+		"minute within range": {
+			"H(0-29) * * * * *", "backup",
+			func(p ParsedExpression) Field { return p.Minutes }, 1, 0, 29,
+		},
+		"minute step": {
+			"H/15 * * * * *", "backup",
+			func(p ParsedExpression) Field { return p.Minutes }, 4, 0, 59,
+		},
+		"step within range": {
+			"H(0-29)/10 * * * * *", "backup",
+			func(p ParsedExpression) Field { return p.Minutes }, 3, 0, 29,
+		},
+		"day limited to every month": {
+			"0 0 H * * *", "backup",
+			func(p ParsedExpression) Field { return p.Days }, 1, 1, 28,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression, WithHashKey(tc.key))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			field := tc.field(avail.ParsedExpression)
+			if len(field.Values) != tc.wantCount {
+				t.Errorf("incorrect number of values; want %d, got %d", tc.wantCount, len(field.Values))
+			}
+
+			for value := range field.Values {
+				if value < tc.wantMin || value > tc.wantMax {
+					t.Errorf("value(%d) not within %d-%d", value, tc.wantMin, tc.wantMax)
+				}
+			}
+
+			again, err := New(tc.expression, WithHashKey(tc.key))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(again.ParsedExpression.Minutes.Values) != len(avail.ParsedExpression.Minutes.Values) {
+				t.Fatal("hashed values should be stable for the same key")
+			}
+			for value := range avail.ParsedExpression.Minutes.Values {
+				if _, ok := again.ParsedExpression.Minutes.Values[value]; !ok {
+					t.Fatal("hashed values should be stable for the same key")
+				}
+			}
+		})
+	}
+}
+
+func TestHashTermsSpread(t *testing.T) {
+	seen := map[int]struct{}{}
+	for _, key := range []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel"} {
+		avail, err := New("H * * * * *", WithHashKey(key))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for value := range avail.ParsedExpression.Minutes.Values {
+			seen[value] = struct{}{}
+		}
+	}
+
+	if len(seen) < 4 {
+		t.Errorf("hashed values should be spread across the range; got %d distinct values", len(seen))
+	}
+}
+
+func TestHashTermsUnparseable(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+	}{
+		"missing hash key":     {"H * * * * *", nil},
+		"range out of bounds":  {"H(0-60) * * * * *", []Option{WithHashKey("backup")}},
+		"range wrong order":    {"H(30-10) * * * * *", []Option{WithHashKey("backup")}},
+		"zero step":            {"H/0 * * * * *", []Option{WithHashKey("backup")}},
+		"malformed hash range": {"H(0-) * * * * *", []Option{WithHashKey("backup")}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(tc.expression, tc.opts...)
+			if err == nil {
+				t.Errorf("expression %s should not be parsed successfully", tc.expression)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package avail
+
+import (
+	"reflect"
+	"time"
+)
+
+// HolidayProvider reports which dates are holidays, for Timeframes and Calendars which exclude
+// them or are only able on them. Providers may be backed by anything from a fixed list, like
+// StaticHolidays, to a regional holiday calendar.
+type HolidayProvider interface {
+	// IsHoliday reports whether the date of the time given is a holiday. The time is in the
+	// location the Timeframe is evaluated in and may be any time within the day.
+	IsHoliday(t time.Time) bool
+}
+
+// WithHolidaysExcluded makes the Timeframe never able on the holidays the provider reports.
+// Ex. "* 9-16 * * 1-5 *" with the public holidays of a region is able during business hours
+// on every business day. Holidays are skipped a day at a time, so Next, Count and the other
+// occurrence functions stay cheap. Intervals cannot exclude holidays.
+func WithHolidaysExcluded(provider HolidayProvider) Option {
+	return func(o *options) {
+		o.holidays, o.onlyHolidays = provider, false
+	}
+}
+
+// WithHolidaysOnly makes the Timeframe only able on the holidays the provider reports. Ex.
+// "0 10 * * * *" with a list of holidays occurs at 10:00 on each of them. Intervals cannot be
+// limited to holidays.
+func WithHolidaysOnly(provider HolidayProvider) Option {
+	return func(o *options) {
+		o.holidays, o.onlyHolidays = provider, true
+	}
+}
+
+// matchesHolidays reports whether the date is allowed by the options' holiday provider.
+func (o options) matchesHolidays(date time.Time) bool {
+	return o.holidays == nil || o.holidays.IsHoliday(date) == o.onlyHolidays
+}
+
+// sameHolidays reports whether both options treat holidays in the same way. Providers which
+// cannot be compared are only the same if neither has one.
+func (o options) sameHolidays(other options) bool {
+	if o.holidays == nil || other.holidays == nil {
+		return o.holidays == nil && other.holidays == nil
+	}
+	if !reflect.TypeOf(o.holidays).Comparable() || !reflect.TypeOf(other.holidays).Comparable() {
+		return false
+	}
+	return o.holidays == other.holidays && o.onlyHolidays == other.onlyHolidays
+}
+
+// StaticHolidays is a HolidayProvider backed by a list of dates, for holidays which are known
+// ahead of time, such as a company's. Dates are compared by their year, month and day alone,
+// irrespective of location.
+type StaticHolidays struct {
+	dates map[date]struct{}
+}
+
+// NewStaticHolidays returns StaticHolidays holding the dates given.
+func NewStaticHolidays(dates ...time.Time) *StaticHolidays {
+	holidays := &StaticHolidays{dates: map[date]struct{}{}}
+	holidays.Add(dates...)
+	return holidays
+}
+
+// Add adds the dates given to the holidays.
+func (s *StaticHolidays) Add(dates ...time.Time) {
+	for _, day := range dates {
+		s.dates[dateOf(day)] = struct{}{}
+	}
+}
+
+// IsHoliday reports whether the date of the time given is one of the holidays.
+func (s *StaticHolidays) IsHoliday(t time.Time) bool {
+	_, ok := s.dates[dateOf(t)]
+	return ok
+}
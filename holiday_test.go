@@ -0,0 +1,151 @@
+package avail
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHolidaysExcluded(t *testing.T) {
+	holidays := NewStaticHolidays(time.Date(2020, 7, 3, 0, 0, 0, 0, time.UTC), time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC))
+
+	timeframe, err := New("0 9 * * 1-5 *", WithHolidaysExcluded(holidays))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		time time.Time
+		want bool
+	}{
+		"business day": {time.Date(2020, 7, 2, 9, 0, 0, 0, time.UTC), true},
+		"holiday":      {time.Date(2020, 7, 3, 9, 0, 0, 0, time.UTC), false},
+		"weekend":      {time.Date(2020, 7, 4, 9, 0, 0, 0, time.UTC), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := timeframe.Able(tc.time); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+
+	want := []time.Time{
+		time.Date(2020, 7, 2, 9, 0, 0, 0, time.UTC),
+		time.Date(2020, 7, 6, 9, 0, 0, 0, time.UTC),
+	}
+	diff := cmp.Diff(want, timeframe.NextN(time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC), 2))
+	if diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+
+	prev, err := timeframe.Prev(time.Date(2020, 7, 6, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !prev.Equal(want[0]) {
+		t.Errorf("want %s, got %s", want[0], prev)
+	}
+
+	// July 2020 has 23 weekdays, one of which is a holiday.
+	if got := timeframe.Count(time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC)); got != 22 {
+		t.Errorf("want %d, got %d", 22, got)
+	}
+
+	if got, want := timeframe.Describe(), "At 09:00 on Monday through Friday, except on holidays"; got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestHolidaysOnly(t *testing.T) {
+	holidays := NewStaticHolidays(time.Date(2020, 7, 3, 0, 0, 0, 0, time.UTC))
+	holidays.Add(time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC))
+
+	timeframe, err := New("0 10 * * * *", WithHolidaysOnly(holidays))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []time.Time{
+		time.Date(2020, 7, 3, 10, 0, 0, 0, time.UTC),
+		time.Date(2020, 12, 25, 10, 0, 0, 0, time.UTC),
+	}
+	diff := cmp.Diff(want, timeframe.NextN(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), 3))
+	if diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+}
+
+func TestHolidaysUnsupported(t *testing.T) {
+	holidays := NewStaticHolidays(time.Date(2020, 7, 3, 0, 0, 0, 0, time.UTC))
+
+	if _, err := New("@every 1h", WithHolidaysExcluded(holidays)); err == nil {
+		t.Errorf("intervals should not accept holidays")
+	}
+
+	timeframe, err := New("0 9 * * 1-5 *", WithHolidaysExcluded(holidays), WithExpandedJSON())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := json.Marshal(timeframe); err == nil {
+		t.Errorf("expected error marshalling holidays to JSON")
+	}
+	if _, err := timeframe.MarshalText(); err == nil {
+		t.Errorf("expected error marshalling holidays to text")
+	}
+	if _, err := timeframe.MarshalBinary(); err == nil {
+		t.Errorf("expected error marshalling holidays to binary")
+	}
+	if _, err := timeframe.ToSQLPredicate(Postgres, "created"); err == nil {
+		t.Errorf("expected error converting holidays to SQL")
+	}
+	if _, err := timeframe.ToRRULE(); err == nil {
+		t.Errorf("expected error converting holidays to an RRULE")
+	}
+}
+
+func TestHolidaysCombined(t *testing.T) {
+	holidays := NewStaticHolidays(time.Date(2020, 7, 3, 0, 0, 0, 0, time.UTC))
+	weekdays := MustNew("* 9-16 * * 1-5 *")
+	excluded := MustNew("* 9-16 * * 1-5 *", WithHolidaysExcluded(holidays))
+
+	if weekdays.Equal(excluded) {
+		t.Errorf("want %t, got %t", false, true)
+	}
+	if !excluded.Equal(MustNew("* 9-16 * * 1-5 *", WithHolidaysExcluded(holidays))) {
+		t.Errorf("want %t, got %t", true, false)
+	}
+	if !weekdays.Contains(excluded) || excluded.Contains(weekdays) {
+		t.Errorf("want weekdays to contain only the business days")
+	}
+
+	mornings := MustNew("* 9-11 * * * *").Intersect(excluded)
+	if mornings.Able(time.Date(2020, 7, 3, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("want %t, got %t", false, true)
+	}
+	if !mornings.Able(time.Date(2020, 7, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("want %t, got %t", true, false)
+	}
+
+	calendar := NewCalendar(time.UTC)
+	calendar.Include(0, weekdays)
+	calendar.Include(1, MustNew("0 12 * * * *", WithHolidaysOnly(holidays)))
+	if !calendar.Able(time.Date(2020, 7, 3, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("want %t, got %t", true, false)
+	}
+
+	calendar.ExcludeHolidays(holidays)
+	if calendar.Able(time.Date(2020, 7, 3, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("want %t, got %t", false, true)
+	}
+	next, err := calendar.Next(time.Date(2020, 7, 2, 17, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2020, 7, 6, 9, 0, 0, 0, time.UTC); !next.Equal(want) {
+		t.Errorf("want %s, got %s", want, next)
+	}
+}
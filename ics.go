@@ -0,0 +1,99 @@
+package avail
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+)
+
+// icsTimeFormat is the RFC 5545 form of a date and time in UTC.
+const icsTimeFormat = "20060102T150405Z"
+
+// icsLineLength is the number of octets after which RFC 5545 lines are folded.
+const icsLineLength = 75
+
+// ToICS returns an RFC 5545 iCalendar holding an event for each of the next n windows of the
+// Timeframe after the time given, as returned by NextWindow, each with the summary given. It
+// is intended for publishing schedules, such as on-call rotations or maintenance windows, to be
+// subscribed to from calendar applications. Ex. "* 2-4 * * 6 *" becomes a three hour event
+// every Saturday.
+//
+// Events are given in UTC so that the calendar needs no timezone definitions. Their UIDs are
+// derived from the expression and the start of the window, so they are stable between exports,
+// and their DTSTAMP is the current time, as given by the clock set with WithClock. Fewer than n
+// events are exported if the Timeframe stops being able first.
+func (a *Timeframe) ToICS(after time.Time, n int, summary string) string {
+	windows := []Window{}
+	for current := after; len(windows) < n; {
+		window, ok := a.NextWindow(current)
+		if !ok {
+			break
+		}
+		windows = append(windows, window)
+		current = window.End
+	}
+
+	return icsCalendar(windows, a.Expression, summary, a.Now())
+}
+
+// ToICS returns an RFC 5545 iCalendar holding an event for each of the next n windows of the
+// calendar after the time given, as the Timeframe's ToICS does.
+func (c *Calendar) ToICS(after time.Time, n int, summary string) string {
+	if c.timeframe == nil {
+		return icsCalendar(nil, "", summary, time.Now())
+	}
+	return c.timeframe.ToICS(after, n, summary)
+}
+
+// icsCalendar renders the windows as the events of a VCALENDAR.
+func icsCalendar(windows []Window, expression, summary string, stamp time.Time) string {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(expression))
+	prefix := fmt.Sprintf("%016x", hash.Sum64())
+
+	lines := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//clintjedwards//avail//EN", "CALSCALE:GREGORIAN"}
+	for _, window := range windows {
+		start := window.Start.UTC().Format(icsTimeFormat)
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+prefix+"-"+start+"@avail",
+			"DTSTAMP:"+stamp.UTC().Format(icsTimeFormat),
+			"DTSTART:"+start,
+			"DTEND:"+window.End.UTC().Format(icsTimeFormat),
+			"SUMMARY:"+icsEscape(summary),
+			"END:VEVENT",
+		)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	var calendar strings.Builder
+	for _, line := range lines {
+		calendar.WriteString(icsFold(line))
+		calendar.WriteString("\r\n")
+	}
+	return calendar.String()
+}
+
+// icsEscape escapes the characters RFC 5545 reserves within text values.
+func icsEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+// icsFold folds the line so that no part of it is longer than RFC 5545 allows, continuing each
+// part on a line starting with a space. Lines are only broken between characters.
+func icsFold(line string) string {
+	var folded strings.Builder
+	length := 0
+	for _, character := range line {
+		size := len(string(character))
+		if length+size > icsLineLength {
+			folded.WriteString("\r\n ")
+			// The leading space counts towards the length of the continued line.
+			length = 1
+		}
+		folded.WriteRune(character)
+		length += size
+	}
+	return folded.String()
+}
@@ -0,0 +1,86 @@
+package avail
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToICS(t *testing.T) {
+	stamp := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timeframe, err := New("CRON_TZ=America/New_York * 2-4 * * 6 *", WithClock(func() time.Time { return stamp }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := timeframe.ToICS(time.Date(2020, 6, 1, 0, 0, 0, 0, location), 2, "Maintenance; db, cache")
+	uid := strings.Split(strings.Split(got, "UID:")[1], "-")[0]
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//clintjedwards//avail//EN",
+		"CALSCALE:GREGORIAN",
+		"BEGIN:VEVENT",
+		"UID:" + uid + "-20200606T060000Z@avail",
+		"DTSTAMP:20200601T000000Z",
+		"DTSTART:20200606T060000Z",
+		"DTEND:20200606T090000Z",
+		`SUMMARY:Maintenance\; db\, cache`,
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:" + uid + "-20200613T060000Z@avail",
+		"DTSTAMP:20200601T000000Z",
+		"DTSTART:20200613T060000Z",
+		"DTEND:20200613T090000Z",
+		`SUMMARY:Maintenance\; db\, cache`,
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	if got != want {
+		t.Errorf("incorrect calendar;\nwant %q\ngot  %q", want, got)
+	}
+
+	if again := timeframe.ToICS(time.Date(2020, 6, 1, 0, 0, 0, 0, location), 2, "Maintenance; db, cache"); again != got {
+		t.Errorf("exports should be stable")
+	}
+}
+
+func TestToICSEnds(t *testing.T) {
+	timeframe := MustNew("0 9 1 1 * 2020")
+
+	got := timeframe.ToICS(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), 5, "New year")
+	if count := strings.Count(got, "BEGIN:VEVENT"); count != 1 {
+		t.Errorf("want %d, got %d", 1, count)
+	}
+
+	calendar := NewCalendar(nil)
+	if got := calendar.ToICS(time.Now(), 5, "Nothing"); strings.Contains(got, "BEGIN:VEVENT") {
+		t.Errorf("empty calendars should export no events")
+	}
+	calendar.Include(0, timeframe)
+	if got := calendar.ToICS(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC), 5, "New year"); strings.Count(got, "BEGIN:VEVENT") != 1 {
+		t.Errorf("want the calendar's single event")
+	}
+}
+
+func TestICSFold(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("é", 60)
+
+	folded := icsFold(line)
+	for _, part := range strings.Split(folded, "\r\n") {
+		if len(part) > icsLineLength {
+			t.Errorf("line %q is longer than %d octets", part, icsLineLength)
+		}
+	}
+
+	if unfolded := strings.Replace(folded, "\r\n ", "", -1); unfolded != line {
+		t.Errorf("want %q, got %q", line, unfolded)
+	}
+}
@@ -6,6 +6,9 @@ package avail
 // * Wildcard: Used to represent all possible values within a certain term. ex. *
 // * List: Used to represent an explicit list of values. ex. 1,2,3
 // * Value: Used to represent a single value. ex. 2
+// * Last: Used to represent the last day of the month, optionally offset. ex. L-3
+// * LastWeekday: Used to represent the last weekday (Mon-Fri) of the month. ex. LW
+// * Hash: Used to represent a stable value derived from a hash key. ex. H, H/15, H(0-29)
 //
 // A cron term is a single field in a complete cron expression.
 // Ex. in the expression: "0 15 10 * * *", "15" would be a term of type "value".
@@ -14,31 +17,40 @@ import "regexp"
 
 // List of regexs that we use to match against a single cron term for identification.
 var (
-	spanRegex     = regexp.MustCompile(`^[0-9]+-[0-9]+$`)
-	wildcardRegex = regexp.MustCompile(`^\*$`)
-	listRegex     = regexp.MustCompile(`,+`)
-	valueRegex    = regexp.MustCompile(`^([0-9]+)$`)
+	spanRegex        = regexp.MustCompile(`^[0-9]+-[0-9]+$`)
+	wildcardRegex    = regexp.MustCompile(`^\*$`)
+	listRegex        = regexp.MustCompile(`,+`)
+	valueRegex       = regexp.MustCompile(`^([0-9]+)$`)
+	lastRegex        = regexp.MustCompile(`(?i)^L(-[0-9]+)?$`)
+	lastWeekdayRegex = regexp.MustCompile(`(?i)^LW$`)
+	hashRegex        = regexp.MustCompile(`(?i)^H(\(([0-9]+)-([0-9]+)\))?(/([0-9]+))?$`)
 )
 
 // termKind is an enum which represents different term kinds
 type termKind string
 
 const (
-	span     termKind = "span"
-	wildcard termKind = "wildcard"
-	list     termKind = "list"
-	value    termKind = "value"
-	unknown  termKind = "unknown"
+	span        termKind = "span"
+	wildcard    termKind = "wildcard"
+	list        termKind = "list"
+	value       termKind = "value"
+	last        termKind = "last"
+	lastWeekday termKind = "lastWeekday"
+	hash        termKind = "hash"
+	unknown     termKind = "unknown"
 )
 
 // termRegexToType stores the mapping between a term's regex representation
 // and the concrete term type it is. This is used to help identify the term
 // so that we can run the correct parser later.
 var termRegexToType = map[*regexp.Regexp]termKind{
-	spanRegex:     span,
-	wildcardRegex: wildcard,
-	listRegex:     list,
-	valueRegex:    value,
+	spanRegex:        span,
+	wildcardRegex:    wildcard,
+	listRegex:        list,
+	valueRegex:       value,
+	lastRegex:        last,
+	lastWeekdayRegex: lastWeekday,
+	hashRegex:        hash,
 }
 
 func identifyTermKind(term string) termKind {
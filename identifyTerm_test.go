@@ -7,11 +7,14 @@ func TestIdentifyTermType(t *testing.T) {
 		input string
 		want  termKind
 	}{
-		"span":     {"1-12", span},
-		"wildcard": {"*", wildcard},
-		"list":     {"1,2,3,4,5,6", list},
-		"value":    {"45", value},
-		"unknown":  {"233)#!", unknown},
+		"span":         {"1-12", span},
+		"wildcard":     {"*", wildcard},
+		"list":         {"1,2,3,4,5,6", list},
+		"value":        {"45", value},
+		"last":         {"L-3", last},
+		"last weekday": {"LW", lastWeekday},
+		"hash":         {"H(0-29)/10", hash},
+		"unknown":      {"233)#!", unknown},
 	}
 
 	for name, tc := range tests {
@@ -0,0 +1,193 @@
+package avail
+
+import (
+	"strings"
+	"time"
+)
+
+// Intersect returns a Timeframe which is able only when both the Timeframe and the other are.
+// Ex. "* 9-17 * * 1-5 *" intersected with "* 12-20 * * * *" is "* 12-17 * * 1-5 *".
+//
+// When both resolve to values in the same location the result is parsed from an expression
+// of the values they have in common, so it can be formatted and marshalled like any other.
+// Alternatives are intersected pairwise and exclusions of either are kept. Otherwise, as when
+// the locations differ or both use relative days like "L", the result is the Timeframe with
+// an exclusion of every time the other is not able.
+func (a Timeframe) Intersect(other Timeframe) Timeframe {
+	plain, otherPlain := a, other
+	plain.exclusions, otherPlain.exclusions = nil, nil
+
+	seconds := a.options.seconds || other.options.seconds
+	expression, ok := intersectExpression(&plain, &otherPlain, seconds)
+	if !ok || a.options.dstPolicy != other.options.dstPolicy || a.options.holidays != nil || other.options.holidays != nil {
+		return a.Except(other.complement())
+	}
+
+	opts := []Option{}
+	if seconds {
+		opts = append(opts, WithSeconds())
+	}
+	if a.options.maxYear != 0 {
+		opts = append(opts, WithYearRange(a.options.minYear, a.options.maxYear))
+	}
+	if a.options.dstPolicy != DSTWallClock {
+		opts = append(opts, WithDSTPolicy(a.options.dstPolicy))
+	}
+	if a.options.granularity != 0 {
+		opts = append(opts, WithGranularity(a.options.granularity))
+	}
+	if a.options.bounded() || other.options.bounded() {
+		opts = append(opts, WithActiveBetween(a.options.intersectActive(other.options)))
+	}
+
+	intersection, err := New(expression, opts...)
+	if err != nil {
+		return a.Except(other.complement())
+	}
+
+	for _, exclusion := range append(append([]Timeframe{}, a.exclusions...), other.exclusions...) {
+		intersection = intersection.Except(exclusion)
+	}
+
+	return intersection
+}
+
+// complement returns a Timeframe which is able whenever the Timeframe is not.
+func (a Timeframe) complement() Timeframe {
+	always := Timeframe{Expression: everyPrefix + " 1m", Interval: time.Minute}
+	return always.Except(a)
+}
+
+// intersectExpression returns a native expression matching the times both Timeframes, which
+// must not have exclusions, match. It returns false if there is no such expression.
+func intersectExpression(a, b *Timeframe, seconds bool) (string, bool) {
+	if locationName(a.Location) != locationName(b.Location) {
+		return "", false
+	}
+
+	prefix := ""
+	if a.Location != nil {
+		prefix = locationPrefixes[0] + a.Location.String() + " "
+	}
+
+	expressions := []string{}
+	for _, left := range intersectionParts(a) {
+		for _, right := range intersectionParts(b) {
+			expression, ok := intersectTimeframes(&left, &right, seconds)
+			if !ok {
+				return "", false
+			}
+			if expression != "" {
+				expressions = append(expressions, expression)
+			}
+		}
+	}
+
+	// Timeframes which are never able cannot be written as an expression.
+	if len(expressions) == 0 {
+		return "", false
+	}
+
+	return prefix + strings.Join(sortedUnique(expressions), " "+alternativeSeparator+" "), true
+}
+
+// intersectionParts returns the alternatives of a combined Timeframe, or the Timeframe itself,
+// without their locations.
+func intersectionParts(a *Timeframe) []Timeframe {
+	parts := []Timeframe{*a}
+	if a.alternatives != nil {
+		parts = append([]Timeframe{}, a.alternatives...)
+	}
+
+	for i := range parts {
+		parts[i].Location = nil
+	}
+	return parts
+}
+
+// intersectTimeframes returns a native expression, without a location prefix, matching the
+// times both of the plain Timeframes match. The expression is empty if they have no values in
+// common.
+func intersectTimeframes(a, b *Timeframe, seconds bool) (string, bool) {
+	switch {
+	case a.Interval != 0 && b.Interval != 0:
+		// Both count from the same epoch, so they coincide at multiples of the least common
+		// multiple of their intervals.
+		if a.options.precision() != b.options.precision() {
+			return "", false
+		}
+		interval := a.Interval / gcd(a.Interval, b.Interval) * b.Interval
+		return everyPrefix + " " + interval.String(), true
+	case a.Interval != 0 || b.Interval != 0:
+		return "", false
+	case a.eitherDay() || b.eitherDay():
+		// Dates matching either day term cannot be intersected term by term.
+		return "", false
+	}
+
+	// Without a seconds term every second of a matching minute is able.
+	left, right := a.ParsedExpression, b.ParsedExpression
+	if !a.options.seconds {
+		left.Seconds = Field{Kind: second, Min: 0, Max: 59, Values: generateSequentialSet(0, 59)}
+	}
+	if !b.options.seconds {
+		right.Seconds = Field{Kind: second, Min: 0, Max: 59, Values: generateSequentialSet(0, 59)}
+	}
+
+	intersection := Timeframe{options: options{seconds: seconds, minYear: a.options.minYear, maxYear: a.options.maxYear}}
+	parsed := &intersection.ParsedExpression
+	fields := []struct {
+		left, right Field
+		target      *Field
+	}{
+		{left.Seconds, right.Seconds, &parsed.Seconds},
+		{left.Minutes, right.Minutes, &parsed.Minutes},
+		{left.Hours, right.Hours, &parsed.Hours},
+		{left.Days, right.Days, &parsed.Days},
+		{left.Months, right.Months, &parsed.Months},
+		{left.Weekdays, right.Weekdays, &parsed.Weekdays},
+		{left.Years, right.Years, &parsed.Years},
+	}
+
+	for i, field := range fields {
+		bounds := nativeFields[i]
+		values, ok := intersectField(field.left, field.right)
+		if !ok {
+			return "", false
+		}
+		if len(values.Values) == 0 && len(values.relative) == 0 {
+			return "", true
+		}
+		if bounds.kind == year {
+			bounds.min, bounds.max = a.options.yearBounds(bounds.min, bounds.max)
+		}
+		values.Kind, values.Min, values.Max = bounds.kind, bounds.min, bounds.max
+		*field.target = values
+	}
+
+	expression, err := intersection.formatNative(true)
+	return expression, err == nil
+}
+
+// intersectField returns a field matching the values both fields match. It returns false if
+// both have relative values, or one has relative values and the other is not a wildcard, as
+// the days those match depend on the month.
+func intersectField(a, b Field) (Field, bool) {
+	switch {
+	case a.isWildcard() && len(b.relative) > 0:
+		return b, true
+	case b.isWildcard() && len(a.relative) > 0:
+		return a, true
+	case len(a.relative) > 0 || len(b.relative) > 0:
+		return Field{}, false
+	}
+
+	values := map[int]struct{}{}
+	for value := range a.values() {
+		if b.Has(value) {
+			values[value] = struct{}{}
+		}
+	}
+
+	return Field{Values: values}, true
+}
@@ -0,0 +1,66 @@
+package avail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIntersect(t *testing.T) {
+	tests := map[string]struct {
+		first, second Timeframe
+		want          string
+	}{
+		"hours":          {MustNew("* 9-17 * * 1-5 *"), MustNew("* 12-20 * * * *"), "* 12-17 * * 1-5 *"},
+		"disjoint lists": {MustNew("0,30 * * * * *"), MustNew("0,15,45 * * * * *"), "0 * * * * *"},
+		"last day":       {MustNew("0 0 L * * *"), MustNew("0 0 * * 1-5 *"), "0 0 L * 1-5 *"},
+		"location":       {MustNew("CRON_TZ=Europe/Berlin * 9-17 * * * *"), MustNew("TZ=Europe/Berlin * * * * 1-5 *"), "CRON_TZ=Europe/Berlin * 9-17 * * 1-5 *"},
+		"alternatives": {MustNew("* 9 * * * * || * 14 * * * *"), MustNew("* 8-10 * * 1-5 * || * 14 * * 0,6 *"),
+			"* 14 * * 0,6 * || * 9 * * 1-5 *"},
+		"seconds":           {MustNew("0,30 * 9 * * * *", WithSeconds()), MustNew("0-29 9 * * * *"), "0,30 0-29 9 * * * *"},
+		"intervals":         {MustNew("@every 10m"), MustNew("@every 15m"), "@every 30m0s"},
+		"quartz":            {MustNew("0 0 9-17 ? * MON-FRI", WithDialect(Quartz)), MustNew("0 12-20 * * * *"), "0 0 12-17 * * 1-5 1970-2099"},
+		"different zones":   {MustNew("CRON_TZ=America/New_York * 9-17 * * * *"), MustNew("CRON_TZ=Europe/London * 9-17 * * * *"), ""},
+		"interval and cron": {MustNew("@every 20m"), MustNew("* 9-17 * * * *"), ""},
+		"relative days":     {MustNew("0 0 L * * *"), MustNew("0 0 LW * * *"), ""},
+		"either day":        {MustNew("* 9 1 * 1 *", WithDomDowOr()), MustNew("* 8-10 * * * *"), ""},
+		"either day unused": {MustNew("* 9 * * 1 *", WithDomDowOr()), MustNew("* * 1 * * *"), "* 9 1 * 1 *"},
+		"exclusions": {MustNew("* 9-17 * * 1-5 *").Except(MustNew("* 12 * * * *")), MustNew("* 8,9,10,12,13 * * * *"),
+			"* 9,10,12,13 * * 1-5 *"},
+	}
+
+	start := time.Date(2021, 1, 25, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			intersection := tc.first.Intersect(tc.second)
+
+			if tc.want != "" && intersection.Expression != tc.want {
+				t.Errorf("incorrect expression; want %q, got %q", tc.want, intersection.Expression)
+			}
+
+			for current := start; current.Before(end); current = current.Add(time.Minute) {
+				want := tc.first.Able(current) && tc.second.Able(current)
+				if got := intersection.Able(current); got != want {
+					t.Fatalf("at %s; want %t, got %t", current, want, got)
+				}
+			}
+
+			next, err := intersection.Next(start)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tc.first.Able(next) || !tc.second.Able(next) {
+				t.Errorf("next occurrence %s should be able in both", next)
+			}
+		})
+	}
+}
+
+func TestIntersectNever(t *testing.T) {
+	intersection := MustNew("* 9 * * * *").Intersect(MustNew("* 10 * * * *"))
+
+	if _, err := intersection.Next(time.Date(2021, 1, 25, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("disjoint timeframes should have no occurrence")
+	}
+}
@@ -0,0 +1,48 @@
+package avail
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// everyPrefix denotes an interval expression. Ex. "@every 1h30m".
+const everyPrefix = "@every"
+
+// intervalEpoch is the instant all interval expressions are anchored to. An interval matches
+// every time that is a whole multiple of its duration away from this instant.
+var intervalEpoch = time.Unix(0, 0).UTC()
+
+// isIntervalExpression reports whether the expression is an "@every <duration>" expression.
+func isIntervalExpression(expression string) bool {
+	terms := strings.Fields(expression)
+	return len(terms) > 0 && strings.EqualFold(terms[0], everyPrefix)
+}
+
+// parseInterval parses the duration portion of an "@every <duration>" expression. Since
+// avail evaluates times at minute precision (or second precision when seconds are enabled)
+// the duration must be a positive whole number of that precision.
+func parseInterval(expression string, precision time.Duration) (time.Duration, error) {
+	rawDuration := strings.TrimSpace(strings.TrimSpace(expression)[len(everyPrefix):])
+
+	interval, err := time.ParseDuration(rawDuration)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse interval %s: %v", rawDuration, err)
+	}
+
+	if interval < precision {
+		return 0, fmt.Errorf("interval(%s) cannot be less than %s", interval, precision)
+	}
+
+	if interval%precision != 0 {
+		return 0, fmt.Errorf("interval(%s) must be a whole multiple of %s", interval, precision)
+	}
+
+	return interval, nil
+}
+
+// ableInterval evaluates if the time given, truncated to the precision, falls on a multiple
+// of the interval.
+func ableInterval(interval, precision time.Duration, t time.Time) bool {
+	return t.Truncate(precision).Sub(intervalEpoch)%interval == 0
+}
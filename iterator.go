@@ -0,0 +1,61 @@
+package avail
+
+import "time"
+
+// Iterator walks the times at which a Timeframe is able, in order, computing each one only
+// when it is asked for. Iterators are created with Timeframe.Occurrences.
+type Iterator struct {
+	timeframe *Timeframe
+	location  *time.Location
+	current   time.Time
+	end       time.Time
+	done      bool
+}
+
+// Occurrences returns an Iterator over the times strictly after start at which the Timeframe is
+// able. Like Next, times are in the Timeframe's location if it has one and the location of
+// start otherwise. Ex.
+//
+//    it := timeframe.Occurrences(time.Now()).Until(deadline)
+//    for next, ok := it.Next(); ok; next, ok = it.Next() {
+//        ...
+//    }
+//
+// A walk can be resumed later by calling Occurrences with the iterator's Position.
+func (a *Timeframe) Occurrences(start time.Time) *Iterator {
+	return &Iterator{
+		timeframe: a,
+		location:  start.Location(),
+		current:   start,
+	}
+}
+
+// Until bounds the iterator so that it stops before end. It returns the iterator so it can be
+// chained with Occurrences.
+func (it *Iterator) Until(end time.Time) *Iterator {
+	it.end = end
+	return it
+}
+
+// Next returns the next time the Timeframe is able. The second return value is false once the
+// iterator's end, or the end of the expression's years, has been reached.
+func (it *Iterator) Next() (time.Time, bool) {
+	if it.done {
+		return time.Time{}, false
+	}
+
+	next, err := it.timeframe.nextFrom(it.current, it.location)
+	if err != nil || (!it.end.IsZero() && !next.Before(it.end)) {
+		it.done = true
+		return time.Time{}, false
+	}
+
+	it.current = next
+	return next, true
+}
+
+// Position returns the last time returned by Next, or the start of the iterator if Next has not
+// returned a time yet.
+func (it *Iterator) Position() time.Time {
+	return it.current
+}
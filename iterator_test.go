@@ -0,0 +1,76 @@
+package avail
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOccurrences(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		start      time.Time
+		end        time.Time
+		want       []time.Time
+	}{
+		"bounded": {"0 9 * * 1-5 *", time.Date(2020, 6, 5, 0, 0, 0, 0, time.UTC), time.Date(2020, 6, 9, 9, 0, 0, 0, time.UTC), []time.Time{
+			time.Date(2020, 6, 5, 9, 0, 0, 0, time.UTC),
+			time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC),
+		}},
+		"years run out": {"0 0 1 1 * 2099-2100", time.Date(2020, 6, 5, 0, 0, 0, 0, time.UTC), time.Time{}, []time.Time{
+			time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		"empty": {"0 9 * * * *", time.Date(2020, 6, 5, 10, 0, 0, 0, time.UTC), time.Date(2020, 6, 6, 9, 0, 0, 0, time.UTC), []time.Time{}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			it := avail.Occurrences(tc.start)
+			if !tc.end.IsZero() {
+				it.Until(tc.end)
+			}
+
+			got := []time.Time{}
+			for next, ok := it.Next(); ok; next, ok = it.Next() {
+				got = append(got, next)
+			}
+
+			diff := cmp.Diff(tc.want, got)
+			if diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+
+			if _, ok := it.Next(); ok {
+				t.Errorf("finished iterator should not return more times")
+			}
+		})
+	}
+}
+
+func TestOccurrencesResume(t *testing.T) {
+	avail, err := New("0 9 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2020, 6, 5, 0, 0, 0, 0, time.UTC)
+
+	it := avail.Occurrences(start)
+	it.Next()
+	it.Next()
+
+	resumed := avail.Occurrences(it.Position())
+	got, _ := resumed.Next()
+	want, _ := it.Next()
+
+	if !got.Equal(want) {
+		t.Errorf("resumed iterator should continue where it stopped; want %s, got %s", want, got)
+	}
+}
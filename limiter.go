@@ -0,0 +1,115 @@
+package avail
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Limiter is a rate limiter that only admits events while its Timeframe is able, and at most
+// limit of them in each period. Ex. a Timeframe of "* 9-16 * * 1-5 *" with a limit of 1000 per
+// hour only admits events on weekdays between 9am and 5pm and never more than 1000 in any
+// clock hour.
+//
+// Periods are consecutive windows, each starting at a multiple of the period since the zero
+// time, so an hourly period starts at the top of every hour. The count of admitted events is
+// reset at the start of each window rather than refilling continuously, so a burst at the end
+// of one window and another at the start of the next are each admitted in full.
+type Limiter struct {
+	timeframe Timeframe
+	limit     int
+	per       time.Duration
+
+	mu sync.Mutex
+	// count is the number of events admitted in the window starting at windowStart.
+	count       int
+	windowStart time.Time
+
+	// now returns the current time, as given by the Timeframe's clock; it is swapped out in
+	// tests.
+	now func() time.Time
+}
+
+// NewLimiter returns a Limiter which admits at most limit events per period, but only
+// during times the given Timeframe is able.
+func NewLimiter(timeframe Timeframe, limit int, per time.Duration) (*Limiter, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit(%d) must be greater than 0", limit)
+	}
+
+	if per <= 0 {
+		return nil, fmt.Errorf("period(%s) must be greater than 0", per)
+	}
+
+	limiter := &Limiter{
+		timeframe: timeframe,
+		limit:     limit,
+		per:       per,
+	}
+	limiter.now = limiter.timeframe.Now
+	return limiter, nil
+}
+
+// Allow reports whether an event may happen now. If it may, it is counted towards the limit.
+func (l *Limiter) Allow() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	wait := l.reserve(l.now())
+	return wait == 0
+}
+
+// Wait blocks until an event may happen and counts it towards the limit, or returns the
+// context's error if it is done first. Outside of the Timeframe, Wait sleeps until its next
+// occurrence, as given by Next, and once the limit is reached it waits for the start of the
+// next window. As with Timeframe's Wait, it checks the clock again at least every minute so that
+// changes to the wall clock are noticed.
+func (l *Limiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		wait := l.reserve(l.now())
+		l.mu.Unlock()
+
+		if wait == 0 {
+			return nil
+		}
+		if recheck := time.Duration(atomic.LoadInt64(&waitRecheck)); wait > recheck {
+			wait = recheck
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve attempts to admit an event at the given time. It returns zero if the event was
+// admitted, otherwise it returns how long the caller should wait before trying again, which is
+// the longest the Timeframe's clock is checked again after if it is never able again.
+// The caller must hold the lock.
+func (l *Limiter) reserve(now time.Time) time.Duration {
+	if !l.timeframe.Able(now) {
+		next, err := l.timeframe.Next(now)
+		if err != nil {
+			return time.Duration(atomic.LoadInt64(&waitRecheck))
+		}
+		return next.Sub(now)
+	}
+
+	if start := now.Truncate(l.per); !start.Equal(l.windowStart) {
+		l.windowStart, l.count = start, 0
+	}
+
+	if l.count < l.limit {
+		l.count++
+		return 0
+	}
+
+	return l.windowStart.Add(l.per).Sub(now)
+}
@@ -0,0 +1,182 @@
+package avail
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	timeframe, err := New("* 9-16 * * 1-5 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limiter, err := NewLimiter(timeframe, 2, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	if !limiter.Allow() || !limiter.Allow() {
+		t.Fatal("first two events within the timeframe should be allowed")
+	}
+
+	if limiter.Allow() {
+		t.Error("event over the limit should not be allowed")
+	}
+
+	now = now.Add(30 * time.Minute)
+	if limiter.Allow() {
+		t.Error("event over the limit should not be allowed later in the same window")
+	}
+
+	now = time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC)
+	if !limiter.Allow() {
+		t.Error("event should be allowed once the next window starts")
+	}
+
+	now = time.Date(2020, 6, 8, 18, 0, 0, 0, time.UTC)
+	if limiter.Allow() {
+		t.Error("event outside of the timeframe should not be allowed")
+	}
+}
+
+func TestLimiterWindowBoundary(t *testing.T) {
+	timeframe, err := New("* * * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limiter, err := NewLimiter(timeframe, 3, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2020, 6, 8, 9, 59, 59, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	// A burst at the end of one window and another at the start of the next are each capped at
+	// the limit.
+	for _, current := range []time.Time{now, time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC)} {
+		now = current
+
+		allowed := 0
+		for i := 0; i < 5; i++ {
+			if limiter.Allow() {
+				allowed++
+			}
+		}
+		if allowed != 3 {
+			t.Errorf("want %d events allowed in the window starting %s, got %d", 3, now.Truncate(time.Hour), allowed)
+		}
+	}
+
+	now = time.Date(2020, 6, 8, 10, 59, 59, 0, time.UTC)
+	if limiter.Allow() {
+		t.Error("event over the limit should not be allowed before the window ends")
+	}
+}
+
+func TestLimiterWaitCancelled(t *testing.T) {
+	timeframe, err := New("* * * * * 1970")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limiter, err := NewLimiter(timeframe, 1, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("want %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestLimiterWait(t *testing.T) {
+	timeframe, err := New("* * * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limiter, err := NewLimiter(timeframe, 1, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLimiterWaitGap(t *testing.T) {
+	shortenWaitRecheck(t, time.Millisecond)
+
+	var mu sync.Mutex
+	now := time.Date(2020, 6, 5, 17, 0, 0, 0, time.UTC)
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+
+	timeframe, err := New("* 9-16 * * 1-5 *", WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limiter, err := NewLimiter(timeframe, 1, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// From Friday evening the Timeframe is not able again until Monday morning.
+	want := 64 * time.Hour
+	if got := limiter.reserve(clock()); got != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- limiter.Wait(context.Background()) }()
+
+	select {
+	case err := <-done:
+		t.Fatalf("want Wait to block until Monday, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	mu.Lock()
+	now = time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC)
+	mu.Unlock()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("want Wait to return once the clock reaches Monday, got none")
+	}
+}
+
+func TestNewLimiterInvalid(t *testing.T) {
+	timeframe, err := New("* * * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewLimiter(timeframe, 0, time.Hour); err == nil {
+		t.Error("limit of 0 should not be accepted")
+	}
+
+	if _, err := NewLimiter(timeframe, 1, 0); err == nil {
+		t.Error("period of 0 should not be accepted")
+	}
+}
@@ -0,0 +1,166 @@
+package avail
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Lint reports terms of the expression which are valid but likely not what was intended, as
+// warnings to be shown to its author. Ex. in a CI pipeline checking a repository of schedules.
+// It reports:
+//
+//    terms that match every value but are not written as "*" (ex. "0-59")
+//    lists which repeat a value (ex. "1,15,1")
+//    day and weekday terms which are both restricted, so a date must match both, unless
+//    WithDomDowOr was given
+//    years which are in the past, as given by the clock set with WithClock
+//    steps which do not divide their range evenly, giving uneven gaps (ex. "*/7" minutes)
+//
+// An expression which cannot be parsed returns a single SeverityError finding holding the
+// error New returns. An expression without findings returns nil. Lint does not evaluate the
+// calendar; SelfCheck does.
+func Lint(expression string, opts ...Option) []Finding {
+	timeframe, err := New(expression, opts...)
+	if err != nil {
+		return []Finding{{SeverityError, err.Error()}}
+	}
+
+	findings := timeframe.lint()
+	if len(findings) == 0 {
+		return nil
+	}
+	return findings
+}
+
+// lint returns the findings for the Timeframe's terms, prefixing those of alternatives with
+// the alternative they belong to.
+func (a *Timeframe) lint() []Finding {
+	if a.alternatives != nil {
+		findings := []Finding{}
+		for i := range a.alternatives {
+			for _, finding := range a.alternatives[i].lint() {
+				finding.Message = fmt.Sprintf("alternative %d: %s", i+1, finding.Message)
+				findings = append(findings, finding)
+			}
+		}
+		return findings
+	}
+
+	if a.Interval != 0 {
+		return nil
+	}
+
+	parsed := a.ParsedExpression
+	fields := []Field{parsed.Minutes, parsed.Hours, parsed.Days, parsed.Months, parsed.Weekdays, parsed.Years}
+	if a.options.seconds {
+		fields = append([]Field{parsed.Seconds}, fields...)
+	}
+
+	findings := []Finding{}
+	for i := range fields {
+		findings = append(findings, fields[i].lint()...)
+	}
+
+	if !parsed.Days.isWildcard() && !parsed.Weekdays.isWildcard() && !a.options.domDowOr {
+		findings = append(findings, Finding{SeverityWarning, fmt.Sprintf(
+			"day term %s and weekday term %s are both restricted, so only dates matching both will match",
+			parsed.Days.Term, parsed.Weekdays.Term)})
+	}
+
+	if !parsed.Years.isWildcard() {
+		current := a.Now().Year()
+		past := map[int]struct{}{}
+		for year := range parsed.Years.Values {
+			if year < current {
+				past[year] = struct{}{}
+			}
+		}
+		if len(past) > 0 {
+			runs := []string{}
+			for _, run := range valueRuns(past, 0) {
+				runs = append(runs, formatRun(run))
+			}
+			findings = append(findings, Finding{SeverityWarning,
+				fmt.Sprintf("year term %s includes years in the past: %s", parsed.Years.Term, strings.Join(runs, ","))})
+		}
+	}
+
+	return findings
+}
+
+// lint returns the findings for the field's term on its own.
+func (f *Field) lint() []Finding {
+	findings := []Finding{}
+
+	if f.isWildcard() && f.Term != "*" && f.Term != "?" {
+		findings = append(findings, Finding{SeverityWarning,
+			fmt.Sprintf("%s term %s matches every value and can be written as *", f.Kind, f.Term)})
+	}
+
+	if items := strings.Split(f.Term, ","); len(items) > 1 {
+		seen := map[string]struct{}{}
+		singles := true
+		for _, item := range items {
+			seen[strings.ToUpper(item)] = struct{}{}
+			if strings.ContainsAny(item, "-/*#") {
+				singles = false
+			}
+		}
+		// Lists of single values repeat one when they resolve to fewer values than they list,
+		// which also catches the same value given by number and by name.
+		if len(seen) < len(items) || (singles && len(f.relative) == 0 && len(f.Values) < len(items)) {
+			findings = append(findings, Finding{SeverityWarning,
+				fmt.Sprintf("%s term %s lists the same value more than once", f.Kind, f.Term)})
+		}
+	}
+
+	if f.Kind != year {
+		for _, item := range strings.Split(f.Term, ",") {
+			if span, step, ok := f.stepSpan(item); ok && span%step != 0 {
+				findings = append(findings, Finding{SeverityWarning, fmt.Sprintf(
+					"%s term %s steps by %d, which does not divide its range evenly", f.Kind, f.Term, step)})
+				break
+			}
+		}
+	}
+
+	return findings
+}
+
+// stepSpan returns the span the stepped term must divide evenly and its step: the number of
+// values in the field for terms which repeat every cycle of it, or the distance from the start
+// to the end of a range. It returns false if the term is not stepped or is not numeric.
+func (f *Field) stepSpan(term string) (int, int, bool) {
+	parts := strings.Split(term, "/")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+
+	step, err := strconv.Atoi(parts[1])
+	if err != nil || step <= 0 {
+		return 0, 0, false
+	}
+
+	base := parts[0]
+	if strings.HasPrefix(strings.ToUpper(base), "H(") && strings.HasSuffix(base, ")") {
+		base = base[2 : len(base)-1]
+	}
+
+	bounds := strings.Split(base, "-")
+	if len(bounds) != 2 {
+		if _, err := strconv.Atoi(base); err != nil && base != "*" && !strings.EqualFold(base, "H") {
+			return 0, 0, false
+		}
+		// Terms like "*/15" and "5/15" continue to the end of the field and repeat from its
+		// start, so their gaps are even when the step divides the number of values.
+		return f.Max - f.Min + 1, step, true
+	}
+
+	start, startErr := strconv.Atoi(bounds[0])
+	end, endErr := strconv.Atoi(bounds[1])
+	if startErr != nil || endErr != nil {
+		return 0, 0, false
+	}
+	return end - start, step, true
+}
@@ -0,0 +1,64 @@
+package avail
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLint(t *testing.T) {
+	clock := WithClock(func() time.Time { return time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC) })
+
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+		want       []Finding
+	}{
+		"clean":    {"0 9 * * 1-5 *", nil, nil},
+		"interval": {"@every 1h", nil, nil},
+		"full span": {"0-59 9 * * * *", nil, []Finding{
+			{SeverityWarning, "minute term 0-59 matches every value and can be written as *"},
+		}},
+		"every weekday by name": {"0 9 * * SUN-SAT *", nil, []Finding{
+			{SeverityWarning, "weekday term SUN-SAT matches every value and can be written as *"},
+		}},
+		"duplicate values": {"0 9 1,15,1 * * *", nil, []Finding{
+			{SeverityWarning, "day term 1,15,1 lists the same value more than once"},
+		}},
+		"duplicate by name": {"0 9 * * 1,MON *", nil, []Finding{
+			{SeverityWarning, "weekday term 1,MON lists the same value more than once"},
+		}},
+		"day and weekday": {"0 9 13 * FRI *", nil, []Finding{
+			{SeverityWarning, "day term 13 and weekday term FRI are both restricted, so only dates matching both will match"},
+		}},
+		"day or weekday": {"0 9 13 * FRI *", []Option{WithDomDowOr()}, nil},
+		"past years": {"0 9 * * * 2019-2022", []Option{clock}, []Finding{
+			{SeverityWarning, "year term 2019-2022 includes years in the past: 2019-2020"},
+		}},
+		"current year": {"0 9 * * * 2021", []Option{clock}, nil},
+		"uneven step": {"0 0 */7 * * ?", []Option{WithDialect(Quartz)}, []Finding{
+			{SeverityWarning, "hour term */7 steps by 7, which does not divide its range evenly"},
+		}},
+		"even step":       {"0 */15 */6 * * ?", []Option{WithDialect(Quartz)}, nil},
+		"offset step":     {"0 5/15 * * * ?", []Option{WithDialect(Quartz)}, nil},
+		"uneven range":    {"0 0-30/20 * * * ?", []Option{WithDialect(Quartz)}, []Finding{{SeverityWarning, "minute term 0-30/20 steps by 20, which does not divide its range evenly"}}},
+		"even range":      {"0 0-30/10 * * * ?", []Option{WithDialect(Quartz)}, nil},
+		"uneven day step": {"0 0 9 */2 * ?", []Option{WithDialect(Quartz)}, []Finding{{SeverityWarning, "day term */2 steps by 2, which does not divide its range evenly"}}},
+		"hashed step":     {"H H/5 * * * *", []Option{WithHashKey("backup")}, []Finding{{SeverityWarning, "hour term H/5 steps by 5, which does not divide its range evenly"}}},
+		"question mark":   {"0 0 9 ? * MON-FRI", []Option{WithDialect(Quartz)}, nil},
+		"invalid":         {"0 25 * * * *", nil, []Finding{{SeverityError, "could not parse hour: value(25) cannot be more than max(23)"}}},
+		"alternatives": {"0 9 * * * * || 0-59 10 * * * *", nil, []Finding{
+			{SeverityWarning, "alternative 2: minute term 0-59 matches every value and can be written as *"},
+		}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			diff := cmp.Diff(tc.want, Lint(tc.expression, tc.opts...))
+			if diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package avail
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// locationPrefixes are the prefixes that may be used to attach a timezone to an expression.
+// Ex. "CRON_TZ=America/New_York 0 9 * * * *".
+var locationPrefixes = []string{"CRON_TZ=", "TZ="}
+
+// ZoneError is returned by New when the zone named by a timezone prefix cannot be loaded. This
+// is commonly caused by the zone database being absent, as in minimal container images; in
+// that case importing time/tzdata or passing WithZoneFallback avoids the error.
+type ZoneError struct {
+	Zone string
+	Err  error
+}
+
+func (e *ZoneError) Error() string {
+	return fmt.Sprintf("could not load timezone %s: %v", e.Zone, e.Err)
+}
+
+func (e *ZoneError) Unwrap() error {
+	return e.Err
+}
+
+// In returns a copy of the Timeframe evaluated in the location given in place of its own, as
+// if its expression had been prefixed with it. Ex. "* 9-16 * * 1-5 *" in Asia/Singapore is
+// "CRON_TZ=Asia/Singapore * 9-16 * * 1-5 *". Exclusions and alternatives which do not have a
+// location of their own are evaluated in it too.
+func (a Timeframe) In(location *time.Location) Timeframe {
+	// The expression has already been parsed, so its prefix is known to be valid.
+	_, schedule, _ := parseLocationPrefix(strings.TrimSpace(a.Expression), time.UTC)
+
+	a.Location = location
+	a.Expression = locationPrefixes[0] + location.String() + " " + schedule
+
+	exclusions := make([]Timeframe, 0, len(a.exclusions))
+	for _, exclusion := range a.exclusions {
+		if exclusion.Location == nil {
+			exclusion = exclusion.In(location)
+		}
+		exclusions = append(exclusions, exclusion)
+	}
+	a.exclusions = exclusions
+
+	return a
+}
+
+// InAny returns a combined Timeframe which is able whenever the Timeframe is able in any of the
+// locations, such as a follow-the-sun support rota covering the business hours of several
+// offices. Ex. "* 9-16 * * 1-5 *" in Europe/London, America/New_York and Asia/Singapore. It is
+// the Union of the Timeframe in each location. If no locations are given the Timeframe is
+// returned unchanged.
+func (a Timeframe) InAny(locations ...*time.Location) Timeframe {
+	if len(locations) == 0 {
+		return a
+	}
+
+	parts := []Timeframe{}
+	for _, location := range locations {
+		parts = append(parts, a.In(location))
+	}
+	return parts[0].Union(parts[1:]...)
+}
+
+// AbleInAny reports whether the Timeframe is able at the time given when evaluated in any of
+// the locations, as with InAny. If no locations are given it is evaluated as Able is.
+func (a *Timeframe) AbleInAny(t time.Time, locations ...*time.Location) bool {
+	if len(locations) == 0 {
+		return a.Able(t)
+	}
+
+	for _, location := range locations {
+		inLocation := a.In(location)
+		if inLocation.Able(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasLocationPrefix reports whether the expression begins with a timezone prefix.
+func hasLocationPrefix(expression string) bool {
+	expression = strings.TrimSpace(expression)
+	for _, prefix := range locationPrefixes {
+		if len(expression) >= len(prefix) && strings.EqualFold(expression[:len(prefix)], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLocationName returns an error if the location cannot be loaded again by its name, as
+// decoding a Timeframe evaluated in it requires.
+func checkLocationName(location *time.Location) error {
+	if location == nil {
+		return nil
+	}
+	if _, err := time.LoadLocation(location.String()); err != nil {
+		return fmt.Errorf("location %s cannot be loaded by name", location)
+	}
+	return nil
+}
+
+// parseLocationPrefix splits a leading timezone prefix from the expression and loads its
+// location. The prefix itself is case-insensitive, the zone name is not. If the expression
+// has no prefix the returned location is nil and the expression is returned unchanged. If
+// the zone cannot be loaded the fallback is returned in its place, unless it is nil.
+func parseLocationPrefix(expression string, fallback *time.Location) (*time.Location, string, error) {
+	for _, prefix := range locationPrefixes {
+		if len(expression) < len(prefix) || !strings.EqualFold(expression[:len(prefix)], prefix) {
+			continue
+		}
+
+		remainder := expression[len(prefix):]
+
+		separator := strings.IndexFunc(remainder, unicode.IsSpace)
+		if separator == -1 {
+			return nil, "", fmt.Errorf("timezone prefix must be followed by a schedule")
+		}
+
+		zone := remainder[:separator]
+		if zone == "" {
+			return nil, "", fmt.Errorf("timezone prefix must name a timezone")
+		}
+
+		location, err := time.LoadLocation(zone)
+		if err != nil {
+			if fallback == nil {
+				return nil, "", &ZoneError{Zone: zone, Err: err}
+			}
+			location = fallback
+		}
+
+		return location, strings.TrimSpace(remainder[separator:]), nil
+	}
+
+	return nil, expression, nil
+}
@@ -0,0 +1,311 @@
+package avail
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLocationPrefix(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		time       time.Time
+		want       bool
+	}{
+		"cron_tz prefix": {
+			"CRON_TZ=America/New_York 0 9 * * * *",
+			time.Date(2020, 6, 8, 13, 0, 0, 0, time.UTC), true,
+		},
+		"cron_tz prefix miss": {
+			"CRON_TZ=America/New_York 0 9 * * * *",
+			time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), false,
+		},
+		"tz prefix": {
+			"TZ=Asia/Tokyo 0 9 * * * *",
+			time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC), true,
+		},
+		"prefixed macro": {
+			"TZ=Asia/Tokyo @daily",
+			time.Date(2020, 6, 7, 15, 0, 0, 0, time.UTC), true,
+		},
+		"prefixed weekday crosses date line": {
+			"TZ=Asia/Tokyo * * * * 1 *",
+			time.Date(2020, 6, 7, 23, 0, 0, 0, time.UTC), true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if avail.Expression != tc.expression {
+				t.Errorf("expression should be retained as %s; got %s", tc.expression, avail.Expression)
+			}
+
+			if avail.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+}
+
+func TestLocationPrefixUnparseable(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+	}{
+		"unknown zone":     {"CRON_TZ=Mars/Olympus_Mons 0 9 * * * *"},
+		"missing zone":     {"TZ= 0 9 * * * *"},
+		"missing schedule": {"CRON_TZ=America/New_York"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(tc.expression)
+			if err == nil {
+				t.Errorf("expression %s should not be parsed successfully", tc.expression)
+			}
+		})
+	}
+}
+
+func TestLocationPrefixZoneError(t *testing.T) {
+	_, err := New("CRON_TZ=Mars/Olympus_Mons 0 9 * * * *")
+
+	var zoneErr *ZoneError
+	if !errors.As(err, &zoneErr) {
+		t.Fatalf("want ZoneError, got %v", err)
+	}
+
+	if zoneErr.Zone != "Mars/Olympus_Mons" {
+		t.Errorf("want zone Mars/Olympus_Mons, got %s", zoneErr.Zone)
+	}
+}
+
+func TestLocationPrefixZoneFallback(t *testing.T) {
+	fallback := time.FixedZone("MARS", 2*60*60)
+
+	avail, err := New("CRON_TZ=Mars/Olympus_Mons 0 9 * * * *", WithZoneFallback(fallback))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if avail.Location != fallback {
+		t.Errorf("want fallback location, got %s", avail.Location)
+	}
+
+	if !avail.Able(time.Date(2020, 6, 8, 7, 0, 0, 0, time.UTC)) {
+		t.Errorf("want %t, got %t", true, false)
+	}
+
+	known, err := New("CRON_TZ=America/New_York 0 9 * * * *", WithZoneFallback(fallback))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if known.Location.String() != "America/New_York" {
+		t.Errorf("fallback should only be used for zones which cannot be loaded; got %s", known.Location)
+	}
+}
+
+func TestWithLocation(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		expression string
+		time       time.Time
+		want       bool
+	}{
+		"utc time":          {"0 9 * * * *", time.Date(2020, 6, 8, 13, 0, 0, 0, time.UTC), true},
+		"utc time miss":     {"0 9 * * * *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), false},
+		"other location":    {"0 9 * * * *", time.Date(2020, 6, 8, 22, 0, 0, 0, tokyo), true},
+		"weekday":           {"* * * * 1 *", time.Date(2020, 6, 9, 2, 0, 0, 0, time.UTC), true},
+		"prefix precedence": {"TZ=Asia/Tokyo 0 9 * * * *", time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC), true},
+		"alternatives":      {"0 8 * * * * || 0 9 * * * *", time.Date(2020, 6, 8, 13, 0, 0, 0, time.UTC), true},
+		"prefixed alternatives": {"TZ=Asia/Tokyo 0 8 * * * * || 0 9 * * * *",
+			time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC), true},
+		"interval": {"@every 1h", time.Date(2020, 6, 8, 13, 0, 0, 0, time.UTC), true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression, WithLocation(newYork))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if avail.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+
+	avail, err := New("0 9 * * * *", WithLocation(newYork))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next, err := avail.Next(time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2020, 6, 8, 9, 0, 0, 0, newYork); !next.Equal(want) || next.Location() != newYork {
+		t.Errorf("want %s, got %s", want, next)
+	}
+
+	windows := avail.Windows(time.Date(2020, 6, 8, 0, 0, 0, 0, tokyo), time.Date(2020, 6, 9, 0, 0, 0, 0, tokyo))
+	if len(windows) != 1 || !windows[0].Start.Equal(time.Date(2020, 6, 8, 9, 0, 0, 0, newYork)) {
+		t.Errorf("want a single window at 9am in New York, got %v", windows)
+	}
+}
+
+func TestWithLocationEncoding(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	avail, err := New("0 9 * * * *", WithLocation(newYork), WithExpandedJSON())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := avail.MarshalText(); err == nil {
+		t.Errorf("locations given as an option should not be marshalled as text")
+	}
+
+	at := time.Date(2020, 6, 8, 13, 0, 0, 0, time.UTC)
+
+	data, err := avail.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := Timeframe{}
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Able(at) {
+		t.Errorf("location should be restored from JSON")
+	}
+
+	data, err = avail.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded = Timeframe{}
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Able(at) {
+		t.Errorf("location should be restored from binary")
+	}
+	if _, err := decoded.MarshalText(); err == nil {
+		t.Errorf("decoded locations given as an option should not be marshalled as text")
+	}
+
+	fixed, err := New("0 9 * * * *", WithLocation(time.FixedZone("Office", -5*60*60)), WithExpandedJSON())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fixed.MarshalJSON(); err == nil {
+		t.Errorf("locations which cannot be loaded by name should not be marshalled as JSON")
+	}
+	if _, err := fixed.MarshalBinary(); err == nil {
+		t.Errorf("locations which cannot be loaded by name should not be marshalled as binary")
+	}
+}
+
+func TestAbleInAny(t *testing.T) {
+	locations := []*time.Location{}
+	for _, name := range []string{"Europe/London", "America/New_York", "Asia/Singapore"} {
+		location, err := time.LoadLocation(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		locations = append(locations, location)
+	}
+
+	avail, err := New("* 9-16 * * 1-5 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rota := avail.InAny(locations...)
+
+	tests := map[string]struct {
+		time time.Time
+		want bool
+	}{
+		"london":           {time.Date(2021, 6, 7, 8, 30, 0, 0, time.UTC), true},
+		"new york":         {time.Date(2021, 6, 7, 19, 30, 0, 0, time.UTC), true},
+		"singapore":        {time.Date(2021, 6, 8, 3, 0, 0, 0, time.UTC), true},
+		"between offices":  {time.Date(2021, 6, 7, 21, 30, 0, 0, time.UTC), false},
+		"before singapore": {time.Date(2021, 6, 6, 23, 0, 0, 0, time.UTC), false},
+		"new york friday":  {time.Date(2021, 6, 11, 20, 0, 0, 0, time.UTC), true},
+		"weekend":          {time.Date(2021, 6, 12, 12, 0, 0, 0, time.UTC), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := avail.AbleInAny(tc.time, locations...); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+			if got := rota.Able(tc.time); got != tc.want {
+				t.Errorf("combined timeframe; want %t, got %t", tc.want, got)
+			}
+		})
+	}
+
+	if !avail.AbleInAny(time.Date(2021, 6, 7, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("without locations the time should be evaluated as given")
+	}
+}
+
+func TestIn(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	christmas, err := New("* * 25 12 * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	avail, err := New("CRON_TZ=America/New_York 0 9 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	avail = avail.Except(christmas)
+
+	inTokyo := avail.In(tokyo)
+	if want := "CRON_TZ=Asia/Tokyo 0 9 * * * *"; inTokyo.Expression != want {
+		t.Errorf("want %q, got %q", want, inTokyo.Expression)
+	}
+
+	if !inTokyo.Able(time.Date(2021, 6, 7, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("should be able at 9:00 in Tokyo")
+	}
+	// 9:00 on December 25th in Tokyo is still December 24th in UTC.
+	if inTokyo.Able(time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("exclusions should be evaluated in the location")
+	}
+	if !avail.Able(time.Date(2020, 12, 24, 14, 0, 0, 0, time.UTC)) {
+		t.Errorf("original timeframe should be unchanged")
+	}
+
+	reparsed, err := New(inTokyo.Expression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reparsed.Contains(inTokyo) {
+		t.Errorf("expression should match the timeframe in the location")
+	}
+}
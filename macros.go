@@ -0,0 +1,103 @@
+package avail
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// macros maps the predefined non-standard cron shortcuts to the six term expression they
+// stand for. Macros are expanded before an expression is parsed.
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 * *",
+	"@annually": "0 0 1 1 * *",
+	"@monthly":  "0 0 1 * * *",
+	"@weekly":   "0 0 * * 0 *",
+	"@daily":    "0 0 * * * *",
+	"@midnight": "0 0 * * * *",
+	"@hourly":   "0 * * * * *",
+}
+
+// registeredMacros holds the macros added with RegisterMacro. They are kept apart from the
+// predefined macros so collisions can be detected.
+var (
+	registeredMacrosMu sync.RWMutex
+	registeredMacros   = map[string]string{}
+)
+
+// RegisterMacro adds a macro which New will expand for all expressions. Ex.
+// RegisterMacro("@payday", "0 9 L * * *") allows New("@payday"). Names must begin with "@",
+// are case-insensitive and cannot collide with a predefined or previously registered macro.
+//
+// The expression must be a valid six term expression. Like the predefined macros, it is
+// prefixed with a seconds term of 0 when parsed using WithSeconds.
+func RegisterMacro(name, expression string) error {
+	name, err := validateMacro(name, expression)
+	if err != nil {
+		return err
+	}
+
+	registeredMacrosMu.Lock()
+	defer registeredMacrosMu.Unlock()
+
+	if _, ok := registeredMacros[name]; ok {
+		return fmt.Errorf("could not register macro %s: already registered", name)
+	}
+
+	registeredMacros[name] = expression
+	return nil
+}
+
+// validateMacro checks that the macro's name and expression are valid and that it does not
+// collide with a predefined macro. It returns the normalized name.
+func validateMacro(name, expression string) (string, error) {
+	if !strings.HasPrefix(name, "@") || len(name) == 1 || strings.IndexFunc(name, unicode.IsSpace) != -1 {
+		return "", fmt.Errorf("could not register macro %s: name must begin with @ and contain no whitespace", name)
+	}
+
+	name = strings.ToLower(name)
+
+	if _, ok := macros[name]; ok || strings.EqualFold(name, everyPrefix) {
+		return "", fmt.Errorf("could not register macro %s: collides with a predefined macro", name)
+	}
+
+	// Macros are expanded after prefixes and other macros have been handled, so they may
+	// only stand for a plain six term expression.
+	if len(strings.Fields(expression)) != 6 {
+		return "", fmt.Errorf("could not register macro %s: must stand for a six term expression", name)
+	}
+
+	// A hash key is given so that macros made up of "H" terms can be validated.
+	if _, err := New(expression, WithHashKey(name)); err != nil {
+		return "", fmt.Errorf("could not register macro %s: %w", name, err)
+	}
+
+	return name, nil
+}
+
+// expandMacro returns the expression a macro stands for. Expressions which are not macros
+// are returned unchanged. Macros are case-insensitive and are looked up in the macros given
+// with WithMacros, then those registered with RegisterMacro and finally the predefined
+// macros. When seconds are enabled the expansion is prefixed with a seconds term of 0.
+func expandMacro(expression string, options options) string {
+	name := strings.ToLower(expression)
+
+	expanded, ok := options.macros[name]
+	if !ok {
+		registeredMacrosMu.RLock()
+		expanded, ok = registeredMacros[name]
+		registeredMacrosMu.RUnlock()
+	}
+	if !ok {
+		expanded, ok = macros[name]
+	}
+	if !ok {
+		return expression
+	}
+
+	if options.seconds {
+		return "0 " + expanded
+	}
+	return expanded
+}
@@ -0,0 +1,83 @@
+package avail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRegisterMacro(t *testing.T) {
+	if err := RegisterMacro("@Payday", "0 9 L * * *"); err != nil {
+		t.Fatal(err)
+	}
+
+	avail, err := New("@payday")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if avail.Expression != "@payday" {
+		t.Errorf("expression should be retained as @payday; got %s", avail.Expression)
+	}
+
+	if !avail.Able(time.Date(2020, 6, 30, 9, 0, 0, 0, time.UTC)) {
+		t.Error("registered macro should be expanded")
+	}
+
+	seconds, err := New("@PAYDAY", WithSeconds())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if seconds.Able(time.Date(2020, 6, 30, 9, 0, 1, 0, time.UTC)) {
+		t.Error("registered macro should be prefixed with a seconds term of 0")
+	}
+
+	if err := RegisterMacro("@payday", "0 10 L * * *"); err == nil {
+		t.Error("registering a macro twice should not be accepted")
+	}
+}
+
+func TestRegisterMacroInvalid(t *testing.T) {
+	tests := map[string]struct {
+		name       string
+		expression string
+	}{
+		"collides with predefined": {"@Daily", "0 9 * * * *"},
+		"collides with interval":   {"@every", "0 9 * * * *"},
+		"missing @":                {"payday", "0 9 L * * *"},
+		"only @":                   {"@", "0 9 L * * *"},
+		"contains whitespace":      {"@pay day", "0 9 L * * *"},
+		"invalid expression":       {"@broken", "0 25 * * * *"},
+		"refers to a macro":        {"@nested", "@daily"},
+		"contains a prefix":        {"@zoned", "TZ=UTC 0 9 * * * *"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := RegisterMacro(tc.name, tc.expression); err == nil {
+				t.Errorf("macro %s should not be registered", tc.name)
+			}
+		})
+	}
+}
+
+func TestWithMacros(t *testing.T) {
+	macros := map[string]string{"@lunch": "0 12 * * 1-5 *"}
+
+	avail, err := New("@lunch", WithMacros(macros))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !avail.Able(time.Date(2020, 6, 8, 12, 0, 0, 0, time.UTC)) {
+		t.Error("macro given as an option should be expanded")
+	}
+
+	if _, err := New("@lunch"); err == nil {
+		t.Error("macro given as an option should not be available to other expressions")
+	}
+
+	if _, err := New("@hourly", WithMacros(map[string]string{"@hourly": "30 * * * * *"})); err == nil {
+		t.Error("macro given as an option should not override a predefined macro")
+	}
+}
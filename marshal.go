@@ -0,0 +1,286 @@
+package avail
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// MarshalText implements encoding.TextMarshaler, so that a Timeframe is encoded as its
+// expression by packages such as encoding/json. Ex. a Timeframe field in a config struct is
+// written as "0 9 * * 1-5 *".
+//
+// Only Timeframes which can be recreated from their expression alone can be marshalled. Those
+// with exclusions, or which were parsed with options that change the grammar, such as
+// WithSeconds or WithDialect, return an error.
+func (a Timeframe) MarshalText() ([]byte, error) {
+	if err := a.checkRoundTrip(); err != nil {
+		return nil, fmt.Errorf("could not marshal cron expression: %s; %w", a.Expression, err)
+	}
+
+	return []byte(a.Expression), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by parsing the text as an expression with
+// New, so that invalid expressions are rejected when they are decoded.
+func (a *Timeframe) UnmarshalText(text []byte) error {
+	timeframe, err := New(string(text))
+	if err != nil {
+		return err
+	}
+
+	*a = timeframe
+	return nil
+}
+
+// checkRoundTrip returns an error if parsing the Timeframe's expression with no options would
+// not recreate it.
+func (a *Timeframe) checkRoundTrip() error {
+	if len(a.exclusions) > 0 {
+		return fmt.Errorf("exclusions cannot be represented in an expression")
+	}
+	if a.Location != nil && !hasLocationPrefix(a.Expression) {
+		return fmt.Errorf("locations given with WithLocation cannot be represented in an expression")
+	}
+	if err := checkLocationName(a.Location); err != nil {
+		return err
+	}
+
+	o := a.options
+	if o.seconds || o.optionalYear || o.hashKey != "" || len(o.macros) > 0 || (o.dialect != "" && o.dialect != Native) ||
+		o.maxYear != 0 || o.domDowOr || o.dstPolicy != DSTWallClock ||
+		o.granularity != 0 || o.bounded() || o.holidays != nil {
+		return fmt.Errorf("expressions parsed with grammar options cannot be recreated without them")
+	}
+
+	for i := range a.alternatives {
+		if err := a.alternatives[i].checkRoundTrip(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expandedTimeframe is the JSON form of a Timeframe marshalled with WithExpandedJSON.
+type expandedTimeframe struct {
+	Expression   string              `json:"expression"`
+	Dialect      Dialect             `json:"dialect,omitempty"`
+	Seconds      bool                `json:"seconds,omitempty"`
+	OptionalYear bool                `json:"optionalYear,omitempty"`
+	HashKey      string              `json:"hashKey,omitempty"`
+	MinYear      int                 `json:"minYear,omitempty"`
+	MaxYear      int                 `json:"maxYear,omitempty"`
+	DomDowOr     bool                `json:"domDowOr,omitempty"`
+	DSTPolicy    DSTPolicy           `json:"dstPolicy,omitempty"`
+	Granularity  string              `json:"granularity,omitempty"`
+	ActiveStart  *time.Time          `json:"activeStart,omitempty"`
+	ActiveEnd    *time.Time          `json:"activeEnd,omitempty"`
+	Location     string              `json:"location,omitempty"`
+	Interval     string              `json:"interval,omitempty"`
+	Fields       *expandedFields     `json:"fields,omitempty"`
+	Alternatives []expandedTimeframe `json:"alternatives,omitempty"`
+}
+
+// expandedFields holds the terms of an expression alongside the values they resolved to.
+type expandedFields struct {
+	Seconds  *expandedField `json:"seconds,omitempty"`
+	Minutes  expandedField  `json:"minutes"`
+	Hours    expandedField  `json:"hours"`
+	Days     expandedField  `json:"days"`
+	Months   expandedField  `json:"months"`
+	Weekdays expandedField  `json:"weekdays"`
+	Years    expandedField  `json:"years"`
+}
+
+type expandedField struct {
+	Term   string `json:"term"`
+	Values []int  `json:"values"`
+}
+
+// MarshalJSON implements json.Marshaler. Timeframes are encoded as their expression, like
+// MarshalText, unless they were parsed with WithExpandedJSON, in which case they are encoded
+// as an object holding the expression, the options needed to parse it again and the values
+// each of its terms resolved to:
+//
+//    {"expression": "0 9 * * 1-5 *", "fields": {"minutes": {"term": "0", "values": [0]}, ...}}
+//
+// In the expanded form the seconds, optional year, hash key, year range, day or weekday,
+// daylight saving time, granularity, active period and dialect options are recorded, so only
+// Timeframes with exclusions, custom macros or holidays cannot be marshalled.
+func (a Timeframe) MarshalJSON() ([]byte, error) {
+	if !a.options.expandedJSON {
+		text, err := a.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(string(text))
+	}
+
+	if len(a.exclusions) > 0 || len(a.options.macros) > 0 || a.options.holidays != nil {
+		return nil, fmt.Errorf("could not marshal cron expression: %s; exclusions, macros and holidays cannot be represented in JSON",
+			a.Expression)
+	}
+	if err := checkLocationName(a.Location); err != nil {
+		return nil, fmt.Errorf("could not marshal cron expression: %s; %w", a.Expression, err)
+	}
+
+	return json.Marshal(a.expand())
+}
+
+func (a *Timeframe) expand() expandedTimeframe {
+	expanded := expandedTimeframe{
+		Expression:   a.Expression,
+		Seconds:      a.options.seconds,
+		OptionalYear: a.options.optionalYear,
+		HashKey:      a.options.hashKey,
+		MinYear:      a.options.minYear,
+		MaxYear:      a.options.maxYear,
+		DomDowOr:     a.options.domDowOr,
+		DSTPolicy:    a.options.dstPolicy,
+	}
+	if a.options.granularity != 0 {
+		expanded.Granularity = a.options.granularity.String()
+	}
+	if !a.options.activeStart.IsZero() {
+		expanded.ActiveStart = &a.options.activeStart
+	}
+	if !a.options.activeEnd.IsZero() {
+		expanded.ActiveEnd = &a.options.activeEnd
+	}
+	if a.options.dialect != Native {
+		expanded.Dialect = a.options.dialect
+	}
+	if a.Location != nil {
+		expanded.Location = a.Location.String()
+	}
+
+	switch {
+	case a.alternatives != nil:
+		for i := range a.alternatives {
+			expanded.Alternatives = append(expanded.Alternatives, a.alternatives[i].expand())
+		}
+	case a.Interval != 0:
+		expanded.Interval = a.Interval.String()
+	default:
+		parsed := a.ParsedExpression
+		expanded.Fields = &expandedFields{
+			Minutes:  expandField(parsed.Minutes),
+			Hours:    expandField(parsed.Hours),
+			Days:     expandField(parsed.Days),
+			Months:   expandField(parsed.Months),
+			Weekdays: expandField(parsed.Weekdays),
+			Years:    expandField(parsed.Years),
+		}
+		if a.options.seconds {
+			seconds := expandField(parsed.Seconds)
+			expanded.Fields.Seconds = &seconds
+		}
+	}
+
+	return expanded
+}
+
+func expandField(field Field) expandedField {
+	return expandedField{Term: field.Term, Values: sortedValues(field.values())}
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting both the expression string and the
+// expanded object written by MarshalJSON. The expression is always parsed again; resolved
+// values in the expanded form are informational and are not read.
+func (a *Timeframe) UnmarshalJSON(data []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		var expression string
+		if err := json.Unmarshal(data, &expression); err != nil {
+			return err
+		}
+		return a.UnmarshalText([]byte(expression))
+	}
+
+	expanded := expandedTimeframe{}
+	if err := json.Unmarshal(data, &expanded); err != nil {
+		return err
+	}
+
+	opts := []Option{WithExpandedJSON()}
+	if expanded.Seconds {
+		opts = append(opts, WithSeconds())
+	}
+	if expanded.OptionalYear {
+		opts = append(opts, WithOptionalYear())
+	}
+	if expanded.HashKey != "" {
+		opts = append(opts, WithHashKey(expanded.HashKey))
+	}
+	if expanded.Dialect != "" {
+		opts = append(opts, WithDialect(expanded.Dialect))
+	}
+	if expanded.MinYear != 0 || expanded.MaxYear != 0 {
+		opts = append(opts, WithYearRange(expanded.MinYear, expanded.MaxYear))
+	}
+	if expanded.DomDowOr {
+		opts = append(opts, WithDomDowOr())
+	}
+	if expanded.DSTPolicy != DSTWallClock {
+		opts = append(opts, WithDSTPolicy(expanded.DSTPolicy))
+	}
+	if expanded.Granularity != "" {
+		granularity, err := time.ParseDuration(expanded.Granularity)
+		if err != nil {
+			return fmt.Errorf("could not parse granularity %s: %w", expanded.Granularity, err)
+		}
+		opts = append(opts, WithGranularity(granularity))
+	}
+	if expanded.ActiveStart != nil || expanded.ActiveEnd != nil {
+		var start, end time.Time
+		if expanded.ActiveStart != nil {
+			start = *expanded.ActiveStart
+		}
+		if expanded.ActiveEnd != nil {
+			end = *expanded.ActiveEnd
+		}
+		opts = append(opts, WithActiveBetween(start, end))
+	}
+	if expanded.Location != "" && !hasLocationPrefix(expanded.Expression) {
+		location, err := time.LoadLocation(expanded.Location)
+		if err != nil {
+			return &ZoneError{Zone: expanded.Location, Err: err}
+		}
+		opts = append(opts, WithLocation(location))
+	}
+
+	timeframe, err := New(expanded.Expression, opts...)
+	if err != nil {
+		return err
+	}
+
+	*a = timeframe
+	return nil
+}
+
+// Value implements driver.Valuer so that a Timeframe is stored as its expression, ex. in a
+// TEXT column. The same Timeframes MarshalText refuses cannot be stored.
+func (a Timeframe) Value() (driver.Value, error) {
+	text, err := a.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+// Scan implements sql.Scanner by parsing the column's text with New, so that invalid
+// expressions are reported when they are read. NULL columns cannot be scanned into a
+// Timeframe; scan them into a pointer to one instead.
+func (a *Timeframe) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		return a.UnmarshalText([]byte(src))
+	case []byte:
+		return a.UnmarshalText(src)
+	case nil:
+		return fmt.Errorf("could not scan NULL into Timeframe")
+	}
+	return fmt.Errorf("could not scan %T into Timeframe", src)
+}
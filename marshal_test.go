@@ -0,0 +1,198 @@
+package avail
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestMarshalText(t *testing.T) {
+	type config struct {
+		Schedule Timeframe `json:"schedule"`
+	}
+
+	schedule, err := New("CRON_TZ=America/New_York 0 9 * * 1-5 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := json.Marshal(config{Schedule: schedule})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"schedule":"CRON_TZ=America/New_York 0 9 * * 1-5 *"}`
+	if string(encoded) != want {
+		t.Errorf("want %s, got %s", want, encoded)
+	}
+
+	decoded := config{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	monday := time.Date(2020, 6, 8, 13, 0, 0, 0, time.UTC)
+	if !decoded.Schedule.Able(monday) {
+		t.Errorf("want %t, got %t", true, false)
+	}
+}
+
+func TestUnmarshalTextInvalid(t *testing.T) {
+	var schedule Timeframe
+	if err := json.Unmarshal([]byte(`"0 9 * *"`), &schedule); err == nil {
+		t.Errorf("invalid expression should not be unmarshalled")
+	}
+}
+
+func TestMarshalTextUnrepresentable(t *testing.T) {
+	seconds, err := New("0 0 9 * * * *", WithSeconds())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	quartz, err := New("0 0 9 ? * MON-FRI", WithDialect(Quartz))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base, err := New("0 9 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		timeframe Timeframe
+	}{
+		"seconds":    {seconds},
+		"dialect":    {quartz},
+		"exclusions": {base.Except(seconds)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := tc.timeframe.MarshalText(); err == nil {
+				t.Errorf("timeframe %s should not be marshalled", tc.timeframe.Expression)
+			}
+		})
+	}
+}
+
+func TestMarshalJSONExpanded(t *testing.T) {
+	schedule, err := New("CRON_TZ=UTC 0 9 L 1 1-5 2020", WithExpandedJSON())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := json.Marshal(schedule)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"expression":"CRON_TZ=UTC 0 9 L 1 1-5 2020","location":"UTC","fields":{` +
+		`"minutes":{"term":"0","values":[0]},"hours":{"term":"9","values":[9]},` +
+		`"days":{"term":"L","values":[]},"months":{"term":"1","values":[1]},` +
+		`"weekdays":{"term":"1-5","values":[1,2,3,4,5]},"years":{"term":"2020","values":[2020]}}}`
+	if string(encoded) != want {
+		t.Errorf("want %s, got %s", want, encoded)
+	}
+
+	decoded := Timeframe{}
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	reencoded, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(reencoded) != want {
+		t.Errorf("expanded form should round trip; want %s, got %s", want, reencoded)
+	}
+}
+
+func TestUnmarshalJSONExpandedOptions(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+		time       time.Time
+	}{
+		"seconds":  {"30 0 9 * * * *", []Option{WithSeconds()}, time.Date(2020, 6, 8, 9, 0, 30, 0, time.UTC)},
+		"dialect":  {"0 0 9 ? * MON-FRI", []Option{WithDialect(Quartz)}, time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC)},
+		"combined": {"0 9 * * 1-5 * || 0 10 * * 0,6 *", nil, time.Date(2020, 6, 6, 10, 0, 0, 0, time.UTC)},
+		"interval": {"@every 90m", nil, time.Date(1970, 1, 1, 1, 30, 0, 0, time.UTC)},
+		"years":    {"0 9 1 1 * 2150", []Option{WithYearRange(2100, 2200)}, time.Date(2150, 1, 1, 9, 0, 0, 0, time.UTC)},
+		"either":   {"0 9 1 * 1 *", []Option{WithDomDowOr()}, time.Date(2021, 6, 7, 9, 0, 0, 0, time.UTC)},
+		"dst": {"CRON_TZ=America/New_York 30 2 * * * *", []Option{WithDSTPolicy(DSTFirst)},
+			time.Date(2020, 3, 8, 7, 0, 0, 0, time.UTC)},
+		"granularity": {"@every 30s", []Option{WithGranularity(time.Second)}, time.Date(2020, 6, 8, 9, 0, 30, 0, time.UTC)},
+		"active": {"0 9 * * 5 *", []Option{WithActiveBetween(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Time{})},
+			time.Date(2025, 7, 4, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := New(tc.expression, append(tc.opts, WithExpandedJSON())...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			encoded, err := json.Marshal(schedule)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			decoded := Timeframe{}
+			if err := json.Unmarshal(encoded, &decoded); err != nil {
+				t.Fatal(err)
+			}
+
+			if !decoded.Able(tc.time) {
+				t.Errorf("want %t, got %t", true, false)
+			}
+		})
+	}
+}
+
+func TestValue(t *testing.T) {
+	schedule, err := New("0 9 * * 1-5 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := schedule.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value != "0 9 * * 1-5 *" {
+		t.Errorf("want %s, got %v", "0 9 * * 1-5 *", value)
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := map[string]struct {
+		src   interface{}
+		valid bool
+	}{
+		"string":  {"0 9 * * 1-5 *", true},
+		"bytes":   {[]byte("0 9 * * 1-5 *"), true},
+		"invalid": {"0 9 * *", false},
+		"null":    {nil, false},
+		"integer": {int64(9), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var schedule Timeframe
+			err := schedule.Scan(tc.src)
+			if (err == nil) != tc.valid {
+				t.Fatalf("want %t, got %t", tc.valid, !tc.valid)
+			}
+
+			if tc.valid && !schedule.Able(time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC)) {
+				t.Errorf("want %t, got %t", true, false)
+			}
+		})
+	}
+}
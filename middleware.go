@@ -0,0 +1,43 @@
+package avail
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// BlackoutMiddleware returns net/http middleware that responds with 503 Service Unavailable
+// whenever the current time falls within the blackout Timeframe, and passes requests through
+// to the wrapped handler otherwise. Ex. a blackout of "* 2-3 * * 0 *" rejects requests every
+// Sunday between 2am and 4am.
+//
+// Rejected responses carry a Retry-After header with the number of seconds until the
+// blackout ends. Blackouts which never end omit the header.
+func BlackoutMiddleware(blackout Timeframe) func(http.Handler) http.Handler {
+	return blackoutMiddleware(blackout, time.Now)
+}
+
+func blackoutMiddleware(blackout Timeframe, now func() time.Time) func(http.Handler) http.Handler {
+	// The blackout ends at the next time its inverse is able.
+	open := MustNew("* * * * * *", WithGranularity(blackout.options.precision())).Except(blackout)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			current := now()
+			if !blackout.Able(current) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if end, err := open.Next(current); err == nil {
+				seconds := int((end.Sub(current) + time.Second - 1) / time.Second)
+				if seconds < 1 {
+					seconds = 1
+				}
+				w.Header().Set("Retry-After", strconv.Itoa(seconds))
+			}
+
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		})
+	}
+}
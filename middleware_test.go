@@ -0,0 +1,71 @@
+package avail
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBlackoutMiddleware(t *testing.T) {
+	tests := map[string]struct {
+		blackout   string
+		now        time.Time
+		wantStatus int
+		wantRetry  string
+	}{
+		"outside blackout": {
+			"* 2-3 * * 0 *", time.Date(2020, 6, 7, 4, 0, 0, 0, time.UTC),
+			http.StatusOK, "",
+		},
+		"inside blackout": {
+			"* 2-3 * * 0 *", time.Date(2020, 6, 7, 3, 30, 0, 0, time.UTC),
+			http.StatusServiceUnavailable, "1800",
+		},
+		"inside blackout mid minute": {
+			"* 2-3 * * 0 *", time.Date(2020, 6, 7, 3, 59, 30, 0, time.UTC),
+			http.StatusServiceUnavailable, "30",
+		},
+		"blackout longer than a week": {
+			"* * * 6 * *", time.Date(2020, 6, 7, 3, 30, 0, 0, time.UTC),
+			http.StatusServiceUnavailable, "2061000",
+		},
+		"seconds blackout": {
+			"0-29 * * * * * *", time.Date(2020, 6, 7, 3, 30, 10, 0, time.UTC),
+			http.StatusServiceUnavailable, "20",
+		},
+		"never ending blackout": {
+			"* * * * * *", time.Date(2020, 6, 7, 3, 30, 0, 0, time.UTC),
+			http.StatusServiceUnavailable, "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts := []Option{}
+			if len(strings.Fields(tc.blackout)) == 7 {
+				opts = append(opts, WithSeconds())
+			}
+
+			blackout, err := New(tc.blackout, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			handler := blackoutMiddleware(blackout, func() time.Time { return tc.now })(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			if recorder.Code != tc.wantStatus {
+				t.Errorf("incorrect status; want %d, got %d", tc.wantStatus, recorder.Code)
+			}
+
+			if got := recorder.Header().Get("Retry-After"); got != tc.wantRetry {
+				t.Errorf("incorrect Retry-After; want %q, got %q", tc.wantRetry, got)
+			}
+		})
+	}
+}
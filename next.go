@@ -0,0 +1,290 @@
+package avail
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNoOccurrence is returned when a Timeframe has no occurrence within the years it can
+// express.
+var ErrNoOccurrence = errors.New("no occurrence within the expression's years")
+
+// Next returns the first time strictly after the time given at which the Timeframe is able,
+// at minute precision (or second precision for expressions with seconds). The result is in
+// the Timeframe's location if it has one and the location of the time given otherwise.
+//
+// Rather than checking every minute, Next advances a field at a time, skipping whole years,
+// months, days and hours which cannot match. ErrNoOccurrence is returned if there is no such
+// time before the end of the expression's years, or of the period given with
+// WithActiveBetween.
+func (a *Timeframe) Next(after time.Time) (time.Time, error) {
+	return a.nextFrom(after, after.Location())
+}
+
+// UntilNext returns how long after the time given the Timeframe is next able, or zero if it
+// is able at that time. The second return value is false if the Timeframe will never be able
+// again.
+func (a *Timeframe) UntilNext(t time.Time) (time.Duration, bool) {
+	if a.Able(t) {
+		return 0, true
+	}
+
+	next, err := a.Next(t)
+	if err != nil {
+		return 0, false
+	}
+
+	return next.Sub(t), true
+}
+
+// Between reports whether the Timeframe is able at any time within the half-open interval
+// [start, end). It is found with Next, so long intervals are as cheap to check as short ones.
+func (a *Timeframe) Between(start, end time.Time) bool {
+	if !start.Before(end) {
+		return false
+	}
+
+	if a.Able(start) {
+		return true
+	}
+
+	next, err := a.Next(start)
+	return err == nil && next.Before(end)
+}
+
+// AbleWithin reports whether the Timeframe is able at any time within d either side of the
+// time given, inclusive. It tolerates clock skew and delayed delivery when matching events to
+// a schedule. Ex. "0 9 * * * *" is able within 2 minutes of 8:58 and of 9:02, but not within
+// 1 minute of 8:58. A negative d is treated as zero, which is the same as Able.
+func (a *Timeframe) AbleWithin(t time.Time, d time.Duration) bool {
+	if d < 0 {
+		d = 0
+	}
+	return a.Between(t.Add(-d), t.Add(d+time.Nanosecond))
+}
+
+// NextN returns the next n times strictly after the time given at which the Timeframe is
+// able, in order. Fewer than n times are returned if the expression's years run out first.
+//
+// Each occurrence is searched for from the one before it, so NextN is cheaper than calling
+// Next with each result in turn.
+func (a *Timeframe) NextN(after time.Time, n int) []time.Time {
+	times := []time.Time{}
+	location := after.Location()
+
+	current := after
+	for len(times) < n {
+		next, err := a.nextFrom(current, location)
+		if err != nil {
+			break
+		}
+		times = append(times, next)
+		current = next
+	}
+
+	return times
+}
+
+// FormatNext returns the next n occurrences after the time given ready for display, as NextN
+// finds them, converted to the location given and formatted with the layout. Ex.
+// FormatNext(now, 3, time.Kitchen, time.UTC) might return ["9:00AM", "9:00AM", "9:00AM"] for a
+// daily schedule. A nil location leaves each occurrence in the location NextN returns it in.
+//
+// An empty layout describes each occurrence in English by how long after the time given it is,
+// to the second. Ex. "in 1 hour 30 minutes" or "in 2 days".
+func (a *Timeframe) FormatNext(after time.Time, n int, layout string, location *time.Location) []string {
+	d := describer{translations: englishPhrases}
+
+	formatted := []string{}
+	for _, next := range a.NextN(after, n) {
+		if layout == "" {
+			until := ceilDivide(next.Sub(after), time.Second) * time.Second
+			formatted = append(formatted, d.phrase("in", d.duration(until)))
+			continue
+		}
+
+		if location != nil {
+			next = next.In(location)
+		}
+		formatted = append(formatted, next.Format(layout))
+	}
+
+	return formatted
+}
+
+// nextFrom returns the next time the Timeframe, including its exclusions, is able. Location
+// is the location to evaluate times in if the Timeframe does not have one of its own.
+func (a *Timeframe) nextFrom(after time.Time, location *time.Location) (time.Time, error) {
+	if a.Location != nil {
+		location = a.Location
+	}
+
+	current := after
+	if !a.options.activeStart.IsZero() && current.Before(a.options.activeStart) {
+		current = a.options.activeStart.Add(-time.Nanosecond)
+	}
+	for {
+		next, err := a.nextMatch(current, location)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !a.options.active(next) {
+			return time.Time{}, ErrNoOccurrence
+		}
+
+		excluded := false
+		for i := range a.exclusions {
+			if a.exclusions[i].Able(next) {
+				excluded = true
+				current = a.exclusions[i].skipExcluded(next, a.options.precision())
+				break
+			}
+		}
+		if !excluded {
+			return next, nil
+		}
+	}
+}
+
+// skipExcluded returns a time, at or after the excluded time given, which is just before the
+// earliest time the exclusion may stop being able. Exclusions which cover whole days are
+// skipped a day at a time.
+func (a *Timeframe) skipExcluded(excluded time.Time, precision time.Duration) time.Time {
+	if !a.coversDays() {
+		return excluded
+	}
+
+	local := excluded
+	if a.Location != nil {
+		local = excluded.In(a.Location)
+	}
+
+	midnight := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, local.Location())
+	if !midnight.After(excluded) {
+		return excluded
+	}
+	return midnight.Add(-precision)
+}
+
+// coversDays reports whether the Timeframe is able for the whole of any day it is able at all.
+func (a *Timeframe) coversDays() bool {
+	parsed := a.ParsedExpression
+	return a.Interval == 0 && a.alternatives == nil && len(a.exclusions) == 0 &&
+		parsed.Hours.isWildcard() && parsed.Minutes.isWildcard() &&
+		(!a.options.seconds || parsed.Seconds.isWildcard())
+}
+
+// nextMatch returns the next time the Timeframe's expression matches, without considering
+// exclusions.
+func (a *Timeframe) nextMatch(after time.Time, location *time.Location) (time.Time, error) {
+	if a.alternatives != nil {
+		var earliest time.Time
+		for i := range a.alternatives {
+			next, err := a.alternatives[i].nextFrom(after, location)
+			if errors.Is(err, ErrNoOccurrence) {
+				continue
+			}
+			if err != nil {
+				return time.Time{}, err
+			}
+			if earliest.IsZero() || next.Before(earliest) {
+				earliest = next
+			}
+		}
+		if earliest.IsZero() {
+			return time.Time{}, ErrNoOccurrence
+		}
+		return earliest, nil
+	}
+
+	if a.Interval != 0 {
+		elapsed := after.Sub(intervalEpoch)
+		intervals := elapsed / a.Interval
+		if elapsed < 0 && elapsed%a.Interval != 0 {
+			intervals--
+		}
+		return intervalEpoch.Add((intervals + 1) * a.Interval).In(location), nil
+	}
+
+	if a.options.dstPolicy != DSTWallClock {
+		return a.nextAcrossTransitions(after, location)
+	}
+	return a.nextWallClock(after, location)
+}
+
+// nextWallClock returns the next time the plain expression matches by wall clock time.
+func (a *Timeframe) nextWallClock(after time.Time, location *time.Location) (time.Time, error) {
+	precision := a.options.precision()
+
+	// Start from the first time after the one given at the expression's precision.
+	t := after.In(location)
+	t = t.Add(-time.Duration(t.Nanosecond()))
+	if precision == time.Minute {
+		t = t.Add(-time.Duration(t.Second()) * time.Second)
+	}
+	t = t.Add(precision)
+
+	parsed := a.ParsedExpression
+
+	for {
+		if !parsed.Years.Has(t.Year()) {
+			year, ok := parsed.Years.valueAfter(t.Year())
+			if !ok {
+				return time.Time{}, ErrNoOccurrence
+			}
+			t = time.Date(year, time.January, 1, 0, 0, 0, 0, location)
+			continue
+		}
+
+		if _, ok := parsed.Months.Values[int(t.Month())]; !ok {
+			t = advance(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, location))
+			continue
+		}
+
+		if !a.matchesDay(t) {
+			t = advance(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, location))
+			continue
+		}
+
+		// Hours, minutes and seconds are advanced by adding durations rather than constructing
+		// times so that hours which are repeated when daylight saving time ends are visited.
+		if _, ok := parsed.Hours.Values[t.Hour()]; !ok {
+			t = t.Add(-time.Duration(t.Second())*time.Second - time.Duration(t.Minute())*time.Minute).Add(time.Hour)
+			continue
+		}
+
+		if _, ok := parsed.Minutes.Values[t.Minute()]; !ok {
+			t = t.Add(-time.Duration(t.Second()) * time.Second).Add(time.Minute)
+			continue
+		}
+
+		if a.options.seconds {
+			if _, ok := parsed.Seconds.Values[t.Second()]; !ok {
+				t = t.Add(time.Second)
+				continue
+			}
+		}
+
+		return t, nil
+	}
+}
+
+// advance returns next, unless constructing it has not moved forward from current (which can
+// happen around daylight saving time transitions), in which case it returns the next hour.
+func advance(current, next time.Time) time.Time {
+	if next.After(current) {
+		return next
+	}
+	return current.Add(time.Hour)
+}
+
+// nextValue returns the smallest value in the set which is greater than the value given and
+// no greater than max.
+func nextValue(values map[int]struct{}, value, max int) (int, bool) {
+	for candidate := value + 1; candidate <= max; candidate++ {
+		if _, ok := values[candidate]; ok {
+			return candidate, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,370 @@
+package avail
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// bruteForceNext returns the next time after the time given at which the Timeframe is able by
+// checking every instant at the given precision.
+func bruteForceNext(a *Timeframe, after time.Time, precision time.Duration, horizon time.Duration) (time.Time, bool) {
+	t := after.Truncate(precision).Add(precision)
+	for end := after.Add(horizon); t.Before(end); t = t.Add(precision) {
+		if a.Able(t) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func TestNext(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		after      time.Time
+		want       time.Time
+	}{
+		"next minute":       {"* * * * * *", time.Date(2020, 6, 8, 9, 0, 30, 0, time.UTC), time.Date(2020, 6, 8, 9, 1, 0, 0, time.UTC)},
+		"strictly after":    {"0 9 * * * *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), time.Date(2020, 6, 9, 9, 0, 0, 0, time.UTC)},
+		"later today":       {"30 17 * * * *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), time.Date(2020, 6, 8, 17, 30, 0, 0, time.UTC)},
+		"next weekday":      {"0 9 * * 1-5 *", time.Date(2020, 6, 6, 9, 0, 0, 0, time.UTC), time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC)},
+		"next month":        {"0 0 1 * * *", time.Date(2020, 12, 15, 0, 0, 0, 0, time.UTC), time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		"leap day":          {"0 0 29 2 * *", time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		"last day":          {"0 0 L * * *", time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC)},
+		"last weekday":      {"0 0 LW * * *", time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 5, 29, 0, 0, 0, 0, time.UTC)},
+		"next year":         {"0 0 1 1 * 2030", time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC), time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)},
+		"before first year": {"0 0 1 1 * *", time.Date(1960, 6, 8, 0, 0, 0, 0, time.UTC), time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)},
+		"interval":          {"@every 90m", time.Date(1970, 1, 1, 1, 0, 0, 0, time.UTC), time.Date(1970, 1, 1, 1, 30, 0, 0, time.UTC)},
+		"interval on":       {"@every 90m", time.Date(1970, 1, 1, 1, 30, 0, 0, time.UTC), time.Date(1970, 1, 1, 3, 0, 0, 0, time.UTC)},
+		"combined": {"0 9 * * 1-5 * || 0 10 * * 0,6 *", time.Date(2020, 6, 5, 10, 0, 0, 0, time.UTC),
+			time.Date(2020, 6, 6, 10, 0, 0, 0, time.UTC)},
+		"timezone": {"CRON_TZ=America/New_York 0 9 * * * *", time.Date(2020, 6, 8, 12, 0, 0, 0, time.UTC),
+			time.Date(2020, 6, 8, 13, 0, 0, 0, time.UTC)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := avail.Next(tc.after)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !got.Equal(tc.want) {
+				t.Errorf("incorrect next time; want %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNextMatchesAble(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+		precision  time.Duration
+	}{
+		"business hours":  {"* 9-17 * * 1-5 *", nil, time.Minute},
+		"quarter hours":   {"0,15,30,45 * * * * *", nil, time.Minute},
+		"seconds":         {"15,45 30 * * * * *", []Option{WithSeconds()}, time.Second},
+		"nth day of week": {"0 0 9 ? * 6#2", []Option{WithDialect(Quartz)}, time.Minute},
+		"nearest weekday": {"0 0 12 1W * ?", []Option{WithDialect(Quartz)}, time.Minute},
+		"new york":        {"CRON_TZ=America/New_York 30 1-3 * * * *", nil, time.Minute},
+	}
+
+	after := time.Date(2020, 3, 7, 22, 17, 0, 0, time.UTC)
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			current := after
+			for i := 0; i < 20; i++ {
+				got, err := avail.Next(current)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				want, ok := bruteForceNext(&avail, current, tc.precision, 62*24*time.Hour)
+				if !ok {
+					t.Fatal("brute force found no occurrence")
+				}
+
+				if !got.Equal(want) {
+					t.Fatalf("incorrect next time after %s; want %s, got %s", current, want, got)
+				}
+				current = got
+			}
+		})
+	}
+}
+
+func TestNextDaylightSavingTime(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	avail, err := New("CRON_TZ=America/New_York 30 1 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// On 2020-11-01 1:30 occurs twice, once in EDT and once in EST.
+	first, err := avail.Next(time.Date(2020, 11, 1, 0, 0, 0, 0, location))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := avail.Next(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if second.Sub(first) != time.Hour {
+		t.Errorf("repeated hour should be visited; got %s then %s", first, second)
+	}
+
+	// On 2020-03-08 2:30 never occurs.
+	skipped, err := New("CRON_TZ=America/New_York 30 2 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := skipped.Next(time.Date(2020, 3, 8, 0, 0, 0, 0, location))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := time.Date(2020, 3, 9, 2, 30, 0, 0, location)
+	if !got.Equal(want) {
+		t.Errorf("incorrect next time; want %s, got %s", want, got)
+	}
+}
+
+func TestNextExcept(t *testing.T) {
+	base, err := New("0 9 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	christmas, err := New("* * 25 12 * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	avail := base.Except(christmas)
+
+	got, err := avail.Next(time.Date(2020, 12, 24, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := time.Date(2020, 12, 26, 9, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("incorrect next time; want %s, got %s", want, got)
+	}
+}
+
+func TestNextNoOccurrence(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		after      time.Time
+	}{
+		"impossible date": {"0 0 30 2 * *", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+		"past the years":  {"0 0 1 1 * 2020", time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)},
+		"past the range":  {"* * * * * *", time.Date(2100, 12, 31, 23, 59, 0, 0, time.UTC)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := avail.Next(tc.after); !errors.Is(err, ErrNoOccurrence) {
+				t.Errorf("want ErrNoOccurrence, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAbleWithin(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		time       time.Time
+		tolerance  time.Duration
+		want       bool
+	}{
+		"inside":         {"0 9 * * * *", time.Date(2020, 6, 8, 9, 0, 30, 0, time.UTC), 0, true},
+		"early":          {"0 9 * * * *", time.Date(2020, 6, 8, 8, 58, 0, 0, time.UTC), 2 * time.Minute, true},
+		"too early":      {"0 9 * * * *", time.Date(2020, 6, 8, 8, 58, 0, 0, time.UTC), time.Minute, false},
+		"late":           {"0 9 * * * *", time.Date(2020, 6, 8, 9, 2, 0, 0, time.UTC), 2 * time.Minute, true},
+		"too late":       {"0 9 * * * *", time.Date(2020, 6, 8, 9, 2, 0, 0, time.UTC), time.Minute - time.Nanosecond, false},
+		"late seconds":   {"0 9 * * * *", time.Date(2020, 6, 8, 9, 1, 30, 0, time.UTC), 31 * time.Second, true},
+		"negative":       {"0 9 * * * *", time.Date(2020, 6, 8, 8, 59, 0, 0, time.UTC), -time.Hour, false},
+		"across a day":   {"0 0 * * * *", time.Date(2020, 6, 7, 23, 55, 0, 0, time.UTC), 5 * time.Minute, true},
+		"never":          {"0 9 30 2 * *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), 24 * time.Hour, false},
+		"outside a week": {"0 9 * * 1 *", time.Date(2020, 6, 10, 9, 0, 0, 0, time.UTC), 24 * time.Hour, false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := avail.AbleWithin(tc.time, tc.tolerance); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNextN(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		after      time.Time
+		n          int
+		want       []time.Time
+	}{
+		"weekdays": {"0 9 * * 1-5 *", time.Date(2020, 6, 5, 0, 0, 0, 0, time.UTC), 3, []time.Time{
+			time.Date(2020, 6, 5, 9, 0, 0, 0, time.UTC),
+			time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC),
+			time.Date(2020, 6, 9, 9, 0, 0, 0, time.UTC),
+		}},
+		"runs out": {"0 0 1 1 * 2099-2100", time.Date(2020, 6, 5, 0, 0, 0, 0, time.UTC), 5, []time.Time{
+			time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+		}},
+		"none": {"0 9 * * * *", time.Date(2020, 6, 5, 0, 0, 0, 0, time.UTC), 0, []time.Time{}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diff := cmp.Diff(tc.want, avail.NextN(tc.after, tc.n))
+			if diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFormatNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		expression string
+		after      time.Time
+		n          int
+		layout     string
+		location   *time.Location
+		want       []string
+	}{
+		"layout": {"0 9 * * 1-5 *", time.Date(2020, 6, 5, 0, 0, 0, 0, time.UTC), 2, "Mon 2006-01-02 15:04", nil,
+			[]string{"Fri 2020-06-05 09:00", "Mon 2020-06-08 09:00"}},
+		"location": {"0 9 * * * *", time.Date(2020, 6, 5, 0, 0, 0, 0, time.UTC), 1, "15:04 MST", newYork,
+			[]string{"05:00 EDT"}},
+		"relative": {"30 10 * * * *", time.Date(2020, 6, 5, 9, 0, 0, 0, time.UTC), 2, "", nil,
+			[]string{"in 1 hour 30 minutes", "in 1 day 1 hour 30 minutes"}},
+		"relative seconds": {"0 9 * * * *", time.Date(2020, 6, 5, 8, 59, 29, 500, time.UTC), 1, "", nil,
+			[]string{"in 31 seconds"}},
+		"runs out": {"0 0 1 1 * 2100", time.Date(2020, 6, 5, 0, 0, 0, 0, time.UTC), 3, "2006", nil,
+			[]string{"2100"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diff := cmp.Diff(tc.want, avail.FormatNext(tc.after, tc.n, tc.layout, tc.location))
+			if diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUntilNext(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		t          time.Time
+		want       time.Duration
+		ok         bool
+	}{
+		"able now":   {"* 9 * * * *", time.Date(2020, 6, 8, 9, 30, 15, 0, time.UTC), 0, true},
+		"later":      {"0 9 * * * *", time.Date(2020, 6, 8, 8, 59, 30, 0, time.UTC), 30 * time.Second, true},
+		"tomorrow":   {"0 9 * * * *", time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC), 23 * time.Hour, true},
+		"never":      {"0 9 30 2 * *", time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC), 0, false},
+		"past years": {"0 9 * * * 2019", time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC), 0, false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, ok := avail.UntilNext(tc.t)
+			if ok != tc.ok {
+				t.Fatalf("want %t, got %t", tc.ok, ok)
+			}
+
+			if got != tc.want {
+				t.Errorf("want %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		start      time.Time
+		end        time.Time
+		want       bool
+	}{
+		"inside":   {"0 9 * * * *", time.Date(2020, 6, 8, 8, 0, 0, 0, time.UTC), time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC), true},
+		"at start": {"0 9 * * * *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), time.Date(2020, 6, 8, 9, 1, 0, 0, time.UTC), true},
+		"at end":   {"0 9 * * * *", time.Date(2020, 6, 8, 8, 0, 0, 0, time.UTC), time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), false},
+		"outside":  {"0 9 * * 1-5 *", time.Date(2020, 6, 6, 0, 0, 0, 0, time.UTC), time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC), false},
+		"long":     {"0 0 29 2 * *", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		"empty":    {"* * * * * *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), false},
+		"never":    {"0 9 30 2 * *", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := avail.Between(tc.start, tc.end); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,492 @@
+package avail
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// minutesPerDay is the number of minutes within a day as measured by a wall clock.
+const minutesPerDay = 24 * 60
+
+// OpeningHours models storefront style availability: a list of open time ranges for each day
+// of the week, a calendar of holidays on which it is closed all day and the timezone the
+// hours are kept in. It is a friendlier alternative to combining several Timeframes by hand.
+//
+// OpeningHours are evaluated at minute precision. Any number of disjoint ranges may be added
+// to a single day (ex. 09:00-12:30 and 13:30-18:00 to close for lunch); each day's ranges are
+// compiled into a bitset of open minutes so checking a time is constant time.
+type OpeningHours struct {
+	location *time.Location
+	ranges   map[time.Weekday][]timeRange
+	holidays map[date]struct{}
+
+	// open holds the compiled ranges for each weekday.
+	open [7]minuteSet
+}
+
+// minuteSet is a bitset of the minutes within a day.
+type minuteSet [(minutesPerDay + 63) / 64]uint64
+
+func (m *minuteSet) add(minute int) {
+	m[minute/64] |= 1 << uint(minute%64)
+}
+
+func (m *minuteSet) has(minute int) bool {
+	return m[minute/64]&(1<<uint(minute%64)) != 0
+}
+
+// timeRange is a range of minutes since midnight on a single day. Start is inclusive and end
+// is exclusive.
+type timeRange struct {
+	start, end int
+}
+
+// date is a calendar date irrespective of location.
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) date {
+	return date{year: t.Year(), month: t.Month(), day: t.Day()}
+}
+
+// NewOpeningHours returns OpeningHours with no open times, kept in the given location. If the
+// location is nil times are evaluated in whichever location they were created in.
+func NewOpeningHours(location *time.Location) *OpeningHours {
+	return &OpeningHours{
+		location: location,
+		ranges:   map[time.Weekday][]timeRange{},
+		holidays: map[date]struct{}{},
+	}
+}
+
+// Add opens the hours on the given weekday from start up to end, both given as 24 hour clock
+// times in the form "15:04". An end of "24:00" closes at midnight. If end is before start the
+// range continues past midnight into the following day. Ex. Add(time.Friday, "22:00", "02:00").
+func (o *OpeningHours) Add(weekday time.Weekday, start, end string) error {
+	if weekday < time.Sunday || weekday > time.Saturday {
+		return fmt.Errorf("weekday(%d) is not a valid weekday", weekday)
+	}
+
+	startMinute, err := parseClockTime(start)
+	if err != nil {
+		return err
+	}
+
+	endMinute, err := parseClockTime(end)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case startMinute == endMinute:
+		return fmt.Errorf("start(%s) cannot be equal to end(%s)", start, end)
+	case startMinute < endMinute:
+		o.addRange(weekday, timeRange{start: startMinute, end: endMinute})
+	default:
+		o.addRange(weekday, timeRange{start: startMinute, end: minutesPerDay})
+		if endMinute > 0 {
+			o.addRange((weekday+1)%7, timeRange{start: 0, end: endMinute})
+		}
+	}
+
+	return nil
+}
+
+// AddHoliday closes the hours for the entire calendar day of the given date.
+func (o *OpeningHours) AddHoliday(day time.Time) {
+	o.holidays[dateOf(day)] = struct{}{}
+}
+
+// addRange adds the range to the weekday, keeping the weekday's ranges sorted and merging any
+// that overlap or touch.
+func (o *OpeningHours) addRange(weekday time.Weekday, newRange timeRange) {
+	ranges := append(o.ranges[weekday], newRange)
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].start < ranges[j].start })
+
+	merged := []timeRange{ranges[0]}
+	for _, current := range ranges[1:] {
+		previous := &merged[len(merged)-1]
+		if current.start <= previous.end {
+			if current.end > previous.end {
+				previous.end = current.end
+			}
+			continue
+		}
+		merged = append(merged, current)
+	}
+
+	o.ranges[weekday] = merged
+
+	compiled := minuteSet{}
+	for _, current := range merged {
+		for minute := current.start; minute < current.end; minute++ {
+			compiled.add(minute)
+		}
+	}
+	o.open[weekday] = compiled
+}
+
+// IsOpen reports whether the hours are open at the time given.
+func (o *OpeningHours) IsOpen(t time.Time) bool {
+	t = o.in(t)
+
+	if o.isHoliday(t) {
+		return false
+	}
+
+	return o.open[t.Weekday()].has(t.Hour()*60 + t.Minute())
+}
+
+// Describe returns a human readable summary of the hours. Consecutive days sharing the same
+// ranges are grouped and days without ranges are omitted.
+// Ex. "Mon-Fri 09:00-12:30, 13:30-18:00; Sat 10:00-14:00 (America/New_York)".
+func (o *OpeningHours) Describe() string {
+	// Weeks are described starting on Monday as that is how opening hours are usually read.
+	week := []time.Weekday{
+		time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday,
+	}
+
+	groups := []string{}
+	for i := 0; i < len(week); {
+		ranges := o.describeRanges(week[i])
+
+		j := i
+		for j+1 < len(week) && o.describeRanges(week[j+1]) == ranges {
+			j++
+		}
+
+		if ranges != "" {
+			days := week[i].String()[:3]
+			if j > i {
+				days += "-" + week[j].String()[:3]
+			}
+			groups = append(groups, days+" "+ranges)
+		}
+
+		i = j + 1
+	}
+
+	description := "Closed"
+	if len(groups) > 0 {
+		description = strings.Join(groups, "; ")
+	}
+
+	switch len(o.holidays) {
+	case 0:
+	case 1:
+		description += "; closed on 1 holiday"
+	default:
+		description += fmt.Sprintf("; closed on %d holidays", len(o.holidays))
+	}
+
+	if o.location != nil {
+		description += " (" + o.location.String() + ")"
+	}
+
+	return description
+}
+
+// describeRanges returns the weekday's ranges formatted as clock times.
+// Ex. "09:00-12:30, 13:30-18:00".
+func (o *OpeningHours) describeRanges(weekday time.Weekday) string {
+	ranges := []string{}
+	for _, current := range o.ranges[weekday] {
+		ranges = append(ranges, formatClockTime(current.start)+"-"+formatClockTime(current.end))
+	}
+	return strings.Join(ranges, ", ")
+}
+
+// NextOpen returns the earliest time at or after t at which the hours are open. If the hours
+// are open at t, t is returned. It returns false if the hours are never open.
+func (o *OpeningHours) NextOpen(t time.Time) (time.Time, bool) {
+	t = o.in(t)
+	if o.IsOpen(t) {
+		return t, true
+	}
+
+	for day := 0; day <= o.horizon(); day++ {
+		midnight := time.Date(t.Year(), t.Month(), t.Day()+day, 0, 0, 0, 0, t.Location())
+		if o.isHoliday(midnight) {
+			continue
+		}
+
+		for _, current := range o.ranges[midnight.Weekday()] {
+			start := atMinute(midnight, current.start)
+			if start.After(t) {
+				return start, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// NextClose returns the earliest time at or after t at which the hours are closed. If the
+// hours are closed at t, t is returned. It returns false if the hours never close.
+func (o *OpeningHours) NextClose(t time.Time) (time.Time, bool) {
+	t = o.in(t)
+	if !o.IsOpen(t) {
+		return t, true
+	}
+
+	current := t.Truncate(time.Minute)
+	for day := 0; day <= o.horizon(); day++ {
+		midnight := time.Date(current.Year(), current.Month(), current.Day(), 0, 0, 0, 0, current.Location())
+		minute := current.Hour()*60 + current.Minute()
+
+		for _, openRange := range o.ranges[midnight.Weekday()] {
+			if minute < openRange.start || minute >= openRange.end {
+				continue
+			}
+
+			if openRange.end < minutesPerDay {
+				return atMinute(midnight, openRange.end), true
+			}
+		}
+
+		// The range runs until midnight, the hours close then unless the next day opens at midnight.
+		current = time.Date(current.Year(), current.Month(), current.Day()+1, 0, 0, 0, 0, current.Location())
+		if !o.IsOpen(current) {
+			return current, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// ToTimeframes converts the weekly hours into a set of Timeframes which, taken together, are
+// able exactly when the hours are open. Holidays cannot be represented as cron expressions and
+// are not carried over.
+func (o *OpeningHours) ToTimeframes() ([]Timeframe, error) {
+	// Weekdays with identical ranges share the same expressions.
+	weekdaysByTerms := map[string][]string{}
+	termOrder := []string{}
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		for _, current := range o.ranges[weekday] {
+			for _, terms := range current.terms() {
+				if _, ok := weekdaysByTerms[terms]; !ok {
+					termOrder = append(termOrder, terms)
+				}
+				weekdaysByTerms[terms] = append(weekdaysByTerms[terms], strconv.Itoa(int(weekday)))
+			}
+		}
+	}
+
+	prefix := ""
+	if o.location != nil && o.location != time.Local {
+		prefix = "TZ=" + o.location.String() + " "
+	}
+
+	timeframes := []Timeframe{}
+	for _, terms := range termOrder {
+		weekdays := strings.Join(weekdaysByTerms[terms], ",")
+		timeframe, err := New(fmt.Sprintf("%s%s * * %s *", prefix, terms, weekdays))
+		if err != nil {
+			return nil, err
+		}
+		timeframes = append(timeframes, timeframe)
+	}
+
+	return timeframes, nil
+}
+
+// OpeningHoursFromTimeframes builds weekly OpeningHours from Timeframes which only restrict the
+// minute, hour and weekday fields. The hours are open whenever any of the Timeframes is able.
+// All Timeframes must share the same location. The alternatives of combined expressions are
+// converted individually. Timeframes with exclusions from Except, an active period or holidays
+// cannot be converted, since weekly hours cannot represent them.
+func OpeningHoursFromTimeframes(timeframes ...Timeframe) (*OpeningHours, error) {
+	if len(timeframes) == 0 {
+		return nil, fmt.Errorf("at least one timeframe is required")
+	}
+
+	expanded := []Timeframe{}
+	for _, timeframe := range timeframes {
+		if err := timeframe.checkWeekly(); err != nil {
+			return nil, err
+		}
+		if timeframe.alternatives == nil {
+			expanded = append(expanded, timeframe)
+			continue
+		}
+		for _, alternative := range timeframe.alternatives {
+			if alternative.Location == nil {
+				alternative.Location = timeframe.Location
+			}
+			expanded = append(expanded, alternative)
+		}
+	}
+	timeframes = expanded
+
+	hours := NewOpeningHours(timeframes[0].Location)
+	open := map[time.Weekday]*[minutesPerDay]bool{}
+
+	for _, timeframe := range timeframes {
+		if !sameLocation(timeframe.Location, hours.location) {
+			return nil, fmt.Errorf("could not convert %s: all timeframes must share a location", timeframe.Expression)
+		}
+
+		if err := timeframe.checkWeekly(); err != nil {
+			return nil, err
+		}
+
+		// Since the day term must be a wildcard, WithDomDowOr does not change which dates match.
+		parsed := timeframe.ParsedExpression
+		if timeframe.Interval != 0 || timeframe.options.seconds ||
+			!parsed.Days.isWildcard() || !parsed.Months.isWildcard() || !parsed.Years.isWildcard() {
+			return nil, fmt.Errorf("could not convert %s: only minutes, hours and weekdays may be restricted",
+				timeframe.Expression)
+		}
+
+		for weekday := range parsed.Weekdays.Values {
+			if open[time.Weekday(weekday)] == nil {
+				open[time.Weekday(weekday)] = &[minutesPerDay]bool{}
+			}
+			for hour := range parsed.Hours.Values {
+				for minute := range parsed.Minutes.Values {
+					open[time.Weekday(weekday)][hour*60+minute] = true
+				}
+			}
+		}
+	}
+
+	for weekday, minutes := range open {
+		start := -1
+		for minute := 0; minute <= minutesPerDay; minute++ {
+			isOpen := minute < minutesPerDay && minutes[minute]
+			switch {
+			case isOpen && start == -1:
+				start = minute
+			case !isOpen && start != -1:
+				hours.addRange(weekday, timeRange{start: start, end: minute})
+				start = -1
+			}
+		}
+	}
+
+	return hours, nil
+}
+
+// checkWeekly returns an error if the Timeframe carries restrictions, beyond its expression,
+// which weekly OpeningHours cannot represent.
+func (a *Timeframe) checkWeekly() error {
+	switch {
+	case len(a.exclusions) != 0:
+		return fmt.Errorf("could not convert %s: timeframes with exclusions cannot be converted", a.Expression)
+	case a.options.bounded():
+		return fmt.Errorf("could not convert %s: timeframes with an active period cannot be converted", a.Expression)
+	case a.options.holidays != nil:
+		return fmt.Errorf("could not convert %s: timeframes with holidays cannot be converted", a.Expression)
+	}
+	return nil
+}
+
+// terms returns the minute and hour terms of the cron expressions which together cover the
+// range. Ex. 09:30-17:15 is covered by "30-59 9", "* 10-16" and "0-14 17".
+func (r timeRange) terms() []string {
+	startHour, startMinute := r.start/60, r.start%60
+	endHour, endMinute := r.end/60, r.end%60
+
+	if startHour == endHour {
+		return []string{fmt.Sprintf("%s %d", spanTerm(startMinute, endMinute-1), startHour)}
+	}
+
+	terms := []string{}
+	firstFullHour := startHour
+	if startMinute != 0 {
+		terms = append(terms, fmt.Sprintf("%s %d", spanTerm(startMinute, 59), startHour))
+		firstFullHour++
+	}
+
+	if firstFullHour <= endHour-1 {
+		terms = append(terms, fmt.Sprintf("* %s", spanTerm(firstFullHour, endHour-1)))
+	}
+
+	if endMinute != 0 {
+		terms = append(terms, fmt.Sprintf("%s %d", spanTerm(0, endMinute-1), endHour))
+	}
+
+	return terms
+}
+
+// spanTerm returns the shortest term representing the inclusive range of values.
+func spanTerm(start, end int) string {
+	if start == end {
+		return strconv.Itoa(start)
+	}
+	return fmt.Sprintf("%d-%d", start, end)
+}
+
+// parseClockTime parses a 24 hour clock time in the form "15:04" into minutes since midnight.
+// "24:00" is accepted to denote the end of the day.
+func parseClockTime(clock string) (int, error) {
+	parts := strings.Split(clock, ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("could not parse time %s; must be in the form HH:MM", clock)
+	}
+
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("could not parse hour %s: %v", parts[0], err)
+	}
+
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("could not parse minute %s: %v", parts[1], err)
+	}
+
+	if hour == 24 && minute == 0 {
+		return minutesPerDay, nil
+	}
+
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("time %s is not a valid time of day", clock)
+	}
+
+	return hour*60 + minute, nil
+}
+
+// sameLocation reports whether both locations refer to the same zone. Locations loaded
+// separately are distinct values even when they share a name.
+func sameLocation(a, b *time.Location) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.String() == b.String()
+}
+
+// atMinute returns the wall clock time the given number of minutes into the day.
+func atMinute(day time.Time, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, minute, 0, 0, day.Location())
+}
+
+// formatClockTime formats minutes since midnight as a 24 hour clock time. Ex. "09:30".
+func formatClockTime(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
+
+// in converts the time to the hours' location, if one was given.
+func (o *OpeningHours) in(t time.Time) time.Time {
+	if o.location != nil {
+		return t.In(o.location)
+	}
+	return t
+}
+
+func (o *OpeningHours) isHoliday(t time.Time) bool {
+	_, ok := o.holidays[dateOf(t)]
+	return ok
+}
+
+// horizon is the number of days after which searching for an open or close time can stop.
+// Every weekday has been seen at least once by then, even when skipping every holiday.
+func (o *OpeningHours) horizon() int {
+	return 7 + len(o.holidays)
+}
@@ -0,0 +1,324 @@
+package avail
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestOpeningHours(t *testing.T) *OpeningHours {
+	t.Helper()
+
+	hours := NewOpeningHours(time.UTC)
+	for weekday := time.Monday; weekday <= time.Friday; weekday++ {
+		if err := hours.Add(weekday, "09:00", "17:30"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := hours.Add(time.Saturday, "22:00", "02:00"); err != nil {
+		t.Fatal(err)
+	}
+	hours.AddHoliday(time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC))
+
+	return hours
+}
+
+func TestOpeningHoursIsOpen(t *testing.T) {
+	hours := newTestOpeningHours(t)
+
+	tests := map[string]struct {
+		time time.Time
+		want bool
+	}{
+		"weekday morning":   {time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), true},
+		"weekday closing":   {time.Date(2020, 6, 8, 17, 30, 0, 0, time.UTC), false},
+		"before opening":    {time.Date(2020, 6, 8, 8, 59, 0, 0, time.UTC), false},
+		"saturday night":    {time.Date(2020, 6, 13, 23, 0, 0, 0, time.UTC), true},
+		"past midnight":     {time.Date(2020, 6, 14, 1, 59, 0, 0, time.UTC), true},
+		"sunday afternoon":  {time.Date(2020, 6, 14, 12, 0, 0, 0, time.UTC), false},
+		"holiday":           {time.Date(2020, 12, 25, 12, 0, 0, 0, time.UTC), false},
+		"other timezone":    {time.Date(2020, 6, 8, 5, 0, 0, 0, time.FixedZone("EDT", -4*60*60)), true},
+		"other timezone no": {time.Date(2020, 6, 8, 4, 0, 0, 0, time.FixedZone("EDT", -4*60*60)), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if hours.IsOpen(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+}
+
+func TestOpeningHoursNextOpenAndClose(t *testing.T) {
+	hours := newTestOpeningHours(t)
+
+	tests := map[string]struct {
+		time      time.Time
+		wantOpen  time.Time
+		wantClose time.Time
+	}{
+		"while open": {
+			time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC),
+			time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC),
+			time.Date(2020, 6, 8, 17, 30, 0, 0, time.UTC),
+		},
+		"overnight": {
+			time.Date(2020, 6, 13, 23, 0, 0, 0, time.UTC),
+			time.Date(2020, 6, 13, 23, 0, 0, 0, time.UTC),
+			time.Date(2020, 6, 14, 2, 0, 0, 0, time.UTC),
+		},
+		"over the weekend": {
+			time.Date(2020, 6, 14, 12, 0, 0, 0, time.UTC),
+			time.Date(2020, 6, 15, 9, 0, 0, 0, time.UTC),
+			time.Date(2020, 6, 14, 12, 0, 0, 0, time.UTC),
+		},
+		"skips holiday": {
+			time.Date(2020, 12, 24, 18, 0, 0, 0, time.UTC),
+			time.Date(2020, 12, 26, 22, 0, 0, 0, time.UTC),
+			time.Date(2020, 12, 24, 18, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			open, ok := hours.NextOpen(tc.time)
+			if !ok || !open.Equal(tc.wantOpen) {
+				t.Errorf("incorrect next open; want %s, got %s", tc.wantOpen, open)
+			}
+
+			closed, ok := hours.NextClose(tc.time)
+			if !ok || !closed.Equal(tc.wantClose) {
+				t.Errorf("incorrect next close; want %s, got %s", tc.wantClose, closed)
+			}
+		})
+	}
+}
+
+func TestOpeningHoursNeverCloses(t *testing.T) {
+	hours := NewOpeningHours(nil)
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if err := hours.Add(weekday, "00:00", "24:00"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, ok := hours.NextClose(time.Now()); ok {
+		t.Error("hours which are always open should never close")
+	}
+
+	if _, ok := NewOpeningHours(nil).NextOpen(time.Now()); ok {
+		t.Error("hours without ranges should never open")
+	}
+}
+
+func TestOpeningHoursTimeframes(t *testing.T) {
+	hours := newTestOpeningHours(t)
+
+	timeframes, err := hours.ToTimeframes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2020, 6, 7, 0, 0, 0, 0, time.UTC)
+	for current := start; current.Before(start.AddDate(0, 0, 7)); current = current.Add(time.Minute) {
+		able := false
+		for _, timeframe := range timeframes {
+			if timeframe.Able(current) {
+				able = true
+			}
+		}
+
+		if able != hours.IsOpen(current) {
+			t.Fatalf("timeframes and hours disagree at %s; timeframes %t, hours %t", current, able, !able)
+		}
+	}
+
+	converted, err := OpeningHoursFromTimeframes(timeframes...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for current := start; current.Before(start.AddDate(0, 0, 7)); current = current.Add(time.Minute) {
+		if converted.IsOpen(current) != hours.IsOpen(current) {
+			t.Fatalf("converted hours disagree at %s", current)
+		}
+	}
+
+	expressions := []string{}
+	for _, timeframe := range timeframes {
+		expressions = append(expressions, timeframe.Expression)
+	}
+
+	combined, err := New(strings.Join(expressions, " || "))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	converted, err = OpeningHoursFromTimeframes(combined)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for current := start; current.Before(start.AddDate(0, 0, 7)); current = current.Add(time.Minute) {
+		if converted.IsOpen(current) != hours.IsOpen(current) {
+			t.Fatalf("hours converted from a combined expression disagree at %s", current)
+		}
+	}
+}
+
+func TestOpeningHoursInvalid(t *testing.T) {
+	hours := NewOpeningHours(nil)
+
+	tests := map[string]struct {
+		start string
+		end   string
+	}{
+		"malformed":   {"9am", "17:00"},
+		"out of day":  {"09:00", "25:00"},
+		"empty range": {"09:00", "09:00"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := hours.Add(time.Monday, tc.start, tc.end); err == nil {
+				t.Errorf("range %s-%s should not be accepted", tc.start, tc.end)
+			}
+		})
+	}
+
+	timeframe, err := New("* * 1 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OpeningHoursFromTimeframes(timeframe); err == nil {
+		t.Error("timeframes restricting the day should not be converted")
+	}
+}
+
+func TestOpeningHoursFromTimeframesUnrepresentable(t *testing.T) {
+	holidays := NewStaticHolidays(time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC))
+	business := MustNew("* 9-16 * * 1-5 *")
+
+	tests := map[string]Timeframe{
+		"except":        business.Except(MustNew("* 12 * * * *")),
+		"active period": MustNew("* 9-16 * * 1-5 *", WithActiveBetween(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), time.Time{})),
+		"holidays":      MustNew("* 9-16 * * 1-5 *", WithHolidaysExcluded(holidays)),
+		"combined except": MustNew("* 9-16 * * 1-5 * || * 10-13 * * 6 *").Except(
+			MustNew("* 12 * * * *")),
+	}
+
+	for name, timeframe := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := OpeningHoursFromTimeframes(timeframe); err == nil {
+				t.Errorf("timeframe should not be converted")
+			}
+		})
+	}
+}
+
+func TestOpeningHoursFromTimeframesDomDowOr(t *testing.T) {
+	timeframe := MustNew("* 9-16 * * 1-5 *", WithDomDowOr())
+
+	hours, err := OpeningHoursFromTimeframes(timeframe)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2020, 6, 7, 0, 0, 0, 0, time.UTC)
+	for current := start; current.Before(start.AddDate(0, 0, 7)); current = current.Add(time.Minute) {
+		if hours.IsOpen(current) != timeframe.Able(current) {
+			t.Fatalf("converted hours disagree at %s", current)
+		}
+	}
+}
+
+func TestOpeningHoursLunchBreak(t *testing.T) {
+	hours := NewOpeningHours(nil)
+	for weekday := time.Monday; weekday <= time.Friday; weekday++ {
+		if err := hours.Add(weekday, "09:00", "12:30"); err != nil {
+			t.Fatal(err)
+		}
+		if err := hours.Add(weekday, "13:30", "18:00"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := map[string]struct {
+		time time.Time
+		want bool
+	}{
+		"last minute before lunch": {time.Date(2020, 6, 8, 12, 29, 0, 0, time.UTC), true},
+		"start of lunch":           {time.Date(2020, 6, 8, 12, 30, 0, 0, time.UTC), false},
+		"last minute of lunch":     {time.Date(2020, 6, 8, 13, 29, 0, 0, time.UTC), false},
+		"end of lunch":             {time.Date(2020, 6, 8, 13, 30, 0, 0, time.UTC), true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if hours.IsOpen(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+
+	closed, ok := hours.NextClose(time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC))
+	if want := time.Date(2020, 6, 8, 12, 30, 0, 0, time.UTC); !ok || !closed.Equal(want) {
+		t.Errorf("incorrect next close; want %s, got %s", want, closed)
+	}
+
+	open, ok := hours.NextOpen(time.Date(2020, 6, 8, 12, 45, 0, 0, time.UTC))
+	if want := time.Date(2020, 6, 8, 13, 30, 0, 0, time.UTC); !ok || !open.Equal(want) {
+		t.Errorf("incorrect next open; want %s, got %s", want, open)
+	}
+
+	timeframes, err := hours.ToTimeframes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC)
+	for current := start; current.Before(start.AddDate(0, 0, 1)); current = current.Add(time.Minute) {
+		able := false
+		for _, timeframe := range timeframes {
+			if timeframe.Able(current) {
+				able = true
+			}
+		}
+
+		if able != hours.IsOpen(current) {
+			t.Fatalf("timeframes and hours disagree at %s; timeframes %t, hours %t", current, able, !able)
+		}
+	}
+}
+
+func TestOpeningHoursDescribe(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hours := NewOpeningHours(location)
+	for weekday := time.Monday; weekday <= time.Friday; weekday++ {
+		if err := hours.Add(weekday, "09:00", "12:30"); err != nil {
+			t.Fatal(err)
+		}
+		if err := hours.Add(weekday, "13:30", "18:00"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := hours.Add(time.Saturday, "10:00", "24:00"); err != nil {
+		t.Fatal(err)
+	}
+	hours.AddHoliday(time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC))
+
+	want := "Mon-Fri 09:00-12:30, 13:30-18:00; Sat 10:00-24:00; closed on 1 holiday (America/New_York)"
+	if got := hours.Describe(); got != want {
+		t.Errorf("incorrect description; want %q, got %q", want, got)
+	}
+
+	if got := NewOpeningHours(nil).Describe(); got != "Closed" {
+		t.Errorf("incorrect description; want %q, got %q", "Closed", got)
+	}
+}
@@ -0,0 +1,254 @@
+package avail
+
+import (
+	"fmt"
+	"time"
+)
+
+// Option configures how an expression is parsed and evaluated. Options are passed to New.
+type Option func(*options)
+
+// options holds the settings that can be changed by passing Options to New.
+type options struct {
+	// seconds enables a leading seconds term, making expressions 7 terms long.
+	seconds bool
+	// optionalYear allows the trailing year term to be omitted.
+	optionalYear bool
+	// hashKey is hashed to resolve "H" terms.
+	hashKey string
+	// macros are expanded in addition to the predefined and registered macros.
+	macros map[string]string
+	// dialect is the grammar expressions are parsed in; the zero value is the native grammar.
+	dialect Dialect
+	// zoneFallback is used in place of a prefixed zone which cannot be loaded.
+	zoneFallback *time.Location
+	// clock returns the current time for AbleNow and Now; when nil time.Now is used.
+	clock func() time.Time
+	// expandedJSON makes MarshalJSON write the expanded object form.
+	expandedJSON bool
+	// rejectImpossible makes New return an error for expressions which can never match.
+	rejectImpossible bool
+	// minYear and maxYear replace the range of the year term; both are zero by default.
+	minYear, maxYear int
+	// domDowOr matches dates satisfying either of the day and weekday terms when both are
+	// restricted.
+	domDowOr bool
+	// location is the location expressions without a timezone prefix are evaluated in.
+	location *time.Location
+	// dstPolicy determines how times skipped or repeated by daylight saving time are treated.
+	dstPolicy DSTPolicy
+	// granularity is the precision expressions without a seconds term are evaluated at; zero
+	// is a minute.
+	granularity time.Duration
+	// activeStart and activeEnd bound the period the Timeframe is able in to
+	// [activeStart, activeEnd); zero values leave that side unbounded.
+	activeStart, activeEnd time.Time
+	// holidays reports the holidays excluded from the Timeframe, or the only days it is able
+	// on if onlyHolidays is set.
+	holidays     HolidayProvider
+	onlyHolidays bool
+}
+
+// WithSeconds allows the expression to contain a leading seconds term with a range of 0-59.
+// Ex. "30 0 12 * * * *" would match 12:00:30 every day.
+//
+// When enabled Able will also compare the seconds of the time it is given.
+func WithSeconds() Option {
+	return func(o *options) {
+		o.seconds = true
+	}
+}
+
+// WithOptionalYear allows the trailing year term to be omitted, in which case the year is
+// treated as a wildcard. This allows standard 5 term crontab expressions like "0 9 * * 1-5"
+// to be used as is.
+func WithOptionalYear() Option {
+	return func(o *options) {
+		o.optionalYear = true
+	}
+}
+
+// WithHashKey sets the key used to resolve "H" terms. Each "H" term is replaced by a value
+// within the field's range derived from a hash of the key, so expressions like "H H * * * *"
+// can be shared by many jobs while each job (keyed by its name) runs at its own stable time.
+//
+// The following forms are accepted:
+//
+//    H          a single hashed value within the field's range
+//    H(0-29)    a single hashed value within the given range
+//    H/15       every 15 values, starting at a hashed offset
+//    H(0-29)/10 every 10 values within the given range, starting at a hashed offset
+//
+// Mirroring Jenkins, hashed days of the month are limited to 1-28 so they occur every month.
+func WithHashKey(key string) Option {
+	return func(o *options) {
+		o.hashKey = key
+	}
+}
+
+// WithMacros adds macros which are only expanded for this expression, taking precedence over
+// macros added with RegisterMacro. Names follow the same rules as RegisterMacro and cannot
+// collide with a predefined macro. Ex. WithMacros(map[string]string{"@payday": "0 9 L * * *"}).
+func WithMacros(macros map[string]string) Option {
+	return func(o *options) {
+		if o.macros == nil {
+			o.macros = map[string]string{}
+		}
+		for name, expression := range macros {
+			o.macros[name] = expression
+		}
+	}
+}
+
+// WithZoneFallback sets the location used when the zone named by a "CRON_TZ=" or "TZ=" prefix
+// cannot be loaded, for instance because the zone database is missing. Ex.
+// WithZoneFallback(time.UTC) or WithZoneFallback(time.FixedZone("EST", -5*60*60)).
+//
+// Without a fallback New returns a *ZoneError in this case.
+func WithZoneFallback(location *time.Location) Option {
+	return func(o *options) {
+		o.zoneFallback = location
+	}
+}
+
+// WithClock sets the function AbleNow and Now use to find the current time, in place of
+// time.Now. It is intended for tests which need to control the current time.
+func WithClock(clock func() time.Time) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// WithExpandedJSON makes MarshalJSON encode the Timeframe as an object holding its
+// expression, the options it was parsed with and the values each of its terms resolved to,
+// rather than as the expression alone. It is intended for audit logs and APIs which need to
+// show exactly which times a schedule covered.
+func WithExpandedJSON() Option {
+	return func(o *options) {
+		o.expandedJSON = true
+	}
+}
+
+// WithRejectImpossible makes New return an error wrapping ErrNeverMatches for expressions
+// which parse but can never match, as no date within their years satisfies their day, month
+// and weekday terms. Ex. "* * 30 2 * *" or "0 0 31 * THU 2021". A combined expression is only
+// rejected if none of its alternatives can match.
+//
+// Without it such expressions are accepted and reported as errors by SelfCheck.
+func WithRejectImpossible() Option {
+	return func(o *options) {
+		o.rejectImpossible = true
+	}
+}
+
+// WithYearRange replaces the range of years the year term may select, which is 1970-2100 by
+// default, in every grammar. Ex. WithYearRange(2020, 2030) for a system with a short horizon
+// which need not hold every year, or WithYearRange(1970, 2500) to plan past 2100. Years must be
+// within 1-9999.
+//
+// A wildcard year matches every year of the range, and Next finds no occurrence after its end.
+func WithYearRange(min, max int) Option {
+	return func(o *options) {
+		o.minYear, o.maxYear = min, max
+	}
+}
+
+// WithDomDowOr matches dates which satisfy either the day of month or the day of week term
+// when both are restricted, as classic cron does, rather than requiring both. Ex. with it
+// "0 0 1,15 * 1 *" matches the 1st and 15th of every month and every Monday; without it only
+// Mondays which fall on the 1st or 15th. When either term is a wildcard only the other
+// decides, as before.
+//
+// Use it when evaluating schedules taken from crontab files, whose meaning otherwise changes
+// silently.
+func WithDomDowOr() Option {
+	return func(o *options) {
+		o.domDowOr = true
+	}
+}
+
+// WithLocation evaluates the expression in the location given, as if it had been prefixed with
+// "CRON_TZ=", so that Able, Next and Windows give the same answer whatever the location of the
+// times passed to them. Ex. New("0 9 * * 1-5 *", WithLocation(newYork)) matches 9am in New
+// York. A timezone prefix in the expression takes precedence over the location.
+//
+// The location is not part of the expression, so MarshalText rejects such Timeframes. Locations
+// which cannot be loaded by name, such as those made with time.FixedZone, cannot be encoded by
+// MarshalBinary or in expanded JSON either.
+func WithLocation(location *time.Location) Option {
+	return func(o *options) {
+		o.location = location
+	}
+}
+
+// yearBounds returns the range of years set with WithYearRange, or the default range given.
+func (o options) yearBounds(min, max int) (int, int) {
+	if o.minYear == 0 && o.maxYear == 0 {
+		return min, max
+	}
+	return o.minYear, o.maxYear
+}
+
+// WithGranularity sets the precision expressions without a seconds term are evaluated at:
+// time.Minute, the default, or time.Second. At second granularity Next, Windows, Count and the
+// other occurrence functions step a second at a time, so "0 9 * * * *" occurs at each of the
+// 60 seconds from 9:00:00, and intervals may be given in seconds, as in "@every 30s".
+// Expressions with a seconds term are always evaluated to the second.
+func WithGranularity(granularity time.Duration) Option {
+	return func(o *options) {
+		o.granularity = granularity
+	}
+}
+
+// precision returns the smallest unit of time the options allow an expression to express.
+func (o options) precision() time.Duration {
+	if o.seconds || o.granularity == time.Second {
+		return time.Second
+	}
+	return time.Minute
+}
+
+// newOptions returns the options produced by applying each Option in order, or an error if
+// the resulting options are invalid.
+func newOptions(opts []Option) (options, error) {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if _, ok := dialects[o.dialect]; !ok && o.dialect != "" && o.dialect != Native {
+		return options{}, fmt.Errorf("unknown dialect %s", o.dialect)
+	}
+
+	if o.granularity != 0 && o.granularity != time.Minute && o.granularity != time.Second {
+		return options{}, fmt.Errorf("granularity(%s) must be a minute or a second", o.granularity)
+	}
+
+	if !o.activeStart.IsZero() && !o.activeEnd.IsZero() && !o.activeStart.Before(o.activeEnd) {
+		return options{}, fmt.Errorf("active period(%s-%s) must start before it ends",
+			o.activeStart.Format(time.RFC3339), o.activeEnd.Format(time.RFC3339))
+	}
+
+	if o.dstPolicy < DSTWallClock || o.dstPolicy > DSTBoth {
+		return options{}, fmt.Errorf("unknown daylight saving time policy %d", o.dstPolicy)
+	}
+
+	if (o.minYear != 0 || o.maxYear != 0) && (o.minYear < 1 || o.maxYear > 9999 || o.minYear > o.maxYear) {
+		return options{}, fmt.Errorf("year range(%d-%d) must be ascending and within 1-9999", o.minYear, o.maxYear)
+	}
+
+	if len(o.macros) > 0 {
+		macros := map[string]string{}
+		for name, expression := range o.macros {
+			normalized, err := validateMacro(name, expression)
+			if err != nil {
+				// Positions within the macro are not positions within the expression being parsed.
+				return options{}, clearPosition(err)
+			}
+			macros[normalized] = expression
+		}
+		o.macros = macros
+	}
+
+	return o, nil
+}
@@ -0,0 +1,51 @@
+package avail
+
+import "time"
+
+// Overlaps reports whether the Timeframe and the other are ever able at the same time, such
+// as two maintenance windows which would collide. Ex. "* 1-3 * * 6 *" overlaps
+// "* 2 * * * *" but not "* 4-5 * * * *".
+//
+// As with Contains, the answer holds for all time, except for Timeframes with intervals or
+// whose terms are evaluated in different locations, which are compared over the year
+// following the current time, as given by the Timeframe's clock.
+func (a Timeframe) Overlaps(other Timeframe) bool {
+	found, ok := findDay(&a, &other, func(own, others secondsOfDay) bool {
+		return own.intersects(others)
+	})
+	if !ok {
+		return a.overlapsWithinYear(&other)
+	}
+	return found
+}
+
+// overlapsWithinYear reports whether the other is able during any window of the Timeframe
+// within the year following the current time.
+func (a *Timeframe) overlapsWithinYear(other *Timeframe) bool {
+	start := a.Now()
+	end := start.AddDate(1, 0, 0)
+
+	for _, window := range a.Windows(start, end) {
+		if other.Between(window.Start, window.End) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextOverlap returns the first time strictly after the time given at which both the
+// Timeframe and the other are able, at the finer precision of the two. The second return
+// value is false if they never overlap again.
+func (a Timeframe) NextOverlap(other Timeframe, after time.Time) (time.Time, bool) {
+	if !a.Overlaps(other) {
+		return time.Time{}, false
+	}
+
+	intersection := a.Intersect(other)
+	next, err := intersection.Next(after)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return next, true
+}
@@ -0,0 +1,70 @@
+package avail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOverlaps(t *testing.T) {
+	business := MustNew("* 9-17 * * 1-5 *")
+
+	tests := map[string]struct {
+		timeframe Timeframe
+		other     Timeframe
+		want      bool
+	}{
+		"itself":             {business, business, true},
+		"shared hour":        {business, MustNew("* 17-20 * * * *"), true},
+		"disjoint hours":     {business, MustNew("* 18-20 * * * *"), false},
+		"weekend":            {business, MustNew("* 10 * * 6,0 *"), false},
+		"seconds":            {MustNew("0 0 10 * * * *", WithSeconds()), MustNew("30 0 10 * * * *", WithSeconds()), false},
+		"seconds in minute":  {MustNew("30 0 10 * * * *", WithSeconds()), MustNew("0 10 * * * *"), true},
+		"never":              {business, MustNew("0 10 31 2 * *"), false},
+		"last day":           {MustNew("0 12 L * * *"), MustNew("0 12 31 * * *"), true},
+		"last day of feb":    {MustNew("0 12 L 2 * *"), MustNew("0 12 30,31 * * *"), false},
+		"years":              {MustNew("0 0 * * * 2020-2030"), MustNew("0 0 * * * 2031"), false},
+		"alternatives":       {MustNew("0 8 * * * * || 0 17 * * * *"), business, true},
+		"exclusion":          {business.Except(MustNew("* 12 * * * *")), MustNew("* 12 * * 1 *"), false},
+		"different location": {MustNew("CRON_TZ=Europe/Berlin * 9 * * * *"), MustNew("CRON_TZ=America/New_York * 9 * * * *"), false},
+		"overlapping zones":  {MustNew("CRON_TZ=Europe/Berlin * 9 * * * *"), MustNew("CRON_TZ=Europe/London * 8 * * * *"), true},
+		"interval":           {business, MustNew("@every 90m"), true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.timeframe.Overlaps(tc.other); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+			if got := tc.other.Overlaps(tc.timeframe); got != tc.want {
+				t.Errorf("want %t, got %t for the reverse", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNextOverlap(t *testing.T) {
+	after := time.Date(2020, 6, 6, 0, 0, 0, 0, time.UTC) // Saturday
+
+	tests := map[string]struct {
+		timeframe string
+		other     string
+		want      time.Time
+		ok        bool
+	}{
+		"shared hour": {"0 9-17 * * 1-5 *", "0 17-20 * * * *", time.Date(2020, 6, 8, 17, 0, 0, 0, time.UTC), true},
+		"last day":    {"0 12 L * * *", "0 12 31 * * *", time.Date(2020, 7, 31, 12, 0, 0, 0, time.UTC), true},
+		"disjoint":    {"0 9-17 * * 1-5 *", "0 18-20 * * * *", time.Time{}, false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := MustNew(tc.timeframe).NextOverlap(MustNew(tc.other), after)
+			if ok != tc.ok {
+				t.Fatalf("want %t, got %t", tc.ok, ok)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("want %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
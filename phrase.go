@@ -0,0 +1,465 @@
+package avail
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// clockRegex matches a time of day within a phrase. Ex. "9am", "9:30pm" or "17:00".
+var clockRegex = regexp.MustCompile(`^([0-9]{1,2})(?::([0-9]{2}))?(am|pm)?$`)
+
+// ordinalDayRegex matches a day of the month within a phrase. Ex. "1st" or "15th".
+var ordinalDayRegex = regexp.MustCompile(`^([0-9]{1,2})(st|nd|rd|th)$`)
+
+// phraseFillers are words that carry no meaning of their own within a phrase.
+var phraseFillers = map[string]bool{"and": true, "the": true, "of": true, "month": true, "on": true, "day": true}
+
+// ParsePhrase returns a Timeframe for a schedule written in plain English. Ex.
+// ParsePhrase("every weekday at 9am") is equivalent to New("0 9 * * 1-5 *").
+//
+// The grammar is deliberately small. A phrase is made up, in any order, of:
+//
+//    times of day         "at 9am", "at 9:30pm and 17:00", "at noon", "at midnight"
+//    repetition           "every minute", "every 15 minutes", "every hour", "every 2 hours", "hourly",
+//                         "daily"
+//    hours                "from 9am to 5pm", "between 9am and 5pm"
+//    days of the week     "every weekday", "on weekends", "on monday and friday", "every tuesday"
+//    days of the month    "on the 1st and 15th", "on the last day of the month"
+//    months and years     "in january", "in march and april", "in 2025"
+//
+// Repetition restarts every hour (or day) as it does in cron, so "every 7 minutes" matches
+// minutes 0, 7 ... 56 of each hour. "daily" without a time of day matches midnight, as
+// "@daily" does. A phrase without a time of day or repetition matches the whole of each day it
+// selects. Times with different minutes are combined as alternatives.
+func ParsePhrase(phrase string) (Timeframe, error) {
+	parser := phraseParser{}
+	if err := parser.parse(phrase); err != nil {
+		return Timeframe{}, fmt.Errorf("could not parse phrase: %s; %w", phrase, err)
+	}
+
+	expression, err := parser.expression()
+	if err != nil {
+		return Timeframe{}, fmt.Errorf("could not parse phrase: %s; %w", phrase, err)
+	}
+
+	return New(expression)
+}
+
+// phraseParser collects the parts of a phrase as it is read.
+type phraseParser struct {
+	words    []string
+	position int
+
+	// times are the times of day given with "at", as minutes since midnight.
+	times []int
+	// minuteStep and hourStep are set by "every N minutes" and "every N hours".
+	minuteStep, hourStep int
+	// everyMinute and hourly are set by "every minute" and "every hour", and daily by "daily".
+	everyMinute, hourly, daily bool
+	// fromHour and toHour bound the hours given with "from" or "between". toHour is exclusive.
+	fromHour, toHour int
+	hoursBounded     bool
+
+	weekdays map[int]struct{}
+	days     map[int]struct{}
+	lastDay  bool
+	months   map[int]struct{}
+	years    map[int]struct{}
+}
+
+func (p *phraseParser) parse(phrase string) error {
+	p.words = strings.Fields(strings.ToLower(strings.ReplaceAll(phrase, ",", " ")))
+	if len(p.words) == 0 {
+		return fmt.Errorf("phrase cannot be empty")
+	}
+
+	for p.position < len(p.words) {
+		word := p.next()
+
+		switch {
+		case word == "every":
+			if err := p.parseEvery(); err != nil {
+				return err
+			}
+		case word == "daily":
+			p.daily = true
+		case word == "hourly":
+			p.hourly = true
+		case word == "at":
+			if err := p.parseTimes(); err != nil {
+				return err
+			}
+		case word == "from" || word == "between":
+			if err := p.parseHours(); err != nil {
+				return err
+			}
+		case word == "in":
+			if err := p.parseMonthsAndYears(); err != nil {
+				return err
+			}
+		case word == "last":
+			p.lastDay = true
+		case p.addWeekdays(word):
+		case ordinalDayRegex.MatchString(word):
+			day, _ := strconv.Atoi(ordinalDayRegex.FindStringSubmatch(word)[1])
+			if day < 1 || day > 31 {
+				return fmt.Errorf("day(%d) must be between 1 and 31", day)
+			}
+			p.days = addValue(p.days, day)
+		case phraseFillers[word]:
+		default:
+			return fmt.Errorf("unrecognized word %q", word)
+		}
+	}
+
+	return nil
+}
+
+// next returns the next word and advances past it.
+func (p *phraseParser) next() string {
+	word := p.words[p.position]
+	p.position++
+	return word
+}
+
+// peek returns the next word without advancing, or an empty string at the end of the phrase.
+func (p *phraseParser) peek() string {
+	if p.position >= len(p.words) {
+		return ""
+	}
+	return p.words[p.position]
+}
+
+// parseEvery parses the remainder of an "every ..." clause.
+func (p *phraseParser) parseEvery() error {
+	word := p.peek()
+	if word == "" {
+		return fmt.Errorf("every must be followed by a unit or a day")
+	}
+	p.position++
+
+	if n, err := strconv.Atoi(word); err == nil {
+		unit := p.peek()
+		p.position++
+
+		switch unit {
+		case "minutes", "minute":
+			if n < 1 || n > 59 {
+				return fmt.Errorf("minutes(%d) must be between 1 and 59", n)
+			}
+			p.minuteStep = n
+		case "hours", "hour":
+			if n < 1 || n > 23 {
+				return fmt.Errorf("hours(%d) must be between 1 and 23", n)
+			}
+			p.hourStep = n
+		default:
+			return fmt.Errorf("every %d must be followed by minutes or hours", n)
+		}
+		return nil
+	}
+
+	switch word {
+	case "minute":
+		p.everyMinute = true
+	case "hour":
+		p.hourly = true
+	case "day":
+	default:
+		if !p.addWeekdays(word) {
+			return fmt.Errorf("unrecognized word %q after every", word)
+		}
+	}
+	return nil
+}
+
+// addWeekdays adds the days of the week named by the word, reporting whether it named any.
+// Ex. "monday", "mondays", "weekday" or "weekends".
+func (p *phraseParser) addWeekdays(word string) bool {
+	switch strings.TrimSuffix(word, "s") {
+	case "weekday":
+		for weekday := 1; weekday <= 5; weekday++ {
+			p.weekdays = addValue(p.weekdays, weekday)
+		}
+		return true
+	case "weekend":
+		p.weekdays = addValue(p.weekdays, 0)
+		p.weekdays = addValue(p.weekdays, 6)
+		return true
+	}
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := strings.ToLower(weekday.String())
+		if word == name || word == name+"s" {
+			p.weekdays = addValue(p.weekdays, int(weekday))
+			return true
+		}
+	}
+	return false
+}
+
+// parseTimes parses the times of day following "at". Ex. "9am and 5:30pm".
+func (p *phraseParser) parseTimes() error {
+	parsed := 0
+	for p.peek() != "" {
+		if p.peek() == "and" && parsed > 0 {
+			p.position++
+			continue
+		}
+
+		minute, ok, err := p.parseClock()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		p.times = append(p.times, minute)
+		parsed++
+	}
+
+	if parsed == 0 {
+		return fmt.Errorf("at must be followed by a time")
+	}
+
+	// A trailing "and" belongs to the clause that follows the times.
+	if p.words[p.position-1] == "and" {
+		p.position--
+	}
+	return nil
+}
+
+// parseHours parses "<time> to <time>" or "<time> and <time>" following "from" or "between".
+func (p *phraseParser) parseHours() error {
+	start, ok, err := p.parseClock()
+	if err != nil {
+		return err
+	}
+	if !ok || (p.peek() != "to" && p.peek() != "and") {
+		return fmt.Errorf("hours must be given as <time> to <time>")
+	}
+	p.position++
+
+	end, ok, err := p.parseClock()
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("hours must be given as <time> to <time>")
+	}
+
+	if start%60 != 0 || end%60 != 0 {
+		return fmt.Errorf("hours must start and end on the hour")
+	}
+	if end == 0 {
+		end = minutesPerDay
+	}
+	if end <= start {
+		return fmt.Errorf("hours must end after they start")
+	}
+
+	p.fromHour, p.toHour, p.hoursBounded = start/60, end/60, true
+	return nil
+}
+
+// parseClock parses a time of day at the current position, reporting false without
+// advancing if there is none. It returns the time as minutes since midnight.
+func (p *phraseParser) parseClock() (int, bool, error) {
+	word := p.peek()
+
+	switch word {
+	case "noon":
+		p.position++
+		return 12 * 60, true, nil
+	case "midnight":
+		p.position++
+		return 0, true, nil
+	}
+
+	match := clockRegex.FindStringSubmatch(word)
+	if match == nil {
+		return 0, false, nil
+	}
+	p.position++
+
+	hour, _ := strconv.Atoi(match[1])
+	minute := 0
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	}
+
+	meridiem := match[3]
+	if meridiem == "" && (p.peek() == "am" || p.peek() == "pm") {
+		meridiem = p.next()
+	}
+
+	if meridiem != "" {
+		if hour < 1 || hour > 12 {
+			return 0, false, fmt.Errorf("time %s is not a valid time of day", word)
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	} else if match[2] == "" {
+		return 0, false, fmt.Errorf("time %s must include minutes or am/pm", word)
+	}
+
+	if hour > 23 || minute > 59 {
+		return 0, false, fmt.Errorf("time %s is not a valid time of day", word)
+	}
+
+	return hour*60 + minute, true, nil
+}
+
+// parseMonthsAndYears parses the months and years following "in". Ex. "january and 2025".
+func (p *phraseParser) parseMonthsAndYears() error {
+	parsed := 0
+	for word := p.peek(); word != ""; word = p.peek() {
+		if word == "and" && parsed > 0 {
+			p.position++
+			continue
+		}
+
+		if year, err := strconv.Atoi(word); err == nil && len(word) == 4 {
+			p.years = addValue(p.years, year)
+		} else if month, ok := monthNamed(word); ok {
+			p.months = addValue(p.months, month)
+		} else {
+			break
+		}
+		p.position++
+		parsed++
+	}
+
+	if parsed == 0 {
+		return fmt.Errorf("in must be followed by a month or year")
+	}
+
+	if p.words[p.position-1] == "and" {
+		p.position--
+	}
+	return nil
+}
+
+// monthNamed returns the month named by the word in full or abbreviated. Ex. "january" or
+// "jan".
+func monthNamed(word string) (int, bool) {
+	if month, ok := fieldNames[month][word]; ok {
+		return month, true
+	}
+	for month := time.January; month <= time.December; month++ {
+		if word == strings.ToLower(month.String()) {
+			return int(month), true
+		}
+	}
+	return 0, false
+}
+
+// expression returns the native expression equivalent to the phrase.
+func (p *phraseParser) expression() (string, error) {
+	repetitions := 0
+	daily := p.daily && len(p.times) == 0
+	for _, set := range []bool{len(p.times) > 0, p.minuteStep != 0, p.hourStep != 0, p.everyMinute, p.hourly, daily} {
+		if set {
+			repetitions++
+		}
+	}
+	if repetitions > 1 {
+		return "", fmt.Errorf("phrase can only contain one of a time of day or a repetition")
+	}
+	if p.hoursBounded && (len(p.times) > 0 || daily) {
+		return "", fmt.Errorf("phrase cannot contain both times of day and hours")
+	}
+
+	times := p.times
+	if daily {
+		times = []int{0}
+	}
+
+	if p.lastDay && len(p.days) > 0 {
+		return "", fmt.Errorf("phrase cannot contain both the last day and other days of the month")
+	}
+
+	days := termOf(p.days)
+	if p.lastDay {
+		days = "L"
+	}
+	date := strings.Join([]string{days, termOf(p.months), termOf(p.weekdays), termOf(p.years)}, " ")
+
+	if len(times) > 0 {
+		// Times sharing a minute are combined into one expression, others become alternatives.
+		hoursByMinute := map[int]map[int]struct{}{}
+		for _, clock := range times {
+			hoursByMinute[clock%60] = addValue(hoursByMinute[clock%60], clock/60)
+		}
+
+		minutes := make([]int, 0, len(hoursByMinute))
+		for minute := range hoursByMinute {
+			minutes = append(minutes, minute)
+		}
+		sort.Ints(minutes)
+
+		expressions := []string{}
+		for _, minute := range minutes {
+			expressions = append(expressions, fmt.Sprintf("%d %s %s", minute, formatNativeValues(hoursByMinute[minute]), date))
+		}
+		return strings.Join(expressions, " || "), nil
+	}
+
+	fromHour, toHour := 0, 24
+	if p.hoursBounded {
+		fromHour, toHour = p.fromHour, p.toHour
+	}
+
+	hours := map[int]struct{}{}
+	step := 1
+	if p.hourStep != 0 {
+		step = p.hourStep
+	}
+	for hour := fromHour; hour < toHour; hour += step {
+		hours = addValue(hours, hour)
+	}
+	hoursTerm := formatNativeValues(hours)
+	if len(hours) == 24 {
+		hoursTerm = "*"
+	}
+
+	minutesTerm := "*"
+	switch {
+	case p.minuteStep != 0:
+		minutes := map[int]struct{}{}
+		for minute := 0; minute < 60; minute += p.minuteStep {
+			minutes = addValue(minutes, minute)
+		}
+		minutesTerm = formatNativeValues(minutes)
+		if len(minutes) == 60 {
+			minutesTerm = "*"
+		}
+	case p.hourStep != 0, p.hourly:
+		minutesTerm = "0"
+	}
+
+	return strings.Join([]string{minutesTerm, hoursTerm, date}, " "), nil
+}
+
+// termOf returns the values as a native term, or a wildcard if there are none.
+func termOf(values map[int]struct{}) string {
+	if len(values) == 0 {
+		return "*"
+	}
+	return formatNativeValues(values)
+}
+
+// addValue adds the value to the set, creating the set if needed.
+func addValue(values map[int]struct{}, value int) map[int]struct{} {
+	if values == nil {
+		values = map[int]struct{}{}
+	}
+	values[value] = struct{}{}
+	return values
+}
@@ -0,0 +1,74 @@
+package avail
+
+import "testing"
+
+func TestParsePhrase(t *testing.T) {
+	tests := map[string]struct {
+		phrase string
+		want   string
+	}{
+		"weekday morning":   {"every weekday at 9am", "0 9 * * 1-5 *"},
+		"minutes and hours": {"every 15 minutes from 9am to 5pm on weekdays", "0,15,30,45 9-16 * * 1-5 *"},
+		"between":           {"every minute between 9am and 5pm", "* 9-16 * * * *"},
+		"every hour":        {"every hour", "0 * * * * *"},
+		"every 2 hours":     {"every 2 hours", "0 0,2,4,6,8,10,12,14,16,18,20,22 * * * *"},
+		"times":             {"at 9am and 5pm", "0 9,17 * * * *"},
+		"mixed minutes":     {"at 9:30am and 5pm", "0 17 * * * * || 30 9 * * * *"},
+		"noon":              {"every day at noon", "0 12 * * * *"},
+		"24 hour clock":     {"at 17:45", "45 17 * * * *"},
+		"separate meridiem": {"at 12 am", "0 0 * * * *"},
+		"weekday names":     {"every monday, wednesday and friday at 8:30", "30 8 * * 1,3,5 *"},
+		"plural weekday":    {"on sundays", "* * * * 0 *"},
+		"days of month":     {"at midnight on the 1st and 15th", "0 0 1,15 * * *"},
+		"last day":          {"at 6pm on the last day of the month", "0 18 L * * *"},
+		"months":            {"daily at 7am in january and march", "0 7 * 1,3 * *"},
+		"daily":             {"daily", "0 0 * * * *"},
+		"daily on weekdays": {"daily on weekdays", "0 0 * * 1-5 *"},
+		"abbreviated month": {"at 7am in dec", "0 7 * 12 * *"},
+		"year":              {"on weekends in 2025", "* * * * 0,6 2025"},
+		"case":              {"Every Weekday At 9AM", "0 9 * * 1-5 *"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := ParsePhrase(tc.phrase)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if avail.Expression != tc.want {
+				t.Errorf("want %q, got %q", tc.want, avail.Expression)
+			}
+		})
+	}
+}
+
+func TestParsePhraseUnparseable(t *testing.T) {
+	tests := map[string]struct {
+		phrase string
+	}{
+		"empty":             {""},
+		"unknown word":      {"every fortnight"},
+		"missing time":      {"at"},
+		"bare number":       {"at 9"},
+		"invalid time":      {"at 13pm"},
+		"two repetitions":   {"every hour at 9am"},
+		"partial hours":     {"every minute from 9:30am to 5pm"},
+		"backwards hours":   {"every minute from 5pm to 9am"},
+		"times and hours":   {"at 9am from 9am to 5pm"},
+		"step out of range": {"every 60 minutes"},
+		"day out of range":  {"on the 32nd"},
+		"missing unit":      {"every 5"},
+		"daily repetition":  {"daily every hour"},
+		"daily hours":       {"daily from 9am to 5pm"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParsePhrase(tc.phrase)
+			if err == nil {
+				t.Errorf("phrase %s should not be parsed successfully", tc.phrase)
+			}
+		})
+	}
+}
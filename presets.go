@@ -0,0 +1,38 @@
+package avail
+
+import (
+	"fmt"
+	"time"
+)
+
+// BusinessHours returns a Timeframe able from the start hour up to the end hour, given on a 24
+// hour clock, Monday to Friday in the location. Ex. BusinessHours(9, 17, location) is
+// "CRON_TZ=<location> * 9-16 * * 1-5 *", able from 9:00 until 17:00. If the location is nil
+// times are evaluated in whichever location they were created in.
+func BusinessHours(start, end int, location *time.Location) (Timeframe, error) {
+	if start < 0 || end > 24 || start >= end {
+		return Timeframe{}, fmt.Errorf("could not build expression: business hours(%d-%d) must be ascending and within 0-24",
+			start, end)
+	}
+
+	return Build().In(location).Hours(Span(start, end-1)...).
+		Weekdays(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday).Timeframe()
+}
+
+// Weekdays returns a Timeframe able all day Monday to Friday, "* * * * 1-5 *".
+func Weekdays() Timeframe {
+	timeframe, _ := Build().Weekdays(time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday).Timeframe()
+	return timeframe
+}
+
+// Weekends returns a Timeframe able all day Saturday and Sunday, "* * * * 0,6 *".
+func Weekends() Timeframe {
+	timeframe, _ := Build().Weekdays(time.Saturday, time.Sunday).Timeframe()
+	return timeframe
+}
+
+// Nightly returns a Timeframe which occurs once a day at the start of the hour given, on a 24
+// hour clock. Ex. Nightly(2) is "0 2 * * * *".
+func Nightly(hour int) (Timeframe, error) {
+	return Build().Minutes(0).Hours(hour).Timeframe()
+}
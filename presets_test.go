@@ -0,0 +1,74 @@
+package avail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPresets(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	business, err := BusinessHours(9, 17, location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	allDay, err := BusinessHours(0, 24, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nightly, err := Nightly(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		timeframe Timeframe
+		want      string
+	}{
+		"business hours":         {business, "CRON_TZ=America/New_York * 9-16 * * 1-5 *"},
+		"all day business hours": {allDay, "* * * * 1-5 *"},
+		"weekdays":               {Weekdays(), "* * * * 1-5 *"},
+		"weekends":               {Weekends(), "* * * * 0,6 *"},
+		"nightly":                {nightly, "0 2 * * * *"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if tc.timeframe.Expression != tc.want {
+				t.Errorf("want %s, got %s", tc.want, tc.timeframe.Expression)
+			}
+
+			if !tc.timeframe.Equal(MustNew(tc.want)) {
+				t.Errorf("timeframe should equal one parsed from %s", tc.want)
+			}
+		})
+	}
+
+	if !business.Able(time.Date(2020, 6, 8, 16, 59, 0, 0, location)) || business.Able(time.Date(2020, 6, 8, 17, 0, 0, 0, location)) {
+		t.Errorf("business hours should end at 17:00")
+	}
+}
+
+func TestPresetsInvalid(t *testing.T) {
+	tests := map[string]struct {
+		build func() (Timeframe, error)
+	}{
+		"reversed business hours": {func() (Timeframe, error) { return BusinessHours(17, 9, nil) }},
+		"empty business hours":    {func() (Timeframe, error) { return BusinessHours(9, 9, nil) }},
+		"business hours past 24":  {func() (Timeframe, error) { return BusinessHours(9, 25, nil) }},
+		"negative business hours": {func() (Timeframe, error) { return BusinessHours(-1, 9, nil) }},
+		"nightly out of bounds":   {func() (Timeframe, error) { return Nightly(24) }},
+		"nightly negative":        {func() (Timeframe, error) { return Nightly(-1) }},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := tc.build(); err == nil {
+				t.Errorf("preset should not be built")
+			}
+		})
+	}
+}
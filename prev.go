@@ -0,0 +1,189 @@
+package avail
+
+import (
+	"errors"
+	"time"
+)
+
+// Prev returns the last time strictly before the time given at which the Timeframe is able,
+// at minute precision (or second precision for expressions with seconds). The result is in
+// the Timeframe's location if it has one and the location of the time given otherwise.
+//
+// Like Next, Prev steps back a field at a time. ErrNoOccurrence is returned if there is no
+// such time after the start of the expression's years, or of the period given with
+// WithActiveBetween.
+func (a *Timeframe) Prev(before time.Time) (time.Time, error) {
+	return a.prevFrom(before, before.Location())
+}
+
+// prevFrom returns the previous time the Timeframe, including its exclusions, is able.
+// Location is the location to evaluate times in if the Timeframe does not have one of its own.
+func (a *Timeframe) prevFrom(before time.Time, location *time.Location) (time.Time, error) {
+	if a.Location != nil {
+		location = a.Location
+	}
+
+	current := before
+	if !a.options.activeEnd.IsZero() && current.After(a.options.activeEnd) {
+		current = a.options.activeEnd
+	}
+	for {
+		prev, err := a.prevMatch(current, location)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !a.options.active(prev) {
+			return time.Time{}, ErrNoOccurrence
+		}
+
+		excluded := false
+		for i := range a.exclusions {
+			if a.exclusions[i].Able(prev) {
+				excluded = true
+				current = a.exclusions[i].skipExcludedBack(prev)
+				break
+			}
+		}
+		if !excluded {
+			return prev, nil
+		}
+	}
+}
+
+// skipExcludedBack returns a time, at or before the excluded time given, which is the
+// earliest time the exclusion may have started being able. Exclusions which cover whole days
+// are skipped a day at a time.
+func (a *Timeframe) skipExcludedBack(excluded time.Time) time.Time {
+	if !a.coversDays() {
+		return excluded
+	}
+
+	local := excluded
+	if a.Location != nil {
+		local = excluded.In(a.Location)
+	}
+
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	if midnight.After(excluded) {
+		return excluded
+	}
+	return midnight
+}
+
+// prevMatch returns the previous time the Timeframe's expression matches, without
+// considering exclusions.
+func (a *Timeframe) prevMatch(before time.Time, location *time.Location) (time.Time, error) {
+	if a.alternatives != nil {
+		var latest time.Time
+		for i := range a.alternatives {
+			prev, err := a.alternatives[i].prevFrom(before, location)
+			if errors.Is(err, ErrNoOccurrence) {
+				continue
+			}
+			if err != nil {
+				return time.Time{}, err
+			}
+			if latest.IsZero() || prev.After(latest) {
+				latest = prev
+			}
+		}
+		if latest.IsZero() {
+			return time.Time{}, ErrNoOccurrence
+		}
+		return latest, nil
+	}
+
+	if a.Interval != 0 {
+		elapsed := before.Sub(intervalEpoch)
+		intervals := elapsed / a.Interval
+		if elapsed%a.Interval <= 0 {
+			intervals--
+		}
+		return intervalEpoch.Add(intervals * a.Interval).In(location), nil
+	}
+
+	if a.options.dstPolicy != DSTWallClock {
+		return a.prevAcrossTransitions(before, location)
+	}
+	return a.prevWallClock(before, location)
+}
+
+// prevWallClock returns the previous time the plain expression matches by wall clock time.
+func (a *Timeframe) prevWallClock(before time.Time, location *time.Location) (time.Time, error) {
+	precision := a.options.precision()
+
+	// Start from the last time before the one given at the expression's precision.
+	t := before.In(location)
+	t = t.Add(-time.Duration(t.Nanosecond()))
+	if precision == time.Minute {
+		t = t.Add(-time.Duration(t.Second()) * time.Second)
+	}
+	if !t.Before(before) {
+		t = t.Add(-precision)
+	}
+
+	parsed := a.ParsedExpression
+
+	for {
+		if !parsed.Years.Has(t.Year()) {
+			year, ok := parsed.Years.valueBefore(t.Year())
+			if !ok {
+				return time.Time{}, ErrNoOccurrence
+			}
+			t = time.Date(year+1, time.January, 1, 0, 0, 0, 0, location).Add(-precision)
+			continue
+		}
+
+		if _, ok := parsed.Months.Values[int(t.Month())]; !ok {
+			t = retreat(t, time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, location).Add(-precision))
+			continue
+		}
+
+		if !a.matchesDay(t) {
+			t = retreat(t, time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, location).Add(-precision))
+			continue
+		}
+
+		// As in nextMatch, hours, minutes and seconds are stepped back by subtracting durations
+		// so that hours which are repeated when daylight saving time ends are visited.
+		if _, ok := parsed.Hours.Values[t.Hour()]; !ok {
+			t = t.Add(-time.Duration(t.Second())*time.Second - time.Duration(t.Minute())*time.Minute).Add(-precision)
+			continue
+		}
+
+		if _, ok := parsed.Minutes.Values[t.Minute()]; !ok {
+			t = t.Add(-time.Duration(t.Second()) * time.Second).Add(-precision)
+			continue
+		}
+
+		if a.options.seconds {
+			if _, ok := parsed.Seconds.Values[t.Second()]; !ok {
+				t = t.Add(-time.Second)
+				continue
+			}
+		}
+
+		return t, nil
+	}
+}
+
+// retreat returns prev, unless constructing it has not moved back from current (which can
+// happen around daylight saving time transitions), in which case it returns the previous
+// hour.
+func retreat(current, prev time.Time) time.Time {
+	if prev.Before(current) {
+		return prev
+	}
+	return current.Add(-time.Hour)
+}
+
+// prevValue returns the largest value in the set which is less than the value given and no
+// less than min.
+func prevValue(values map[int]struct{}, value, min int) (int, bool) {
+	for candidate := value - 1; candidate >= min; candidate-- {
+		if _, ok := values[candidate]; ok {
+			return candidate, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,187 @@
+package avail
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// bruteForcePrev returns the last time before the time given at which the Timeframe is able by
+// checking every instant at the given precision.
+func bruteForcePrev(a *Timeframe, before time.Time, precision time.Duration, horizon time.Duration) (time.Time, bool) {
+	t := before.Truncate(precision)
+	if !t.Before(before) {
+		t = t.Add(-precision)
+	}
+	for end := before.Add(-horizon); t.After(end); t = t.Add(-precision) {
+		if a.Able(t) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func TestPrev(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		before     time.Time
+		want       time.Time
+	}{
+		"previous minute":  {"* * * * * *", time.Date(2020, 6, 8, 9, 0, 30, 0, time.UTC), time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC)},
+		"strictly before":  {"0 9 * * * *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), time.Date(2020, 6, 7, 9, 0, 0, 0, time.UTC)},
+		"earlier today":    {"30 7 * * * *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), time.Date(2020, 6, 8, 7, 30, 0, 0, time.UTC)},
+		"previous weekday": {"0 9 * * 1-5 *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), time.Date(2020, 6, 5, 9, 0, 0, 0, time.UTC)},
+		"previous month":   {"0 0 1 * * *", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)},
+		"last minute":      {"59 23 * * * *", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 12, 31, 23, 59, 0, 0, time.UTC)},
+		"leap day":         {"0 0 29 2 * *", time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)},
+		"last day":         {"0 0 L * * *", time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC)},
+		"previous year":    {"0 0 1 1 * 2010", time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC), time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)},
+		"after last year":  {"0 0 1 1 * *", time.Date(2150, 6, 8, 0, 0, 0, 0, time.UTC), time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)},
+		"interval":         {"@every 90m", time.Date(1970, 1, 1, 2, 0, 0, 0, time.UTC), time.Date(1970, 1, 1, 1, 30, 0, 0, time.UTC)},
+		"interval on":      {"@every 90m", time.Date(1970, 1, 1, 1, 30, 0, 0, time.UTC), time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)},
+		"interval before epoch": {"@every 90m", time.Date(1969, 12, 31, 23, 0, 0, 0, time.UTC),
+			time.Date(1969, 12, 31, 22, 30, 0, 0, time.UTC)},
+		"combined": {"0 9 * * 1-5 * || 0 10 * * 0,6 *", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC),
+			time.Date(2020, 6, 7, 10, 0, 0, 0, time.UTC)},
+		"timezone": {"CRON_TZ=America/New_York 0 9 * * * *", time.Date(2020, 6, 8, 12, 0, 0, 0, time.UTC),
+			time.Date(2020, 6, 7, 13, 0, 0, 0, time.UTC)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := avail.Prev(tc.before)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !got.Equal(tc.want) {
+				t.Errorf("incorrect previous time; want %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPrevMatchesAble(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+		precision  time.Duration
+	}{
+		"business hours":  {"* 9-17 * * 1-5 *", nil, time.Minute},
+		"quarter hours":   {"0,15,30,45 * * * * *", nil, time.Minute},
+		"seconds":         {"15,45 30 * * * * *", []Option{WithSeconds()}, time.Second},
+		"last weekday":    {"0 0 LW * * *", nil, time.Minute},
+		"nth day of week": {"0 0 9 ? * 6#2", []Option{WithDialect(Quartz)}, time.Minute},
+		"nearest weekday": {"0 0 12 1W * ?", []Option{WithDialect(Quartz)}, time.Minute},
+		"new york":        {"CRON_TZ=America/New_York 30 1-3 * * * *", nil, time.Minute},
+	}
+
+	before := time.Date(2020, 11, 9, 22, 17, 0, 0, time.UTC)
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			current := before
+			for i := 0; i < 20; i++ {
+				got, err := avail.Prev(current)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				want, ok := bruteForcePrev(&avail, current, tc.precision, 62*24*time.Hour)
+				if !ok {
+					t.Fatal("brute force found no occurrence")
+				}
+
+				if !got.Equal(want) {
+					t.Fatalf("incorrect previous time before %s; want %s, got %s", current, want, got)
+				}
+				current = got
+			}
+		})
+	}
+}
+
+func TestPrevDaylightSavingTime(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	avail, err := New("CRON_TZ=America/New_York 30 1 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// On 2020-11-01 1:30 occurs twice, once in EDT and once in EST.
+	second, err := avail.Prev(time.Date(2020, 11, 1, 3, 0, 0, 0, location))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := avail.Prev(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if second.Sub(first) != time.Hour {
+		t.Errorf("repeated hour should be visited; got %s then %s", second, first)
+	}
+}
+
+func TestPrevExcept(t *testing.T) {
+	base, err := New("0 9 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	christmas, err := New("* * 25 12 * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	avail := base.Except(christmas)
+
+	got, err := avail.Prev(time.Date(2020, 12, 26, 8, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := time.Date(2020, 12, 24, 9, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("incorrect previous time; want %s, got %s", want, got)
+	}
+}
+
+func TestPrevNoOccurrence(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		before     time.Time
+	}{
+		"impossible date":  {"0 0 30 2 * *", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+		"before the years": {"0 0 1 1 * 2020", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+		"before the range": {"* * * * * *", time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := avail.Prev(tc.before); !errors.Is(err, ErrNoOccurrence) {
+				t.Errorf("want ErrNoOccurrence, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package avail
+
+import "time"
+
+// hoursPerWeek is the number of hours in a week.
+const hoursPerWeek = 7 * 24
+
+// intervalEpochHourOfWeek is the hour of the week, counted from midnight on Sunday, at which
+// intervals are anchored. The unix epoch fell on a Thursday.
+const intervalEpochHourOfWeek = 4 * 24
+
+// MinutesOfDay returns, in ascending order, the minutes since midnight (0-1439) at which the
+// Timeframe is able on at least one day. Ex. "0,30 9 * * 1-5 *" returns [540 570].
+//
+// Exclusions added with Except are not subtracted, since an exclusion rarely removes a minute
+// from every day. Interval expressions are projected onto UTC as they are anchored to the unix
+// epoch rather than to a wall clock.
+func (a *Timeframe) MinutesOfDay() []int {
+	minutes := map[int]struct{}{}
+	a.projectMinutesOfDay(minutes)
+	return sortedValues(minutes)
+}
+
+// HoursOfWeek returns, in ascending order, the hours since midnight on Sunday (0-167) in which
+// the Timeframe is able for at least one minute. Ex. "* 9 * * 1 *" returns [33].
+//
+// As with MinutesOfDay, exclusions are not subtracted and interval expressions are projected
+// onto UTC.
+func (a *Timeframe) HoursOfWeek() []int {
+	hours := map[int]struct{}{}
+	a.projectHoursOfWeek(hours)
+	return sortedValues(hours)
+}
+
+func (a *Timeframe) projectMinutesOfDay(minutes map[int]struct{}) {
+	if a.alternatives != nil {
+		for i := range a.alternatives {
+			a.alternatives[i].projectMinutesOfDay(minutes)
+		}
+		return
+	}
+
+	if a.Interval != 0 {
+		for _, offset := range intervalOffsets(a.Interval, 24*time.Hour) {
+			minutes[int(offset/time.Minute)] = struct{}{}
+		}
+		return
+	}
+
+	if len(a.matchingDates()) == 0 {
+		return
+	}
+
+	for hour := range a.ParsedExpression.Hours.Values {
+		for minute := range a.ParsedExpression.Minutes.Values {
+			minutes[hour*60+minute] = struct{}{}
+		}
+	}
+}
+
+func (a *Timeframe) projectHoursOfWeek(hours map[int]struct{}) {
+	if a.alternatives != nil {
+		for i := range a.alternatives {
+			a.alternatives[i].projectHoursOfWeek(hours)
+		}
+		return
+	}
+
+	if a.Interval != 0 {
+		for _, offset := range intervalOffsets(a.Interval, 7*24*time.Hour) {
+			hours[(intervalEpochHourOfWeek+int(offset/time.Hour))%hoursPerWeek] = struct{}{}
+		}
+		return
+	}
+
+	// The day of the month and weekday terms interact, so the weekdays which can occur are
+	// taken from the dates the expression actually matches.
+	weekdays := map[time.Weekday]struct{}{}
+	for _, date := range a.matchingDates() {
+		weekdays[date.Weekday()] = struct{}{}
+		if len(weekdays) == 7 {
+			break
+		}
+	}
+
+	for weekday := range weekdays {
+		for hour := range a.ParsedExpression.Hours.Values {
+			hours[int(weekday)*24+hour] = struct{}{}
+		}
+	}
+}
+
+// intervalOffsets returns the offsets into each period at which an
+// interval falls. Because the interval repeats indefinitely, these are the multiples of the
+// greatest common divisor of the interval and the period.
+func intervalOffsets(interval, period time.Duration) []time.Duration {
+	divisor := gcd(interval, period)
+
+	offsets := []time.Duration{}
+	for offset := time.Duration(0); offset < period; offset += divisor {
+		offsets = append(offsets, offset)
+	}
+	return offsets
+}
+
+// gcd returns the greatest common divisor of two positive durations.
+func gcd(a, b time.Duration) time.Duration {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,64 @@
+package avail
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMinutesOfDay(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		want       []int
+	}{
+		"single minute":  {"0 9 * * * *", []int{540}},
+		"many minutes":   {"0,30 9,17 * * 1-5 *", []int{540, 570, 1020, 1050}},
+		"combined":       {"0 9 * * 1-5 * || 15 0 * * 0,6 *", []int{15, 540}},
+		"interval":       {"@every 5h", []int{0, 60, 120, 180, 240, 300, 360, 420, 480, 540, 600, 660, 720, 780, 840, 900, 960, 1020, 1080, 1140, 1200, 1260, 1320, 1380}},
+		"daily interval": {"@every 8h", []int{0, 480, 960}},
+		"never":          {"0 9 30 2 * *", []int{}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diff := cmp.Diff(tc.want, avail.MinutesOfDay())
+			if diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHoursOfWeek(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		want       []int
+	}{
+		"monday morning": {"* 9 * * 1 *", []int{33}},
+		"weekends":       {"0 12 * * 0,6 *", []int{12, 156}},
+		"combined":       {"0 9 * * 1 * || 0 10 * * 2 *", []int{33, 58}},
+		"day of month":   {"0 0 13 * 5 *", []int{120}},
+		"interval":       {"@every 72h", []int{0, 24, 48, 72, 96, 120, 144}},
+		"weekly":         {"@every 168h", []int{96}},
+		"never":          {"0 9 30 2 * *", []int{}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diff := cmp.Diff(tc.want, avail.HoursOfWeek())
+			if diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package avail
+
+import (
+	"math/rand"
+	"time"
+)
+
+// RandomTime returns a time within [start, end) at which the Timeframe is able, chosen
+// uniformly from every minute (or every second, for expressions with seconds) at which it is,
+// using the source of randomness given. Ex. to spread load or inject faults at random times
+// within an eligible schedule. The second return value is false if the Timeframe is never able
+// within the range.
+//
+// The time is found by bisecting the range with Count, so plain expressions, which Count
+// measures a day at a time, are cheap to sample from long ranges.
+func (a *Timeframe) RandomTime(start, end time.Time, rng *rand.Rand) (time.Time, bool) {
+	total := a.Count(start, end)
+	if total == 0 {
+		return time.Time{}, false
+	}
+	target := int(rng.Int63n(int64(total)))
+
+	location := start.Location()
+	if a.Location != nil {
+		location = a.Location
+	}
+	precision := a.options.precision()
+
+	// The occurrence sought is the first whose end has the target number of occurrences before
+	// it, searched for among the whole multiples of the precision within the range.
+	first := start.Truncate(precision)
+	if first.Before(start) {
+		first = first.Add(precision)
+	}
+	low, high := int64(0), int64(end.Sub(first)/precision)
+	for low < high {
+		middle := low + (high-low)/2
+		if a.Count(start, first.Add(time.Duration(middle+1)*precision)) > target {
+			high = middle
+		} else {
+			low = middle + 1
+		}
+	}
+
+	return first.Add(time.Duration(low) * precision).In(location), true
+}
@@ -0,0 +1,70 @@
+package avail
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestRandomTime(t *testing.T) {
+	start := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	tests := map[string]Timeframe{
+		"business hours": MustNew("* 9-17 * * 1-5 *"),
+		"seconds":        MustNew("0,30 0 12 * * * *", WithSeconds()),
+		"exclusion":      MustNew("* 9-17 * * 1-5 *").Except(MustNew("* 12 * * * *")),
+		"alternatives":   MustNew("0 9 * * 1 * || 30 18 * * 5 *"),
+		"location":       MustNew("CRON_TZ=America/New_York 0 9 * * * *"),
+		"interval":       MustNew("@every 7h"),
+	}
+
+	for name, timeframe := range tests {
+		t.Run(name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(1))
+			for i := 0; i < 20; i++ {
+				sample, ok := timeframe.RandomTime(start, end, rng)
+				if !ok {
+					t.Fatalf("want %t, got %t", true, ok)
+				}
+				if sample.Before(start) || !sample.Before(end) {
+					t.Fatalf("%s is outside of the range", sample)
+				}
+				if !timeframe.Able(sample) {
+					t.Fatalf("%s is not able", sample)
+				}
+			}
+		})
+	}
+}
+
+func TestRandomTimeUniform(t *testing.T) {
+	start := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	timeframe := MustNew("0 9,21 * * * *")
+
+	rng := rand.New(rand.NewSource(1))
+	counts := map[time.Time]int{}
+	for i := 0; i < 2800; i++ {
+		sample, _ := timeframe.RandomTime(start, end, rng)
+		counts[sample]++
+	}
+
+	if len(counts) != 14 {
+		t.Fatalf("want %d distinct times, got %d", 14, len(counts))
+	}
+	for sample, count := range counts {
+		if count < 140 || count > 260 {
+			t.Errorf("%s was chosen %d times out of 2800; want around 200", sample, count)
+		}
+	}
+}
+
+func TestRandomTimeNever(t *testing.T) {
+	start := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	timeframe := MustNew("0 9 * * 6 *")
+
+	if _, ok := timeframe.RandomTime(start, start.AddDate(0, 0, 5), rand.New(rand.NewSource(1))); ok {
+		t.Errorf("want %t, got %t", false, ok)
+	}
+}
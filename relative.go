@@ -0,0 +1,124 @@
+package avail
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// relativeValue is a value which can only be resolved once the month being evaluated is known.
+// Ex. "L-3" (three days before the last day of the month) falls on a different day of the
+// month depending on the month's length.
+type relativeValue struct {
+	kind termKind
+	// offset is the number of days before the last day of the month for terms of kind last.
+	offset int
+	// day is the day of the month for terms of kind nearestWeekday.
+	day int
+	// weekday is the day of the week for terms of kind nthDayOfWeek and lastDayOfWeek.
+	weekday time.Weekday
+	// nth is the occurrence of the weekday within the month for terms of kind nthDayOfWeek.
+	nth int
+}
+
+// The following kinds of relative values have no native syntax and are only produced when
+// parsing a dialect such as Quartz.
+const (
+	// nearestWeekday is the weekday (Mon-Fri) nearest to a day of the month. ex. 15W
+	nearestWeekday termKind = "nearestWeekday"
+	// nthDayOfWeek is the nth occurrence of a day of the week in the month. ex. 6#3
+	nthDayOfWeek termKind = "nthDayOfWeek"
+	// lastDayOfWeek is the last occurrence of a day of the week in the month. ex. 6L
+	lastDayOfWeek termKind = "lastDayOfWeek"
+)
+
+// matches reports whether the relative value resolves to the day of the time given.
+func (r relativeValue) matches(t time.Time) bool {
+	switch r.kind {
+	case last:
+		return t.Day() == daysIn(t.Month(), t.Year())-r.offset
+	case lastWeekday:
+		return t.Day() == lastWeekdayOf(t.Month(), t.Year())
+	case nearestWeekday:
+		day, ok := nearestWeekdayTo(r.day, t.Month(), t.Year())
+		return ok && t.Day() == day
+	case nthDayOfWeek:
+		return t.Weekday() == r.weekday && (t.Day()-1)/7+1 == r.nth
+	case lastDayOfWeek:
+		return t.Weekday() == r.weekday && t.Day()+7 > daysIn(t.Month(), t.Year())
+	}
+
+	return false
+}
+
+// parseLastField parses terms of the form "L" (the last day of the month) and "L-<offset>"
+// (offset days before the last day of the month).
+func (f *Field) parseLastField(term string) ([]relativeValue, error) {
+	if f.Kind != day {
+		return nil, reasonf(ErrInvalidTerm, "term %s is only allowed in the day field", term)
+	}
+
+	offset := 0
+	if rawOffset := strings.TrimPrefix(strings.ToUpper(term), "L"); rawOffset != "" {
+		value, err := strconv.Atoi(strings.TrimPrefix(rawOffset, "-"))
+		if err != nil {
+			return nil, reasonf(ErrInvalidTerm, "could not parse offset %s: %v", rawOffset, err)
+		}
+		offset = value
+	}
+
+	// The shortest month has 28 days, so any larger offset would be before the first of the month.
+	if offset > 27 {
+		return nil, reasonf(ErrOutOfBounds, "offset(%d) cannot be more than max(27)", offset)
+	}
+
+	return []relativeValue{{kind: last, offset: offset}}, nil
+}
+
+// parseLastWeekdayField parses the "LW" term (the last weekday of the month).
+func (f *Field) parseLastWeekdayField(term string) ([]relativeValue, error) {
+	if f.Kind != day {
+		return nil, reasonf(ErrInvalidTerm, "term %s is only allowed in the day field", term)
+	}
+
+	return []relativeValue{{kind: lastWeekday}}, nil
+}
+
+// lastWeekdayOf returns the day of the month of the last Monday through Friday in the month.
+func lastWeekdayOf(month time.Month, year int) int {
+	last := time.Date(year, month, daysIn(month, year), 0, 0, 0, 0, time.UTC)
+	for last.Weekday() == time.Saturday || last.Weekday() == time.Sunday {
+		last = last.AddDate(0, 0, -1)
+	}
+	return last.Day()
+}
+
+// nearestWeekdayTo returns the day of the month of the Monday through Friday nearest to the
+// given day without leaving the month. Ex. if the 1st is a Saturday the nearest weekday is
+// Monday the 3rd. It returns false if the month does not have the given day.
+func nearestWeekdayTo(day int, month time.Month, year int) (int, bool) {
+	days := daysIn(month, year)
+	if day > days {
+		return 0, false
+	}
+
+	switch time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2, true
+		}
+		return day - 1, true
+	case time.Sunday:
+		if day == days {
+			return day - 2, true
+		}
+		return day + 1, true
+	}
+
+	return day, true
+}
+
+// daysIn returns the number of days in the month of the given year.
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,98 @@
+// Package rotation computes on-call rotations from avail Timeframes: who is on call at any
+// time and when one participant hands off to the next.
+//
+// A rotation is a list of participants taking turns, starting with the first at its epoch and
+// handing off to the next at each occurrence of its schedule. Ex. "0 9 * * 1 *" hands off
+// every Monday at 9:00, giving each participant a week on call in turn. Handoffs are counted
+// with the Timeframe's Count, so finding who is on call years after the epoch is cheap.
+package rotation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/clintjedwards/avail/v2"
+)
+
+// Rotation is a list of participants taking turns on call.
+type Rotation struct {
+	// Schedule is the Timeframe whose occurrences, as returned by its Next, are the handoffs.
+	Schedule avail.Timeframe
+	// Participants are on call in order, starting again from the first after the last.
+	Participants []string
+	// Epoch is the time the first participant goes on call. Occurrences of the schedule at or
+	// before it are not handoffs.
+	Epoch time.Time
+}
+
+// Handoff is a time at which one participant hands off to the next.
+type Handoff struct {
+	Time     time.Time
+	From, To string
+}
+
+// New returns a Rotation of the participants, handing off at each occurrence of the schedule
+// after the epoch. At least one participant must be given.
+func New(schedule avail.Timeframe, participants []string, epoch time.Time) (*Rotation, error) {
+	if len(participants) == 0 {
+		return nil, fmt.Errorf("rotation must have at least one participant")
+	}
+
+	return &Rotation{Schedule: schedule, Participants: participants, Epoch: epoch}, nil
+}
+
+// OnCall returns the participant on call at the time given. It returns false before the
+// epoch.
+func (r *Rotation) OnCall(t time.Time) (string, bool) {
+	if t.Before(r.Epoch) {
+		return "", false
+	}
+	return r.participant(r.handoffsBefore(t.Add(time.Nanosecond))), true
+}
+
+// NextHandoff returns the first handoff strictly after the time given. It returns false if
+// the schedule never occurs again.
+func (r *Rotation) NextHandoff(after time.Time) (Handoff, bool) {
+	handoffs := r.Handoffs(after, time.Time{}, 1)
+	if len(handoffs) == 0 {
+		return Handoff{}, false
+	}
+	return handoffs[0], true
+}
+
+// Handoffs returns, in order, the handoffs strictly after the start and before the end, up to
+// limit of them. A zero end leaves the range unbounded, and a limit of zero or less does not
+// limit the number of handoffs returned.
+func (r *Rotation) Handoffs(start, end time.Time, limit int) []Handoff {
+	handoffs := []Handoff{}
+	if start.Before(r.Epoch) {
+		start = r.Epoch
+	}
+
+	previous := r.handoffsBefore(start.Add(time.Nanosecond))
+	for current := start; limit <= 0 || len(handoffs) < limit; {
+		next, err := r.Schedule.Next(current)
+		if err != nil || (!end.IsZero() && !next.Before(end)) {
+			break
+		}
+
+		handoffs = append(handoffs, Handoff{Time: next, From: r.participant(previous), To: r.participant(previous + 1)})
+		previous++
+		current = next
+	}
+
+	return handoffs
+}
+
+// handoffsBefore returns the number of handoffs after the epoch and before the time given.
+func (r *Rotation) handoffsBefore(t time.Time) int {
+	if !t.After(r.Epoch) {
+		return 0
+	}
+	return r.Schedule.Count(r.Epoch.Add(time.Nanosecond), t)
+}
+
+// participant returns the participant on call after the given number of handoffs.
+func (r *Rotation) participant(handoffs int) string {
+	return r.Participants[handoffs%len(r.Participants)]
+}
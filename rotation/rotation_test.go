@@ -0,0 +1,115 @@
+package rotation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/clintjedwards/avail/v2"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOnCall(t *testing.T) {
+	epoch := time.Date(2020, 6, 1, 9, 0, 0, 0, time.UTC)
+	rotation, err := New(avail.MustNew("0 9 * * 1 *"), []string{"alice", "bob", "carol"}, epoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		time time.Time
+		want string
+		ok   bool
+	}{
+		"before epoch":      {epoch.Add(-time.Second), "", false},
+		"at epoch":          {epoch, "alice", true},
+		"first week":        {time.Date(2020, 6, 5, 12, 0, 0, 0, time.UTC), "alice", true},
+		"before handoff":    {time.Date(2020, 6, 8, 8, 59, 59, 0, time.UTC), "alice", true},
+		"at handoff":        {time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), "bob", true},
+		"during handoff":    {time.Date(2020, 6, 8, 9, 0, 30, 0, time.UTC), "bob", true},
+		"third week":        {time.Date(2020, 6, 16, 0, 0, 0, 0, time.UTC), "carol", true},
+		"wraps around":      {time.Date(2020, 6, 23, 0, 0, 0, 0, time.UTC), "alice", true},
+		"years later":       {time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC), brute(rotation, time.Date(2025, 6, 3, 0, 0, 0, 0, time.UTC)), true},
+		"mid week years on": {time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), brute(rotation, time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)), true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, ok := rotation.OnCall(tc.time)
+			if ok != tc.ok || got != tc.want {
+				t.Errorf("want %s %t, got %s %t", tc.want, tc.ok, got, ok)
+			}
+		})
+	}
+}
+
+// brute returns who is on call at the time by stepping through every handoff since the epoch.
+func brute(rotation *Rotation, t time.Time) string {
+	count := 0
+	for current := rotation.Epoch; ; count++ {
+		next, err := rotation.Schedule.Next(current)
+		if err != nil || next.After(t) {
+			break
+		}
+		current = next
+	}
+	return rotation.Participants[count%len(rotation.Participants)]
+}
+
+func TestOnCallEpochWithinOccurrence(t *testing.T) {
+	epoch := time.Date(2020, 6, 1, 9, 0, 30, 0, time.UTC)
+	rotation, err := New(avail.MustNew("0 9 * * * *"), []string{"alice", "bob"}, epoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, _ := rotation.OnCall(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)); got != "alice" {
+		t.Errorf("want %s, got %s", "alice", got)
+	}
+	if got, _ := rotation.OnCall(time.Date(2020, 6, 2, 9, 0, 0, 0, time.UTC)); got != "bob" {
+		t.Errorf("want %s, got %s", "bob", got)
+	}
+}
+
+func TestHandoffs(t *testing.T) {
+	epoch := time.Date(2020, 6, 1, 9, 0, 0, 0, time.UTC)
+	rotation, err := New(avail.MustNew("0 9 * * 1 *"), []string{"alice", "bob", "carol"}, epoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Handoff{
+		{Time: time.Date(2020, 6, 15, 9, 0, 0, 0, time.UTC), From: "bob", To: "carol"},
+		{Time: time.Date(2020, 6, 22, 9, 0, 0, 0, time.UTC), From: "carol", To: "alice"},
+		{Time: time.Date(2020, 6, 29, 9, 0, 0, 0, time.UTC), From: "alice", To: "bob"},
+	}
+	got := rotation.Handoffs(time.Date(2020, 6, 10, 0, 0, 0, 0, time.UTC), time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), 0)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+
+	if got := rotation.Handoffs(time.Date(2020, 6, 10, 0, 0, 0, 0, time.UTC), time.Time{}, 2); cmp.Diff(want[:2], got) != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", cmp.Diff(want[:2], got))
+	}
+
+	next, ok := rotation.NextHandoff(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	if !ok {
+		t.Fatal("want a handoff")
+	}
+	if diff := cmp.Diff(Handoff{Time: time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), From: "alice", To: "bob"}, next); diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+
+	ending, err := New(avail.MustNew("0 9 1 6 * 2020"), []string{"alice", "bob"}, epoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ending.NextHandoff(epoch); ok {
+		t.Errorf("want %t, got %t", false, true)
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	if _, err := New(avail.MustNew("0 9 * * 1 *"), nil, time.Now()); err == nil {
+		t.Errorf("rotations without participants should not be created")
+	}
+}
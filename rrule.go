@@ -0,0 +1,408 @@
+package avail
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rruleWeekdays maps the RFC 5545 weekday names to their time.Weekday.
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// rruleFrequencies lists the RFC 5545 frequencies from finest to coarsest along with the field
+// each one advances and the fields it takes from DTSTART when they are not given. Ex.
+// FREQ=DAILY takes the hour and minute from DTSTART.
+var rruleFrequencies = []struct {
+	name     string
+	required []string
+}{
+	{"SECONDLY", nil},
+	{"MINUTELY", nil},
+	{"HOURLY", []string{"BYMINUTE"}},
+	{"DAILY", []string{"BYHOUR", "BYMINUTE"}},
+	{"WEEKLY", []string{"BYDAY", "BYHOUR", "BYMINUTE"}},
+	{"MONTHLY", []string{"BYMONTHDAY|BYDAY", "BYHOUR", "BYMINUTE"}},
+	{"YEARLY", []string{"BYMONTH", "BYMONTHDAY|BYDAY", "BYHOUR", "BYMINUTE"}},
+}
+
+// ToRRULE returns an RFC 5545 recurrence rule which recurs at the times the Timeframe is able.
+// Ex. "0 9 * * 1-5 *" becomes "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0".
+//
+// Recurrence rules do not carry a timezone, so the Timeframe's location must be given as the
+// TZID of the rule's DTSTART. Intervals recur relative to DTSTART rather than the unix epoch,
+// so DTSTART should be an occurrence of the interval. An error is returned for years, which
+// rules cannot restrict, "LW" and nearest weekday terms, exclusions and combined expressions.
+func (a *Timeframe) ToRRULE() (string, error) {
+	if len(a.exclusions) > 0 || a.alternatives != nil {
+		return "", fmt.Errorf("could not convert %s: exclusions and combined expressions cannot be converted", a.Expression)
+	}
+
+	if a.Interval != 0 {
+		if a.Interval%time.Minute == 0 {
+			return fmt.Sprintf("FREQ=MINUTELY;INTERVAL=%d", a.Interval/time.Minute), nil
+		}
+		return fmt.Sprintf("FREQ=SECONDLY;INTERVAL=%d", a.Interval/time.Second), nil
+	}
+
+	parsed := a.ParsedExpression
+	if !parsed.Years.isWildcard() {
+		return "", fmt.Errorf("could not convert %s: years cannot be restricted", a.Expression)
+	}
+	if a.eitherDay() {
+		return "", fmt.Errorf("could not convert %s: dates matching either the day or weekday term cannot be converted", a.Expression)
+	}
+	if a.options.bounded() {
+		return "", fmt.Errorf("could not convert %s: active periods cannot be converted", a.Expression)
+	}
+	if a.options.holidays != nil {
+		return "", fmt.Errorf("could not convert %s: holidays cannot be converted", a.Expression)
+	}
+
+	monthDays := []string{}
+	for _, value := range sortedValues(parsed.Days.Values) {
+		monthDays = append(monthDays, strconv.Itoa(value))
+	}
+
+	days := []string{}
+	for _, value := range sortedValues(parsed.Weekdays.Values) {
+		days = append(days, rruleWeekdayName(time.Weekday(value)))
+	}
+
+	ordinal := false
+	for _, relative := range append(append([]relativeValue{}, parsed.Days.relative...), parsed.Weekdays.relative...) {
+		switch relative.kind {
+		case last:
+			monthDays = append(monthDays, strconv.Itoa(-relative.offset-1))
+		case nthDayOfWeek:
+			days = append(days, strconv.Itoa(relative.nth)+rruleWeekdayName(relative.weekday))
+			ordinal = true
+		case lastDayOfWeek:
+			days = append(days, "-1"+rruleWeekdayName(relative.weekday))
+			ordinal = true
+		default:
+			return "", fmt.Errorf("could not convert %s: term kind %s cannot be converted", a.Expression, relative.kind)
+		}
+	}
+
+	daysRestricted := !parsed.Days.isWildcard()
+	weekdaysRestricted := !parsed.Weekdays.isWildcard()
+	monthsRestricted := !parsed.Months.isWildcard()
+
+	// The finest wildcard field determines the frequency; every field finer than the
+	// frequency must then be listed explicitly.
+	var frequency string
+	switch {
+	case ordinal:
+		frequency = "MONTHLY"
+	case a.options.seconds && parsed.Seconds.isWildcard():
+		frequency = "SECONDLY"
+	case parsed.Minutes.isWildcard():
+		frequency = "MINUTELY"
+	case parsed.Hours.isWildcard():
+		frequency = "HOURLY"
+	case weekdaysRestricted && !daysRestricted && !monthsRestricted:
+		frequency = "WEEKLY"
+	case daysRestricted && !weekdaysRestricted && !monthsRestricted:
+		frequency = "MONTHLY"
+	case daysRestricted && !weekdaysRestricted && monthsRestricted:
+		frequency = "YEARLY"
+	default:
+		frequency = "DAILY"
+	}
+
+	parts := []string{"FREQ=" + frequency}
+	if monthsRestricted {
+		parts = append(parts, "BYMONTH="+joinValues(parsed.Months.Values))
+	}
+	if daysRestricted {
+		parts = append(parts, "BYMONTHDAY="+strings.Join(monthDays, ","))
+	}
+	if weekdaysRestricted {
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	fine := frequency == "DAILY" || frequency == "WEEKLY" || frequency == "MONTHLY" || frequency == "YEARLY"
+	if fine || !parsed.Hours.isWildcard() {
+		parts = append(parts, "BYHOUR="+joinValues(parsed.Hours.Values))
+	}
+	if fine || frequency == "HOURLY" || !parsed.Minutes.isWildcard() {
+		parts = append(parts, "BYMINUTE="+joinValues(parsed.Minutes.Values))
+	}
+	if a.options.seconds && frequency != "SECONDLY" {
+		parts = append(parts, "BYSECOND="+joinValues(parsed.Seconds.Values))
+	}
+
+	return strings.Join(parts, ";"), nil
+}
+
+// FromRRULE returns a Timeframe which is able at the times the RFC 5545 recurrence rule
+// recurs. Ex. "FREQ=MONTHLY;BYDAY=-1FR;BYHOUR=17;BYMINUTE=0" is able at 17:00 on the last
+// Friday of every month. A leading "RRULE:" is ignored.
+//
+// Since the Timeframe has no DTSTART, every field a rule would take from DTSTART must be
+// given. Ex. FREQ=DAILY requires BYHOUR and BYMINUTE. Rules with an INTERVAL may not contain
+// any BY rules and recur relative to the unix epoch rather than DTSTART. COUNT, UNTIL,
+// BYSETPOS, BYWEEKNO and BYYEARDAY cannot be converted.
+func FromRRULE(rule string) (Timeframe, error) {
+	timeframe, err := parseRRULE(rule)
+	if err != nil {
+		return Timeframe{}, fmt.Errorf("could not parse recurrence rule: %s; %w", rule, err)
+	}
+	return timeframe, nil
+}
+
+// parseRRULE parses the recurrence rule into a Timeframe.
+func parseRRULE(rule string) (Timeframe, error) {
+	trimmed := strings.TrimSpace(rule)
+	if len(trimmed) >= len("RRULE:") && strings.EqualFold(trimmed[:len("RRULE:")], "RRULE:") {
+		trimmed = trimmed[len("RRULE:"):]
+	}
+
+	parts := map[string]string{}
+	for _, part := range strings.Split(trimmed, ";") {
+		pair := strings.SplitN(part, "=", 2)
+		if len(pair) != 2 || pair[1] == "" {
+			return Timeframe{}, fmt.Errorf("could not parse part %q: must be in the form NAME=VALUE", part)
+		}
+
+		name := strings.ToUpper(pair[0])
+		if _, ok := parts[name]; ok {
+			return Timeframe{}, fmt.Errorf("%s is given more than once", name)
+		}
+		parts[name] = strings.ToUpper(pair[1])
+	}
+
+	for _, name := range []string{"COUNT", "UNTIL", "BYSETPOS", "BYWEEKNO", "BYYEARDAY"} {
+		if _, ok := parts[name]; ok {
+			return Timeframe{}, fmt.Errorf("%s cannot be converted", name)
+		}
+	}
+
+	frequency, ok := parts["FREQ"]
+	if !ok {
+		return Timeframe{}, fmt.Errorf("FREQ is required")
+	}
+
+	frequencyIndex := -1
+	for i, candidate := range rruleFrequencies {
+		if candidate.name == frequency {
+			frequencyIndex = i
+		}
+	}
+	if frequencyIndex == -1 {
+		return Timeframe{}, fmt.Errorf("unknown FREQ %s", frequency)
+	}
+
+	if rawInterval, ok := parts["INTERVAL"]; ok && rawInterval != "1" {
+		return parseRRULEInterval(parts, frequency, rawInterval)
+	}
+
+	for _, required := range rruleFrequencies[frequencyIndex].required {
+		found := false
+		for _, name := range strings.Split(required, "|") {
+			if _, ok := parts[name]; ok {
+				found = true
+			}
+		}
+		if !found {
+			return Timeframe{}, fmt.Errorf("FREQ=%s requires %s since it would otherwise be taken from DTSTART",
+				frequency, strings.Replace(required, "|", " or ", -1))
+		}
+	}
+
+	options := options{}
+	if _, ok := parts["BYSECOND"]; ok {
+		options.seconds = true
+	}
+
+	parsed := ParsedExpression{}
+	fields := []struct {
+		part     string
+		kind     FieldType
+		min, max int
+		field    *Field
+	}{
+		{"BYSECOND", second, 0, 59, &parsed.Seconds},
+		{"BYMINUTE", minute, 0, 59, &parsed.Minutes},
+		{"BYHOUR", hour, 0, 23, &parsed.Hours},
+		{"BYMONTHDAY", day, 1, 31, &parsed.Days},
+		{"BYMONTH", month, 1, 12, &parsed.Months},
+		{"BYDAY", weekday, 0, 6, &parsed.Weekdays},
+		{"", year, 1970, 2100, &parsed.Years},
+	}
+
+	for _, field := range fields {
+		*field.field = Field{Kind: field.kind, Term: "*", Min: field.min, Max: field.max}
+		if field.kind == second && !options.seconds {
+			continue
+		}
+
+		value, ok := parts[field.part]
+		if !ok {
+			field.field.setWildcard()
+			continue
+		}
+
+		field.field.Term = value
+		var err error
+		switch field.kind {
+		case day:
+			err = parseRRULEMonthDays(field.field, value)
+		case weekday:
+			err = parseRRULEDays(field.field, value, frequency, parts)
+		default:
+			field.field.Values, err = parseRRULEValues(value, field.min, field.max)
+		}
+		if err != nil {
+			return Timeframe{}, fmt.Errorf("could not parse %s: %w", field.part, err)
+		}
+	}
+
+	return Timeframe{
+		Expression:       rule,
+		ParsedExpression: parsed,
+		options:          options,
+	}, nil
+}
+
+// parseRRULEInterval parses a rule with an INTERVAL into an interval Timeframe.
+func parseRRULEInterval(parts map[string]string, frequency, rawInterval string) (Timeframe, error) {
+	for name := range parts {
+		if strings.HasPrefix(name, "BY") {
+			return Timeframe{}, fmt.Errorf("INTERVAL cannot be combined with %s", name)
+		}
+	}
+
+	count, err := strconv.Atoi(rawInterval)
+	if err != nil || count < 1 {
+		return Timeframe{}, fmt.Errorf("INTERVAL must be a positive number; got %s", rawInterval)
+	}
+
+	units := map[string]time.Duration{
+		"SECONDLY": time.Second,
+		"MINUTELY": time.Minute,
+		"HOURLY":   time.Hour,
+		"DAILY":    24 * time.Hour,
+		"WEEKLY":   7 * 24 * time.Hour,
+	}
+
+	unit, ok := units[frequency]
+	if !ok {
+		return Timeframe{}, fmt.Errorf("INTERVAL cannot be converted for FREQ=%s", frequency)
+	}
+
+	options := options{}
+	interval := time.Duration(count) * unit
+	if interval%time.Minute != 0 {
+		options.seconds = true
+	}
+
+	return Timeframe{
+		Expression: strings.Join([]string{"FREQ=" + frequency, "INTERVAL=" + rawInterval}, ";"),
+		Interval:   interval,
+		options:    options,
+	}, nil
+}
+
+// parseRRULEValues parses a comma separated list of values within the bounds.
+func parseRRULEValues(value string, min, max int) (map[int]struct{}, error) {
+	values := map[int]struct{}{}
+	for _, rawValue := range strings.Split(value, ",") {
+		parsed, err := strconv.Atoi(rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse value %s: %v", rawValue, err)
+		}
+		if parsed < min || parsed > max {
+			return nil, fmt.Errorf("value(%d) must be within %d-%d", parsed, min, max)
+		}
+		values[parsed] = struct{}{}
+	}
+	return values, nil
+}
+
+// parseRRULEMonthDays parses BYMONTHDAY, where negative values count back from the last day of
+// the month.
+func parseRRULEMonthDays(f *Field, value string) error {
+	f.Values = map[int]struct{}{}
+	for _, rawValue := range strings.Split(value, ",") {
+		parsed, err := strconv.Atoi(rawValue)
+		if err != nil {
+			return fmt.Errorf("could not parse value %s: %v", rawValue, err)
+		}
+
+		switch {
+		case parsed >= 1 && parsed <= 31:
+			f.Values[parsed] = struct{}{}
+		case parsed <= -1 && parsed >= -28:
+			f.relative = append(f.relative, relativeValue{kind: last, offset: -parsed - 1})
+		default:
+			return fmt.Errorf("value(%d) must be within 1-31 or -28 to -1", parsed)
+		}
+	}
+	return nil
+}
+
+// parseRRULEDays parses BYDAY, where days may be prefixed by their occurrence within the
+// month. Ex. "3FR" is the third Friday and "-1FR" the last.
+func parseRRULEDays(f *Field, value, frequency string, parts map[string]string) error {
+	f.Values = map[int]struct{}{}
+	for _, rawValue := range strings.Split(value, ",") {
+		if len(rawValue) < 2 {
+			return fmt.Errorf("could not parse day %s", rawValue)
+		}
+
+		weekday, ok := rruleWeekdays[rawValue[len(rawValue)-2:]]
+		if !ok {
+			return fmt.Errorf("could not parse day %s", rawValue)
+		}
+
+		rawOrdinal := rawValue[:len(rawValue)-2]
+		if rawOrdinal == "" {
+			f.Values[int(weekday)] = struct{}{}
+			continue
+		}
+
+		_, hasMonth := parts["BYMONTH"]
+		if frequency != "MONTHLY" && !(frequency == "YEARLY" && hasMonth) {
+			return fmt.Errorf("day %s with an occurrence is only supported within a month", rawValue)
+		}
+
+		ordinal, err := strconv.Atoi(rawOrdinal)
+		if err != nil {
+			return fmt.Errorf("could not parse occurrence %s: %v", rawOrdinal, err)
+		}
+
+		switch {
+		case ordinal == -1:
+			f.relative = append(f.relative, relativeValue{kind: lastDayOfWeek, weekday: weekday})
+		case ordinal >= 1 && ordinal <= 5:
+			f.relative = append(f.relative, relativeValue{kind: nthDayOfWeek, weekday: weekday, nth: ordinal})
+		default:
+			return fmt.Errorf("occurrence(%d) must be within 1-5 or -1", ordinal)
+		}
+	}
+	return nil
+}
+
+// rruleWeekdayName returns the RFC 5545 name of the weekday.
+func rruleWeekdayName(weekday time.Weekday) string {
+	for name, candidate := range rruleWeekdays {
+		if candidate == weekday {
+			return name
+		}
+	}
+	return ""
+}
+
+// joinValues returns the set's values as a sorted comma separated list.
+func joinValues(values map[int]struct{}) string {
+	rendered := []string{}
+	for _, value := range sortedValues(values) {
+		rendered = append(rendered, strconv.Itoa(value))
+	}
+	return strings.Join(rendered, ",")
+}
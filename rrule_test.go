@@ -0,0 +1,135 @@
+package avail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestToRRULE(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		dialect    Dialect
+		want       string
+	}{
+		"weekdays":        {"0 9 * * 1-5 *", Native, "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0"},
+		"every minute":    {"* * * * * *", Native, "FREQ=MINUTELY"},
+		"business hours":  {"* 9-11 * * * *", Native, "FREQ=MINUTELY;BYHOUR=9,10,11"},
+		"hourly":          {"@hourly", Native, "FREQ=HOURLY;BYMINUTE=0"},
+		"daily":           {"30 12 * * * *", Native, "FREQ=DAILY;BYHOUR=12;BYMINUTE=30"},
+		"monthly":         {"0 0 1,15 * * *", Native, "FREQ=MONTHLY;BYMONTHDAY=1,15;BYHOUR=0;BYMINUTE=0"},
+		"yearly":          {"0 0 25 12 * *", Native, "FREQ=YEARLY;BYMONTH=12;BYMONTHDAY=25;BYHOUR=0;BYMINUTE=0"},
+		"last day":        {"0 0 L-1 * * *", Native, "FREQ=MONTHLY;BYMONTHDAY=-2;BYHOUR=0;BYMINUTE=0"},
+		"friday the 13th": {"0 0 13 * 5 *", Native, "FREQ=DAILY;BYMONTHDAY=13;BYDAY=FR;BYHOUR=0;BYMINUTE=0"},
+		"interval":        {"@every 90m", Native, "FREQ=MINUTELY;INTERVAL=90"},
+		"last friday":     {"0 0 17 ? * 6L", Quartz, "FREQ=MONTHLY;BYDAY=-1FR;BYHOUR=17;BYMINUTE=0;BYSECOND=0"},
+		"third monday":    {"0 0 9 ? * 2#3", Quartz, "FREQ=MONTHLY;BYDAY=3MO;BYHOUR=9;BYMINUTE=0;BYSECOND=0"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression, WithDialect(tc.dialect))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := avail.ToRRULE()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tc.want {
+				t.Errorf("incorrect rule; want %q, got %q", tc.want, got)
+			}
+
+			// The rule must convert back to a Timeframe which matches the same times.
+			converted, err := FromRRULE(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+			comparison := converted.CompareAgainst(avail.Able, from, from.AddDate(0, 2, 0))
+			if !comparison.Matches() {
+				t.Errorf("converted rule does not match the expression; first divergence %v", comparison.Divergences[0])
+			}
+		})
+	}
+
+	either := MustNew("0 0 13 * 5 *", WithDomDowOr())
+	if _, err := either.ToRRULE(); err == nil {
+		t.Errorf("expected error converting dates matching either day term")
+	}
+
+	bounded := MustNew("0 9 * * 5 *", WithActiveBetween(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Time{}))
+	if _, err := bounded.ToRRULE(); err == nil {
+		t.Errorf("expected error converting an active period")
+	}
+
+	for _, expression := range []string{"0 0 * * * 2020", "0 0 LW * * *", "0 0 * * * * || 0 12 * * * *"} {
+		avail, err := New(expression)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := avail.ToRRULE(); err == nil {
+			t.Errorf("expected error converting %q", expression)
+		}
+	}
+}
+
+func TestFromRRULE(t *testing.T) {
+	tests := map[string]struct {
+		rule string
+		time time.Time
+		want bool
+	}{
+		"prefixed":            {"RRULE:FREQ=DAILY;BYHOUR=9;BYMINUTE=0", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), true},
+		"lowercase":           {"freq=daily;byhour=9;byminute=0", time.Date(2020, 6, 8, 9, 1, 0, 0, time.UTC), false},
+		"weekly":              {"FREQ=WEEKLY;BYDAY=SA,SU;BYHOUR=10;BYMINUTE=0", time.Date(2020, 6, 7, 10, 0, 0, 0, time.UTC), true},
+		"weekly miss":         {"FREQ=WEEKLY;BYDAY=SA,SU;BYHOUR=10;BYMINUTE=0", time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC), false},
+		"last day":            {"FREQ=MONTHLY;BYMONTHDAY=-1;BYHOUR=0;BYMINUTE=0", time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC), true},
+		"second friday":       {"FREQ=MONTHLY;BYDAY=2FR;BYHOUR=0;BYMINUTE=0", time.Date(2020, 6, 12, 0, 0, 0, 0, time.UTC), true},
+		"yearly with ordinal": {"FREQ=YEARLY;BYMONTH=11;BYDAY=4TH;BYHOUR=0;BYMINUTE=0", time.Date(2020, 11, 26, 0, 0, 0, 0, time.UTC), true},
+		"seconds":             {"FREQ=MINUTELY;BYSECOND=30", time.Date(2020, 6, 8, 9, 0, 30, 0, time.UTC), true},
+		"seconds miss":        {"FREQ=MINUTELY;BYSECOND=30", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), false},
+		"interval":            {"FREQ=HOURLY;INTERVAL=2", time.Date(2020, 6, 8, 8, 0, 0, 0, time.UTC), true},
+		"interval miss":       {"FREQ=HOURLY;INTERVAL=2", time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := FromRRULE(tc.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if avail.Able(tc.time) != tc.want {
+				t.Errorf("want %t, got %t", tc.want, !tc.want)
+			}
+		})
+	}
+}
+
+func TestFromRRULEInvalid(t *testing.T) {
+	tests := map[string]string{
+		"missing frequency":       "BYHOUR=9",
+		"unknown frequency":       "FREQ=FORTNIGHTLY",
+		"time from dtstart":       "FREQ=DAILY",
+		"day from dtstart":        "FREQ=MONTHLY;BYHOUR=9;BYMINUTE=0",
+		"count":                   "FREQ=MINUTELY;COUNT=10",
+		"until":                   "FREQ=MINUTELY;UNTIL=20201231T000000Z",
+		"interval with by rule":   "FREQ=HOURLY;INTERVAL=2;BYMINUTE=0",
+		"ordinal without a month": "FREQ=WEEKLY;BYDAY=2FR;BYHOUR=0;BYMINUTE=0",
+		"out of range hour":       "FREQ=DAILY;BYHOUR=24;BYMINUTE=0",
+		"malformed part":          "FREQ=DAILY;BYHOUR",
+		"repeated part":           "FREQ=DAILY;FREQ=WEEKLY",
+	}
+
+	for name, rule := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := FromRRULE(rule); err == nil {
+				t.Errorf("expected error for rule %q", rule)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package avail
+
+import "time"
+
+// Schedule holds the values each term of a native expression matches, for building a
+// Timeframe from data which is already structured, such as integer lists from an API, without
+// formatting it as an expression first. Terms left empty match every value. Ex.
+//
+//    timeframe, err := avail.NewFromSchedule(avail.Schedule{
+//        Minutes:  []int{0, 30},
+//        Hours:    []int{9, 10, 11},
+//        Weekdays: []time.Weekday{time.Monday, time.Friday},
+//    })
+type Schedule struct {
+	// Seconds, from 0 to 59, give the expression a leading seconds term as if parsed
+	// WithSeconds when set.
+	Seconds []int
+	// Minutes run from 0 to 59.
+	Minutes []int
+	// Hours run from 0 to 23.
+	Hours []int
+	// Days are days of the month, from 1 to 31.
+	Days     []int
+	Months   []time.Month
+	Weekdays []time.Weekday
+	// Years run from 1970 to 2100.
+	Years []int
+	// Location is the location the expression is evaluated in, if any. It must be one
+	// time.LoadLocation can load by name.
+	Location *time.Location
+}
+
+// NewFromSchedule returns a Timeframe matching the values of the Schedule. Each value is
+// checked against the range of its term, and the first outside of it is returned as an error.
+// The Timeframe's Expression is the canonical native expression for the values.
+func NewFromSchedule(schedule Schedule) (Timeframe, error) {
+	builder := Build()
+	for _, term := range []struct {
+		values []int
+		add    func(...int) *Builder
+	}{
+		{schedule.Seconds, builder.Seconds},
+		{schedule.Minutes, builder.Minutes},
+		{schedule.Hours, builder.Hours},
+		{schedule.Days, builder.Days},
+		{schedule.Years, builder.Years},
+	} {
+		if len(term.values) > 0 {
+			term.add(term.values...)
+		}
+	}
+
+	if len(schedule.Months) > 0 {
+		builder.Months(schedule.Months...)
+	}
+	if len(schedule.Weekdays) > 0 {
+		builder.Weekdays(schedule.Weekdays...)
+	}
+	if schedule.Location != nil {
+		builder.In(schedule.Location)
+	}
+
+	return builder.Timeframe()
+}
@@ -0,0 +1,63 @@
+package avail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewFromSchedule(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		schedule Schedule
+		want     string
+	}{
+		"empty": {Schedule{}, "* * * * * *"},
+		"half hourly": {
+			Schedule{Minutes: []int{0, 30}, Hours: []int{9, 10, 11}, Weekdays: []time.Weekday{time.Monday, time.Friday}},
+			"0,30 9-11 * * 1,5 *",
+		},
+		"unordered duplicates": {Schedule{Minutes: []int{30, 0, 30}, Hours: []int{12}}, "0,30 12 * * * *"},
+		"months and years": {
+			Schedule{Minutes: []int{0}, Hours: []int{0}, Days: []int{1}, Months: []time.Month{time.March}, Years: []int{2030}},
+			"0 0 1 3 * 2030",
+		},
+		"seconds":  {Schedule{Seconds: []int{15}, Minutes: []int{0}, Hours: []int{6}}, "15 0 6 * * * *"},
+		"location": {Schedule{Minutes: []int{0}, Hours: []int{9}, Location: newYork}, "CRON_TZ=America/New_York 0 9 * * * *"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			timeframe, err := NewFromSchedule(tc.schedule)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if timeframe.Expression != tc.want {
+				t.Errorf("want %s, got %s", tc.want, timeframe.Expression)
+			}
+		})
+	}
+}
+
+func TestNewFromScheduleInvalid(t *testing.T) {
+	tests := map[string]Schedule{
+		"second":  {Seconds: []int{60}},
+		"minute":  {Minutes: []int{-1}},
+		"hour":    {Hours: []int{24}},
+		"day":     {Days: []int{32}},
+		"month":   {Months: []time.Month{13}},
+		"weekday": {Weekdays: []time.Weekday{7}},
+		"year":    {Years: []int{1969}},
+	}
+
+	for name, schedule := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewFromSchedule(schedule); err == nil {
+				t.Errorf("invalid schedules should not be built successfully")
+			}
+		})
+	}
+}
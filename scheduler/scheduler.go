@@ -0,0 +1,229 @@
+// Package scheduler runs callbacks at the times avail Timeframes occur, making avail usable as
+// a lightweight in-process cron runner.
+//
+// Entries are added with Add and run once the Scheduler is started. The Scheduler sleeps until
+// the earliest next occurrence of any entry, as given by its Timeframe's Next, and runs each
+// entry which is due in its own goroutine. The clock is checked again at least every minute, so
+// changes to the wall clock are noticed. Runs which are missed by more than the Timeframe's
+// precision, such as while the process was suspended, are skipped rather than run late, and a
+// run which is still going when the entry is next due does not delay it.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/clintjedwards/avail/v2"
+)
+
+// Scheduler runs the callbacks of its entries at the times their Timeframes occur.
+type Scheduler struct {
+	mu      sync.Mutex
+	entries []*entry
+	names   map[string]struct{}
+
+	// wake is signalled when entries are added to a running scheduler so that it plans again.
+	wake chan struct{}
+	// stop cancels the running scheduler and done is closed once it has stopped.
+	stop func()
+	done chan struct{}
+	// running tracks the callbacks in progress.
+	running sync.WaitGroup
+
+	// now returns the current time and recheck is the longest the scheduler sleeps before
+	// checking it again; both are swapped out in tests.
+	now     func() time.Time
+	recheck time.Duration
+}
+
+// entry is a callback and the Timeframe at which it runs.
+type entry struct {
+	name      string
+	timeframe avail.Timeframe
+	fn        func(context.Context)
+
+	// next is the time the entry is next due, or the zero time if it will never be again.
+	next time.Time
+}
+
+// Event is a run of an entry, as given to its callback by EventFromContext.
+type Event struct {
+	// Name is the name of the entry being run.
+	Name string
+	// Occurrence is the time the entry was due, as returned by its Timeframe's Next, which may be
+	// slightly earlier than the time the callback is called.
+	Occurrence time.Time
+}
+
+// eventKey is the context key under which a callback's Event is stored.
+type eventKey struct{}
+
+// EventFromContext returns the Event of the run a callback was called for, from the context it
+// was called with. It returns false for contexts not given to a callback by a Scheduler.
+func EventFromContext(ctx context.Context) (Event, bool) {
+	event, ok := ctx.Value(eventKey{}).(Event)
+	return event, ok
+}
+
+// New returns a Scheduler without entries.
+func New() *Scheduler {
+	return &Scheduler{
+		names:   map[string]struct{}{},
+		wake:    make(chan struct{}, 1),
+		now:     time.Now,
+		recheck: time.Minute,
+	}
+}
+
+// Add adds an entry which calls fn each time the Timeframe occurs, with the context the
+// Scheduler was started with carrying the run's Event. Names must be unique within the Scheduler. Entries may be added
+// before or after the Scheduler is started.
+func (s *Scheduler) Add(name string, timeframe avail.Timeframe, fn func(context.Context)) error {
+	if fn == nil {
+		return fmt.Errorf("entry %s must have a callback", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.names[name]; ok {
+		return fmt.Errorf("entry %s already exists", name)
+	}
+	s.names[name] = struct{}{}
+
+	added := &entry{name: name, timeframe: timeframe, fn: fn}
+	if s.stop != nil {
+		added.plan(s.now())
+	}
+	s.entries = append(s.entries, added)
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Start starts running entries in the background until the context is done or Stop is
+// called. It returns an error if the Scheduler is already running.
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stop != nil {
+		return fmt.Errorf("scheduler is already running")
+	}
+
+	now := s.now()
+	for _, current := range s.entries {
+		current.plan(now)
+	}
+
+	loop, cancel := context.WithCancel(ctx)
+	s.stop, s.done = cancel, make(chan struct{})
+	go s.run(ctx, loop, s.done)
+	return nil
+}
+
+// Stop stops the Scheduler from running entries and waits for the callbacks in progress to
+// return. Their context is not cancelled, so they may finish their work. If the Scheduler is not
+// running, as once the context it was started with is done, Stop only waits for the callbacks.
+// It may be started again afterwards.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.mu.Unlock()
+
+	if stop != nil {
+		stop()
+		<-done
+	}
+	s.running.Wait()
+}
+
+// run sleeps until entries are due and runs them, with the context given, until the loop's
+// context is done. The Scheduler is marked as stopped on return, unless it has since been
+// started again, so that it may be started again after the context it was started with is done.
+func (s *Scheduler) run(ctx, loop context.Context, done chan struct{}) {
+	defer func() {
+		s.mu.Lock()
+		if s.done == done {
+			s.stop, s.done = nil, nil
+		}
+		s.mu.Unlock()
+		close(done)
+	}()
+
+	for {
+		s.mu.Lock()
+		var earliest time.Time
+		for _, current := range s.entries {
+			if !current.next.IsZero() && (earliest.IsZero() || current.next.Before(earliest)) {
+				earliest = current.next
+			}
+		}
+		s.mu.Unlock()
+
+		// Without any entry due the scheduler waits to be woken by one being added.
+		var timer *time.Timer
+		var due <-chan time.Time
+		if !earliest.IsZero() {
+			wait := earliest.Sub(s.now())
+			if wait > s.recheck {
+				wait = s.recheck
+			}
+			timer = time.NewTimer(wait)
+			due = timer.C
+		}
+
+		woken := false
+		select {
+		case <-loop.Done():
+		case <-s.wake:
+			woken = true
+		case <-due:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+
+		if loop.Err() != nil {
+			return
+		}
+		if woken {
+			continue
+		}
+
+		s.mu.Lock()
+		now := s.now()
+		for _, current := range s.entries {
+			if current.next.IsZero() || current.next.After(now) {
+				continue
+			}
+
+			// Runs missed by more than the Timeframe's precision are skipped.
+			if !current.next.Before(now.Add(-current.timeframe.Precision())) {
+				s.running.Add(1)
+				event := Event{Name: current.name, Occurrence: current.next}
+				go func(fn func(context.Context)) {
+					defer s.running.Done()
+					fn(context.WithValue(ctx, eventKey{}, event))
+				}(current.fn)
+			}
+			current.plan(now)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// plan sets the time the entry is next due to its Timeframe's next occurrence after the time
+// given.
+func (e *entry) plan(after time.Time) {
+	next, err := e.timeframe.Next(after)
+	if err != nil {
+		next = time.Time{}
+	}
+	e.next = next
+}
@@ -0,0 +1,273 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/clintjedwards/avail/v2"
+)
+
+// everySecond occurs at the start of every second, so that tests run entries quickly.
+func everySecond() avail.Timeframe {
+	return avail.MustNew("* * * * * *", avail.WithGranularity(time.Second))
+}
+
+func TestAdd(t *testing.T) {
+	scheduler := New()
+
+	err := scheduler.Add("backup", everySecond(), func(context.Context) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = scheduler.Add("backup", everySecond(), func(context.Context) {})
+	if err == nil {
+		t.Errorf("want error for duplicate entry, got nil")
+	}
+
+	err = scheduler.Add("report", everySecond(), nil)
+	if err == nil {
+		t.Errorf("want error for entry without a callback, got nil")
+	}
+}
+
+func TestRun(t *testing.T) {
+	scheduler := New()
+
+	var runs int32
+	called := make(chan struct{}, 10)
+	err := scheduler.Add("tick", everySecond(), func(context.Context) {
+		atomic.AddInt32(&runs, 1)
+		called <- struct{}{}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = scheduler.Start(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-called:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("want entry to run twice, ran %d times", atomic.LoadInt32(&runs))
+		}
+	}
+
+	// Entries run at the start of each second, so at most one can be due between the second run
+	// and stopping.
+	scheduler.Stop()
+	stopped := atomic.LoadInt32(&runs)
+	time.Sleep(1500 * time.Millisecond)
+
+	got := atomic.LoadInt32(&runs)
+	if got != stopped {
+		t.Errorf("want %d runs after stopping, got %d", stopped, got)
+	}
+}
+
+func TestStartTwice(t *testing.T) {
+	scheduler := New()
+
+	err := scheduler.Start(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer scheduler.Stop()
+
+	err = scheduler.Start(context.Background())
+	if err == nil {
+		t.Errorf("want error for starting twice, got nil")
+	}
+}
+
+func TestAddWhileRunning(t *testing.T) {
+	scheduler := New()
+
+	err := scheduler.Start(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer scheduler.Stop()
+
+	called := make(chan struct{}, 10)
+	err = scheduler.Add("tick", everySecond(), func(context.Context) {
+		called <- struct{}{}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-called:
+	case <-time.After(5 * time.Second):
+		t.Errorf("want entry added while running to run, got none")
+	}
+}
+
+func TestStopWaitsForCallbacks(t *testing.T) {
+	scheduler := New()
+
+	var finished int32
+	started := make(chan struct{}, 10)
+	err := scheduler.Add("slow", everySecond(), func(context.Context) {
+		started <- struct{}{}
+		time.Sleep(300 * time.Millisecond)
+		atomic.StoreInt32(&finished, 1)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = scheduler.Start(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("want entry to run, got none")
+	}
+
+	scheduler.Stop()
+	if atomic.LoadInt32(&finished) != 1 {
+		t.Errorf("want %t, got %t", true, false)
+	}
+}
+
+func TestContextCancel(t *testing.T) {
+	scheduler := New()
+
+	var runs int32
+	called := make(chan struct{}, 10)
+	err := scheduler.Add("tick", everySecond(), func(context.Context) {
+		atomic.AddInt32(&runs, 1)
+		called <- struct{}{}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err = scheduler.Start(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-called:
+	case <-time.After(5 * time.Second):
+		t.Fatal("want entry to run, got none")
+	}
+
+	cancel()
+	scheduler.Stop()
+	stopped := atomic.LoadInt32(&runs)
+	time.Sleep(1500 * time.Millisecond)
+
+	got := atomic.LoadInt32(&runs)
+	if got != stopped {
+		t.Errorf("want %d runs after cancelling, got %d", stopped, got)
+	}
+}
+
+func TestRestartAfterCancel(t *testing.T) {
+	scheduler := New()
+
+	called := make(chan struct{}, 10)
+	err := scheduler.Add("tick", everySecond(), func(context.Context) {
+		called <- struct{}{}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err = scheduler.Start(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Once the run goroutine notices the cancellation the Scheduler can be started again without
+	// calling Stop.
+	cancel()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		err = scheduler.Start(context.Background())
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("want scheduler to start after cancelling, got %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	defer scheduler.Stop()
+
+	for len(called) > 0 {
+		<-called
+	}
+	select {
+	case <-called:
+	case <-time.After(5 * time.Second):
+		t.Errorf("want entry to run after starting again, got none")
+	}
+}
+
+func TestClockJump(t *testing.T) {
+	var mu sync.Mutex
+	now := time.Date(2020, 6, 8, 8, 30, 0, 0, time.UTC)
+	set := func(t time.Time) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = t
+	}
+
+	scheduler := New()
+	scheduler.recheck = time.Millisecond
+	scheduler.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+
+	runs := make(chan time.Time, 10)
+	err := scheduler.Add("hourly", avail.MustNew("0 * * * * *"), func(context.Context) {
+		runs <- scheduler.now()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = scheduler.Start(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer scheduler.Stop()
+
+	// Jumping past the 9:00 run, as after a suspend, skips it rather than running it late.
+	set(time.Date(2020, 6, 8, 9, 30, 0, 0, time.UTC))
+	select {
+	case run := <-runs:
+		t.Fatalf("want missed run skipped, got run at %s", run)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	set(time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC))
+	select {
+	case run := <-runs:
+		want := time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC)
+		if !run.Equal(want) {
+			t.Errorf("want run at %s, got %s", want, run)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("want run once the clock reaches the entry, got none")
+	}
+}
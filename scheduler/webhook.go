@@ -0,0 +1,136 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Webhook posts each run of the entries it is the callback of to a URL as JSON, so that
+// systems not written in Go can follow a Scheduler's entries. Ex.
+//
+//	hook := &scheduler.Webhook{URL: "https://example.com/occurrences"}
+//	err := s.Add("backup", timeframe, hook.Post)
+//
+// Deliveries which fail with a network error, 429 Too Many Requests or a 5xx status are
+// attempted again after a backoff which doubles each time. Any other status outside of 2xx
+// fails the delivery straight away.
+type Webhook struct {
+	// URL is the address each Payload is posted to.
+	URL string
+	// Client sends the requests. A nil Client uses http.DefaultClient.
+	Client *http.Client
+	// Attempts is the most times a delivery is attempted. Zero or less attempts it three times.
+	Attempts int
+	// Backoff is the wait before the second attempt. Zero or less waits one second.
+	Backoff time.Duration
+	// OnError, if set, is called by Post with the error of each delivery which fails.
+	OnError func(Event, error)
+}
+
+// Payload is the JSON body posted by a Webhook for each run.
+type Payload struct {
+	// ID is the name of the entry being run.
+	ID string `json:"id"`
+	// Occurrence is the time the entry was due.
+	Occurrence time.Time `json:"occurrence"`
+	// Fingerprint is the same for every attempt to deliver a run and differs between runs, so
+	// receivers can ignore runs they have already seen.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Post delivers the run of the Event carried by the context, as a Scheduler calls its entries
+// with, reporting failure to OnError. It is meant to be given to Add as an entry's callback.
+func (w *Webhook) Post(ctx context.Context) {
+	event, ok := EventFromContext(ctx)
+	err := fmt.Errorf("could not deliver run: context was not given by a scheduler")
+	if ok {
+		err = w.Send(ctx, event)
+	}
+	if err != nil && w.OnError != nil {
+		w.OnError(event, err)
+	}
+}
+
+// Send delivers the Event, attempting it again with backoff until it succeeds, the attempts
+// run out or the context is done. It returns the error of the last attempt.
+func (w *Webhook) Send(ctx context.Context, event Event) error {
+	body, err := json.Marshal(Payload{
+		ID:          event.Name,
+		Occurrence:  event.Occurrence,
+		Fingerprint: fingerprint(event),
+	})
+	if err != nil {
+		return fmt.Errorf("could not deliver %s: %w", event.Name, err)
+	}
+
+	attempts := w.Attempts
+	if attempts <= 0 {
+		attempts = 3
+	}
+	backoff := w.Backoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+
+	for attempt := 1; ; attempt++ {
+		retry, err := w.attempt(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= attempts {
+			return fmt.Errorf("could not deliver %s after %d attempts: %w", event.Name, attempt, err)
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("could not deliver %s after %d attempts: %w", event.Name, attempt, ctx.Err())
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// attempt posts the body once. It returns whether a failed attempt is worth trying again.
+func (w *Webhook) attempt(ctx context.Context, body []byte) (bool, error) {
+	request, err := http.NewRequest(http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	request = request.WithContext(ctx)
+	request.Header.Set("Content-Type", "application/json")
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500
+	return retry, fmt.Errorf("unexpected status %s", response.Status)
+}
+
+// fingerprint returns a digest of the entry's name and the time it was due.
+func fingerprint(event Event) string {
+	digest := sha256.Sum256([]byte(event.Name + "\x00" + event.Occurrence.UTC().Format(time.RFC3339Nano)))
+	return hex.EncodeToString(digest[:16])
+}
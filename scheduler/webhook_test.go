@@ -0,0 +1,165 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// recorder is a webhook receiver which responds with each of its statuses in turn, then 200,
+// and keeps the payloads it receives.
+type recorder struct {
+	mu       sync.Mutex
+	statuses []int
+	payloads []Payload
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, request *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var payload Payload
+	if err := json.NewDecoder(request.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.payloads = append(r.payloads, payload)
+
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+// received returns the payloads received so far.
+func (r *recorder) received() []Payload {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Payload{}, r.payloads...)
+}
+
+func TestWebhookSend(t *testing.T) {
+	tests := map[string]struct {
+		statuses []int
+		attempts int
+		wantErr  bool
+	}{
+		"delivered":        {nil, 1, false},
+		"retried":          {[]int{http.StatusServiceUnavailable, http.StatusTooManyRequests}, 3, false},
+		"attempts run out": {[]int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}, 3, true},
+		"not retried":      {[]int{http.StatusBadRequest}, 1, true},
+	}
+
+	event := Event{Name: "backup", Occurrence: time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC)}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			receiver := &recorder{statuses: tc.statuses}
+			server := httptest.NewServer(receiver)
+			defer server.Close()
+
+			hook := &Webhook{URL: server.URL, Attempts: 3, Backoff: time.Millisecond}
+			err := hook.Send(context.Background(), event)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("want error %t, got %v", tc.wantErr, err)
+			}
+
+			payloads := receiver.received()
+			if len(payloads) != tc.attempts {
+				t.Fatalf("want %d attempts, got %d", tc.attempts, len(payloads))
+			}
+
+			// Every attempt carries the same payload.
+			want := Payload{ID: "backup", Occurrence: event.Occurrence, Fingerprint: fingerprint(event)}
+			for _, payload := range payloads {
+				diff := cmp.Diff(want, payload)
+				if diff != "" {
+					t.Errorf("result is different than expected(-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookFingerprint(t *testing.T) {
+	event := Event{Name: "backup", Occurrence: time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC)}
+
+	// The same run in another location has the same fingerprint.
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	same := Event{Name: "backup", Occurrence: event.Occurrence.In(newYork)}
+	if fingerprint(event) != fingerprint(same) {
+		t.Errorf("want %s, got %s", fingerprint(event), fingerprint(same))
+	}
+
+	for _, other := range []Event{
+		{Name: "report", Occurrence: event.Occurrence},
+		{Name: "backup", Occurrence: event.Occurrence.Add(time.Minute)},
+	} {
+		if fingerprint(event) == fingerprint(other) {
+			t.Errorf("want fingerprints of %v and %v to differ, got %s", event, other, fingerprint(event))
+		}
+	}
+}
+
+func TestWebhookPost(t *testing.T) {
+	receiver := &recorder{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	scheduler := New()
+	errs := make(chan error, 10)
+	hook := &Webhook{URL: server.URL, OnError: func(_ Event, err error) { errs <- err }}
+	err := scheduler.Add("tick", everySecond(), hook.Post)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = scheduler.Start(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if len(receiver.received()) > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("want run delivered, got none")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	scheduler.Stop()
+
+	payload := receiver.received()[0]
+	if payload.ID != "tick" {
+		t.Errorf("want %s, got %s", "tick", payload.ID)
+	}
+	if !payload.Occurrence.Equal(payload.Occurrence.Truncate(time.Second)) {
+		t.Errorf("want occurrence at the start of a second, got %s", payload.Occurrence)
+	}
+
+	select {
+	case err := <-errs:
+		t.Errorf("want no error, got %v", err)
+	default:
+	}
+
+	// Called outside of a scheduler there is no run to deliver.
+	hook.Post(context.Background())
+	select {
+	case <-errs:
+	default:
+		t.Errorf("want error for context without an event, got none")
+	}
+}
@@ -0,0 +1,214 @@
+package avail
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Severity describes how serious a Finding is.
+type Severity string
+
+const (
+	// SeverityError is used for findings which mean the expression will never match.
+	SeverityError Severity = "error"
+	// SeverityWarning is used for findings which are likely to surprise, such as days which
+	// are skipped in some months.
+	SeverityWarning Severity = "warning"
+)
+
+// Finding is a single issue found when checking a Timeframe.
+type Finding struct {
+	Severity Severity
+	Message  string
+}
+
+// SelfCheck exhaustively evaluates every date within the Timeframe's years and reports
+// calendar edge cases which may not behave as intended: expressions which can never match,
+// days which do not occur in some of the selected months (the 31st, February 29th), relative
+// days which do not occur every month and years after which the expression stops matching.
+// A Timeframe without findings returns nil. Exclusions are not considered.
+func (a *Timeframe) SelfCheck() []Finding {
+	if a.alternatives != nil {
+		findings := []Finding{}
+		never := true
+		for i := range a.alternatives {
+			for _, finding := range a.alternatives[i].SelfCheck() {
+				// An alternative which can never match is only an error if all of them can't.
+				if finding.Severity == SeverityError {
+					finding.Severity = SeverityWarning
+				}
+				finding.Message = fmt.Sprintf("alternative %d: %s", i+1, finding.Message)
+				findings = append(findings, finding)
+			}
+			if !a.alternatives[i].neverMatches() {
+				never = false
+			}
+		}
+		if never {
+			findings = append(findings, Finding{SeverityError, "expression can never match: none of its alternatives can"})
+		}
+		if len(findings) == 0 {
+			return nil
+		}
+		return findings
+	}
+
+	if a.Interval != 0 {
+		return nil
+	}
+
+	parsed := a.ParsedExpression
+	findings := []Finding{}
+
+	matching := a.matchingDates()
+	if len(matching) == 0 {
+		return []Finding{{SeverityError,
+			"expression can never match: no date within its years satisfies its day, month and weekday terms"}}
+	}
+
+	if !parsed.Days.isWildcard() {
+		months := sortedValues(parsed.Months.Values)
+		for _, day := range []int{29, 30, 31} {
+			if _, ok := parsed.Days.Values[day]; !ok {
+				continue
+			}
+
+			skipped := []string{}
+			for _, month := range months {
+				if day > daysIn(time.Month(month), 2001) && !(day == 29 && month == int(time.February)) {
+					skipped = append(skipped, time.Month(month).String())
+				}
+			}
+			if len(skipped) > 0 {
+				findings = append(findings, Finding{SeverityWarning,
+					fmt.Sprintf("day %d does not occur in %s", day, joinList(skipped))})
+			}
+
+			if _, ok := parsed.Months.Values[int(time.February)]; ok && day == 29 {
+				findings = append(findings, Finding{SeverityWarning, "February 29 only occurs in leap years"})
+			}
+		}
+	}
+
+	for _, relative := range append(append([]relativeValue{}, parsed.Days.relative...), parsed.Weekdays.relative...) {
+		if !a.relativeOccursMonthly(relative, matching) {
+			findings = append(findings, Finding{SeverityWarning,
+				fmt.Sprintf("%s does not occur in every selected month", describeRelative(relative))})
+		}
+	}
+
+	if !parsed.Years.isWildcard() {
+		last := matching[len(matching)-1]
+		findings = append(findings, Finding{SeverityWarning,
+			fmt.Sprintf("expression stops matching after %s", last.Format("2006-01-02"))})
+	}
+
+	if len(findings) == 0 {
+		return nil
+	}
+	return findings
+}
+
+// neverMatches reports whether the Timeframe can never match, without considering exclusions.
+func (a *Timeframe) neverMatches() bool {
+	if a.alternatives != nil {
+		for i := range a.alternatives {
+			if !a.alternatives[i].neverMatches() {
+				return false
+			}
+		}
+		return true
+	}
+
+	return a.Interval == 0 && len(a.matchingDates()) == 0
+}
+
+// checkPossible returns an error wrapping ErrNeverMatches if the Timeframe can never match,
+// described as SelfCheck would.
+func (a *Timeframe) checkPossible() error {
+	if !a.neverMatches() {
+		return nil
+	}
+
+	if a.alternatives != nil {
+		return reasonf(ErrNeverMatches, "expression can never match: none of its alternatives can")
+	}
+	return reasonf(ErrNeverMatches,
+		"expression can never match: no date within its years satisfies its day, month and weekday terms")
+}
+
+// matchingDates returns, in order, every date within the Timeframe's years which satisfies
+// its day, month, weekday and year terms.
+func (a *Timeframe) matchingDates() []time.Time {
+	parsed := a.ParsedExpression
+	dates := []time.Time{}
+
+	for _, year := range sortedValues(parsed.Years.values()) {
+		for _, month := range sortedValues(parsed.Months.Values) {
+			for day := 1; day <= daysIn(time.Month(month), year); day++ {
+				date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+				if a.matchesDay(date) {
+					dates = append(dates, date)
+				}
+			}
+		}
+	}
+
+	return dates
+}
+
+// relativeOccursMonthly reports whether the relative value resolves to a date in every
+// selected month of every selected year.
+func (a *Timeframe) relativeOccursMonthly(relative relativeValue, matching []time.Time) bool {
+	parsed := a.ParsedExpression
+	for _, year := range sortedValues(parsed.Years.values()) {
+		for _, month := range sortedValues(parsed.Months.Values) {
+			found := false
+			for day := 1; day <= daysIn(time.Month(month), year); day++ {
+				if relative.matches(time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// describeRelative returns a short description of a relative value for use in findings.
+func describeRelative(relative relativeValue) string {
+	switch relative.kind {
+	case nearestWeekday:
+		return fmt.Sprintf("the weekday nearest day %d", relative.day)
+	case nthDayOfWeek:
+		return fmt.Sprintf("occurrence %d of %s", relative.nth, relative.weekday)
+	case lastDayOfWeek:
+		return fmt.Sprintf("the last %s", relative.weekday)
+	case lastWeekday:
+		return "the last weekday"
+	}
+	return fmt.Sprintf("%d days before the last day", relative.offset)
+}
+
+// sortedValues returns the values of the set in ascending order.
+func sortedValues(values map[int]struct{}) []int {
+	sorted := make([]int, 0, len(values))
+	for value := range values {
+		sorted = append(sorted, value)
+	}
+	sort.Ints(sorted)
+	return sorted
+}
+
+// joinList joins the items as an English list. Ex. "April, June and September".
+func joinList(items []string) string {
+	if len(items) == 1 {
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
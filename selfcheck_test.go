@@ -0,0 +1,92 @@
+package avail
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSelfCheck(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		dialect    Dialect
+		want       []Finding
+	}{
+		"no findings":    {"0 9 * * 1-5 *", Native, nil},
+		"wildcard days":  {"0 9 * 2 * *", Native, nil},
+		"interval":       {"@every 1h", Native, nil},
+		"every last day": {"0 9 L * * *", Native, nil},
+		"February 30th": {"0 9 30 2 * *", Native, []Finding{
+			{SeverityError, "expression can never match: no date within its years satisfies its day, month and weekday terms"},
+		}},
+		"the 31st": {"0 9 31 * * *", Native, []Finding{
+			{SeverityWarning, "day 31 does not occur in February, April, June, September and November"},
+		}},
+		"February 29th": {"0 9 29 2 * *", Native, []Finding{
+			{SeverityWarning, "February 29 only occurs in leap years"},
+		}},
+		"February 29th without leap years": {"0 9 29 2 * 2021-2023", Native, []Finding{
+			{SeverityError, "expression can never match: no date within its years satisfies its day, month and weekday terms"},
+		}},
+		"fifth friday": {"0 0 9 ? * 6#5", Quartz, []Finding{
+			{SeverityWarning, "occurrence 5 of Friday does not occur in every selected month"},
+		}},
+		"limited years": {"0 9 1 1 * 2020-2022", Native, []Finding{
+			{SeverityWarning, "expression stops matching after 2022-01-01"},
+		}},
+		"impossible alternative": {"0 9 30 2 * * || 0 9 1 * * *", Native, []Finding{
+			{SeverityWarning, "alternative 1: expression can never match: no date within its years satisfies its day, month and weekday terms"},
+		}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression, WithDialect(tc.dialect))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diff := cmp.Diff(tc.want, avail.SelfCheck())
+			if diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithRejectImpossible(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+		rejected   bool
+	}{
+		"possible":               {"0 9 * * 1-5 *", nil, false},
+		"february 30th":          {"* * 30 2 * *", nil, true},
+		"weekday and year":       {"0 0 31 * THU 2021", nil, true},
+		"weekday in other years": {"0 0 31 * THU 2021-2022", nil, false},
+		"leap day":               {"0 9 29 2 * 2021-2023", nil, true},
+		"quartz":                 {"0 0 9 30 2 ?", []Option{WithDialect(Quartz)}, true},
+		"interval":               {"@every 1h", nil, false},
+		"impossible alternative": {"0 9 30 2 * * || 0 9 1 * * *", nil, false},
+		"every alternative":      {"0 9 30 2 * * || 0 9 31 4 * *", nil, true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := New(tc.expression, tc.opts...); err != nil {
+				t.Fatalf("expressions should be accepted without the option: %v", err)
+			}
+
+			_, err := New(tc.expression, append(tc.opts, WithRejectImpossible())...)
+			if got := errors.Is(err, ErrNeverMatches); got != tc.rejected {
+				t.Errorf("want %t, got %t: %v", tc.rejected, got, err)
+			}
+
+			validateErr := Validate(tc.expression, append(tc.opts, WithRejectImpossible())...)
+			if got := errors.Is(validateErr, ErrNeverMatches); got != tc.rejected {
+				t.Errorf("want %t, got %t: %v", tc.rejected, got, validateErr)
+			}
+		})
+	}
+}
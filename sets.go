@@ -0,0 +1,47 @@
+package avail
+
+import "fmt"
+
+// NewFromSets returns a Timeframe matching the sets of values given for each field, without
+// parsing an expression, for callers which have already computed them. Ex.
+//
+//    avail.NewFromSets(map[avail.FieldType][]int{
+//        avail.MinuteField:  {0, 30},
+//        avail.HourField:    {9, 13, 17},
+//        avail.WeekdayField: {1, 3, 5},
+//    })
+//
+// Fields which are left out match every value; a seconds field gives the Timeframe seconds as
+// if parsed WithSeconds. Values are checked against their field's bounds and weekdays run from
+// 0 (Sunday) to 6. The Timeframe's Expression, and so its String, is the canonical native
+// expression for the sets.
+func NewFromSets(sets map[FieldType][]int) (Timeframe, error) {
+	values := make([]map[int]struct{}, len(nativeFields))
+	for kind, set := range sets {
+		index := -1
+		for i, bounds := range nativeFields {
+			if bounds.kind == kind {
+				index = i
+			}
+		}
+		if index == -1 {
+			return Timeframe{}, fmt.Errorf("could not build expression: unknown field type %s", kind)
+		}
+
+		bounds := nativeFields[index]
+		if len(set) == 0 {
+			return Timeframe{}, fmt.Errorf("could not build expression: %s field has no values", kind)
+		}
+
+		values[index] = map[int]struct{}{}
+		for _, value := range set {
+			if value < bounds.min || value > bounds.max {
+				return Timeframe{}, fmt.Errorf("could not build expression: %s value(%d) must be within %d-%d",
+					kind, value, bounds.min, bounds.max)
+			}
+			values[index][value] = struct{}{}
+		}
+	}
+
+	return fromValues(values, nil)
+}
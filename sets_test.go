@@ -0,0 +1,74 @@
+package avail
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewFromSets(t *testing.T) {
+	tests := map[string]struct {
+		sets map[FieldType][]int
+		want string
+	}{
+		"empty": {map[FieldType][]int{}, "* * * * * *"},
+		"values": {map[FieldType][]int{
+			MinuteField: {0, 30}, HourField: {9, 13, 17}, WeekdayField: {1, 3, 5},
+		}, "0,30 9,13,17 * * 1,3,5 *"},
+		"run":       {map[FieldType][]int{MinuteField: {0}, HourField: {11, 9, 10, 9}}, "0 9-11 * * * *"},
+		"every day": {map[FieldType][]int{MinuteField: {0}, DayField: Span(1, 31)}, "0 * * * * *"},
+		"seconds":   {map[FieldType][]int{SecondField: {0, 30}}, "0,30 * * * * * *"},
+		"years":     {map[FieldType][]int{MinuteField: {0}, HourField: {0}, MonthField: {1}, DayField: {1}, YearField: {2030, 2031}}, "0 0 1 1 * 2030-2031"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			timeframe, err := NewFromSets(tc.sets)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if timeframe.String() != tc.want {
+				t.Errorf("want %s, got %s", tc.want, timeframe.String())
+			}
+
+			opts := []Option{}
+			if _, ok := tc.sets[SecondField]; ok {
+				opts = append(opts, WithSeconds())
+			}
+			parsed, err := New(tc.want, opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diff := cmp.Diff(parsed.ParsedExpression, timeframe.ParsedExpression, cmp.AllowUnexported(Field{}))
+			if diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+
+			after := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+			if diff := cmp.Diff(parsed.NextN(after, 10), timeframe.NextN(after, 10)); diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewFromSetsInvalid(t *testing.T) {
+	tests := map[string]map[FieldType][]int{
+		"above max":    {MinuteField: {60}},
+		"below min":    {DayField: {0}},
+		"weekday":      {WeekdayField: {7}},
+		"year":         {YearField: {2101}},
+		"no values":    {HourField: {}},
+		"unknown type": {FieldType("fortnight"): {1}},
+	}
+
+	for name, sets := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewFromSets(sets); err == nil {
+				t.Errorf("invalid sets should not be built successfully")
+			}
+		})
+	}
+}
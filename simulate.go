@@ -0,0 +1,62 @@
+package avail
+
+import (
+	"fmt"
+	"time"
+)
+
+// Simulation is the virtual clock given to the callback of Simulate. It allows downstream
+// logic to be tested against a schedule without waiting for real time to pass.
+type Simulation struct {
+	now     time.Time
+	stopped bool
+}
+
+// Now returns the current virtual time.
+func (s *Simulation) Now() time.Time {
+	return s.now
+}
+
+// Advance moves the virtual clock forward, simulating work which takes the given duration.
+// Occurrences which fall before the new time are skipped. Negative durations are ignored.
+func (s *Simulation) Advance(duration time.Duration) {
+	if duration > 0 {
+		s.now = s.now.Add(duration)
+	}
+}
+
+// Stop ends the simulation once the callback returns.
+func (s *Simulation) Stop() {
+	s.stopped = true
+}
+
+// Simulate walks virtual time from the start of the range up to, but not including, its end
+// in increments of step and calls fn at every time the Timeframe is able. Ex. a step of 24h
+// starting at midnight simulates a daily billing run against "0 0 * * 1-5 *".
+func (a *Timeframe) Simulate(from, to time.Time, step time.Duration, fn func(*Simulation)) error {
+	if step <= 0 {
+		return fmt.Errorf("step(%s) must be positive", step)
+	}
+
+	simulation := &Simulation{}
+
+	for current := from; current.Before(to); {
+		simulation.now = current
+
+		if a.Able(current) {
+			fn(simulation)
+			if simulation.stopped {
+				return nil
+			}
+		}
+
+		// Skip to the first step at or after the time the callback advanced the clock to.
+		steps := int64(1)
+		if elapsed := simulation.now.Sub(current); elapsed > step {
+			steps = int64((elapsed + step - 1) / step)
+		}
+		current = current.Add(time.Duration(steps) * step)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package avail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimulate(t *testing.T) {
+	avail, err := New("0 0 * * 1-5 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A quarter of daily billing runs, which only occur on weekdays.
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	runs := []time.Time{}
+	err = avail.Simulate(from, to, time.Hour, func(simulation *Simulation) {
+		runs = append(runs, simulation.Now())
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(runs) != 65 {
+		t.Errorf("incorrect number of runs; want 65, got %d", len(runs))
+	}
+
+	for _, run := range runs {
+		if run.Weekday() == time.Saturday || run.Weekday() == time.Sunday {
+			t.Errorf("run should not occur on a weekend: %s", run)
+		}
+	}
+}
+
+func TestSimulateAdvanceAndStop(t *testing.T) {
+	avail, err := New("* * * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	runs := []time.Time{}
+	err = avail.Simulate(from, from.Add(time.Hour), time.Minute, func(simulation *Simulation) {
+		runs = append(runs, simulation.Now())
+
+		// Each run takes 90 seconds so the minute after every run is skipped.
+		simulation.Advance(90 * time.Second)
+		if len(runs) == 3 {
+			simulation.Stop()
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []time.Time{from, from.Add(2 * time.Minute), from.Add(4 * time.Minute)}
+	if len(runs) != len(want) {
+		t.Fatalf("incorrect number of runs; want %d, got %d", len(want), len(runs))
+	}
+	for i := range want {
+		if !runs[i].Equal(want[i]) {
+			t.Errorf("incorrect run %d; want %s, got %s", i, want[i], runs[i])
+		}
+	}
+
+	if err := avail.Simulate(from, from.Add(time.Hour), 0, func(*Simulation) {}); err == nil {
+		t.Error("a step of zero should not be accepted")
+	}
+}
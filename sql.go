@@ -0,0 +1,293 @@
+package avail
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SQLDialect is a database whose SQL ToSQLPredicate can generate.
+type SQLDialect string
+
+const (
+	// Postgres generates predicates for PostgreSQL. Columns are expected to be of type
+	// timestamptz so that timezone prefixes can be honoured.
+	Postgres SQLDialect = "postgres"
+	// MySQL generates predicates for MySQL. Timezone prefixes are converted with CONVERT_TZ,
+	// which requires the server's timezone tables to be loaded and treats the column as UTC.
+	MySQL SQLDialect = "mysql"
+)
+
+// sqlBuilder generates the SQL expressions which make up a predicate.
+type sqlBuilder struct {
+	dialect SQLDialect
+	// column is the column as given; local is the column converted to the Timeframe's location.
+	column, local string
+}
+
+// ToSQLPredicate returns a predicate for use in a WHERE clause which is true for the values
+// of the column that the Timeframe is able at. Ex. "0 9-17 * * 1-5 *" for Postgres becomes
+// "(EXTRACT(MINUTE FROM created) IN (0) AND EXTRACT(HOUR FROM created) IN (9, ...) AND ...)".
+//
+// The column is inserted verbatim and so must never come from untrusted input. The "LW" term,
+// the nearest weekday term of dialects and holidays have no SQL equivalent and return an error.
+func (a *Timeframe) ToSQLPredicate(dialect SQLDialect, column string) (string, error) {
+	if dialect != Postgres && dialect != MySQL {
+		return "", fmt.Errorf("unknown sql dialect %s", dialect)
+	}
+
+	if strings.TrimSpace(column) == "" {
+		return "", fmt.Errorf("column cannot be empty")
+	}
+
+	return a.sqlPredicate(sqlBuilder{dialect: dialect, column: column, local: column})
+}
+
+// sqlPredicate returns the predicate for the Timeframe, including its exclusions.
+func (a *Timeframe) sqlPredicate(b sqlBuilder) (string, error) {
+	if a.options.holidays != nil {
+		return "", fmt.Errorf("could not convert %s: holidays have no SQL equivalent", a.Expression)
+	}
+
+	if a.Location != nil {
+		b.local = b.inLocation(a.Location)
+	}
+
+	conditions := []string{}
+
+	switch {
+	case a.alternatives != nil:
+		alternatives := []string{}
+		for i := range a.alternatives {
+			alternative, err := a.alternatives[i].sqlPredicate(b)
+			if err != nil {
+				return "", err
+			}
+			alternatives = append(alternatives, alternative)
+		}
+		conditions = append(conditions, "("+strings.Join(alternatives, " OR ")+")")
+	case a.Interval != 0:
+		precision := a.options.precision()
+		conditions = append(conditions, fmt.Sprintf("MOD(%s, %d) = 0",
+			b.cast(fmt.Sprintf("FLOOR(%s / %d)", b.epoch(), precision/time.Second)), a.Interval/precision))
+	default:
+		fields, err := a.sqlFieldConditions(b)
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, fields...)
+	}
+
+	// Alternatives carry the active period of the Timeframe they were parsed from.
+	if a.alternatives == nil {
+		if !a.options.activeStart.IsZero() {
+			conditions = append(conditions, fmt.Sprintf("%s >= %s", b.epoch(), sqlEpoch(a.options.activeStart)))
+		}
+		if !a.options.activeEnd.IsZero() {
+			conditions = append(conditions, fmt.Sprintf("%s < %s", b.epoch(), sqlEpoch(a.options.activeEnd)))
+		}
+	}
+
+	for i := range a.exclusions {
+		exclusion, err := a.exclusions[i].sqlPredicate(sqlBuilder{dialect: b.dialect, column: b.column, local: b.column})
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, "NOT "+exclusion)
+	}
+
+	switch {
+	case len(conditions) == 0:
+		return "TRUE", nil
+	case len(conditions) == 1 && a.alternatives != nil:
+		// The alternatives are already parenthesised.
+		return conditions[0], nil
+	}
+
+	return "(" + strings.Join(conditions, " AND ") + ")", nil
+}
+
+// sqlFieldConditions returns a condition for each field of the expression which is not a
+// wildcard.
+func (a *Timeframe) sqlFieldConditions(b sqlBuilder) ([]string, error) {
+	parsed := a.ParsedExpression
+	conditions := []string{}
+
+	fields := []struct {
+		field *Field
+		sql   string
+		shift int
+	}{
+		{&parsed.Minutes, b.extract("MINUTE"), 0},
+		{&parsed.Hours, b.extract("HOUR"), 0},
+		{&parsed.Days, b.extract("DAY"), 0},
+		{&parsed.Months, b.extract("MONTH"), 0},
+		{&parsed.Weekdays, b.weekday(), b.weekdayShift()},
+		{&parsed.Years, b.extract("YEAR"), 0},
+	}
+	if a.options.seconds {
+		fields = append([]struct {
+			field *Field
+			sql   string
+			shift int
+		}{{&parsed.Seconds, b.extract("SECOND"), 0}}, fields...)
+	}
+
+	dayCondition := ""
+	for _, field := range fields {
+		if field.field.isWildcard() {
+			continue
+		}
+
+		alternatives := []string{}
+		if len(field.field.Values) > 0 {
+			alternatives = append(alternatives, fmt.Sprintf("%s IN (%s)", field.sql, sqlValues(field.field.Values, field.shift)))
+		}
+
+		for _, relative := range field.field.relative {
+			condition, err := b.relative(relative)
+			if err != nil {
+				return nil, fmt.Errorf("could not convert %s: %w", a.Expression, err)
+			}
+			alternatives = append(alternatives, condition)
+		}
+
+		condition := ""
+		switch len(alternatives) {
+		case 0:
+			// A field without any values can never match.
+			condition = "FALSE"
+		case 1:
+			condition = alternatives[0]
+		default:
+			condition = "(" + strings.Join(alternatives, " OR ") + ")"
+		}
+
+		// Dates matching either day term are matched by one condition for both.
+		switch {
+		case a.eitherDay() && field.field == &parsed.Days:
+			dayCondition = condition
+			continue
+		case a.eitherDay() && field.field == &parsed.Weekdays:
+			condition = "(" + dayCondition + " OR " + condition + ")"
+		}
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, nil
+}
+
+// relative returns the condition for a relative value.
+func (b sqlBuilder) relative(relative relativeValue) (string, error) {
+	month := b.extract("MONTH")
+	weekday := func(weekday time.Weekday) string {
+		return fmt.Sprintf("%s = %d", b.weekday(), int(weekday)+b.weekdayShift())
+	}
+
+	switch relative.kind {
+	case last:
+		// The day is offset days before the last day of the month when the day after it is
+		// the first of the next month.
+		return fmt.Sprintf("EXTRACT(DAY FROM %s) = 1", b.addDays(relative.offset+1)), nil
+	case nthDayOfWeek:
+		return fmt.Sprintf("(%s AND FLOOR((%s - 1) / 7) = %d)",
+			weekday(relative.weekday), b.extract("DAY"), relative.nth-1), nil
+	case lastDayOfWeek:
+		return fmt.Sprintf("(%s AND EXTRACT(MONTH FROM %s) <> %s)",
+			weekday(relative.weekday), b.addDays(7), month), nil
+	}
+
+	return "", fmt.Errorf("term kind %s has no SQL equivalent", relative.kind)
+}
+
+// extract returns the SQL for the given unit of the local column.
+func (b sqlBuilder) extract(unit string) string {
+	if unit == "SECOND" && b.dialect == Postgres {
+		// Postgres includes fractional seconds.
+		return fmt.Sprintf("FLOOR(EXTRACT(SECOND FROM %s))", b.local)
+	}
+	return fmt.Sprintf("EXTRACT(%s FROM %s)", unit, b.local)
+}
+
+// weekday returns the SQL for the day of week of the local column.
+func (b sqlBuilder) weekday() string {
+	if b.dialect == MySQL {
+		return fmt.Sprintf("DAYOFWEEK(%s)", b.local)
+	}
+	return fmt.Sprintf("EXTRACT(DOW FROM %s)", b.local)
+}
+
+// weekdayShift returns the value of Sunday in the dialect's day of week numbering.
+func (b sqlBuilder) weekdayShift() int {
+	if b.dialect == MySQL {
+		return 1
+	}
+	return 0
+}
+
+// addDays returns the SQL for the local column moved forward by the given number of days.
+func (b sqlBuilder) addDays(days int) string {
+	if b.dialect == MySQL {
+		return fmt.Sprintf("(%s + INTERVAL %d DAY)", b.local, days)
+	}
+	return fmt.Sprintf("(%s + INTERVAL '%d days')", b.local, days)
+}
+
+// epoch returns the SQL for the number of seconds since the unix epoch of the column.
+func (b sqlBuilder) epoch() string {
+	if b.dialect == MySQL {
+		return fmt.Sprintf("UNIX_TIMESTAMP(%s)", b.column)
+	}
+	return fmt.Sprintf("EXTRACT(EPOCH FROM %s)", b.column)
+}
+
+// cast returns the SQL converting the value to an integer.
+func (b sqlBuilder) cast(value string) string {
+	if b.dialect == MySQL {
+		return fmt.Sprintf("CAST(%s AS SIGNED)", value)
+	}
+	return fmt.Sprintf("CAST(%s AS BIGINT)", value)
+}
+
+// inLocation returns the SQL for the column converted to the given location.
+func (b sqlBuilder) inLocation(location *time.Location) string {
+	zone := "'" + strings.Replace(location.String(), "'", "''", -1) + "'"
+	if b.dialect == MySQL {
+		return fmt.Sprintf("CONVERT_TZ(%s, 'UTC', %s)", b.column, zone)
+	}
+	return fmt.Sprintf("(%s AT TIME ZONE %s)", b.column, zone)
+}
+
+// sqlEpoch returns the seconds since the unix epoch of the time, with the fraction of a second
+// if it has one.
+func sqlEpoch(t time.Time) string {
+	seconds, nanoseconds := t.Unix(), t.Nanosecond()
+	if nanoseconds == 0 {
+		return strconv.FormatInt(seconds, 10)
+	}
+
+	// The fraction counts back from the following second for times before the epoch.
+	sign := ""
+	if seconds < 0 {
+		sign, seconds, nanoseconds = "-", -(seconds + 1), int(time.Second)-nanoseconds
+	}
+	return fmt.Sprintf("%s%d.%09d", sign, seconds, nanoseconds)
+}
+
+// sqlValues returns the set's values, shifted by the given amount, as a sorted comma
+// separated list.
+func sqlValues(values map[int]struct{}, shift int) string {
+	sorted := make([]int, 0, len(values))
+	for value := range values {
+		sorted = append(sorted, value+shift)
+	}
+	sort.Ints(sorted)
+
+	rendered := make([]string, 0, len(sorted))
+	for _, value := range sorted {
+		rendered = append(rendered, strconv.Itoa(value))
+	}
+	return strings.Join(rendered, ", ")
+}
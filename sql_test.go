@@ -0,0 +1,168 @@
+package avail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestToSQLPredicate(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		dialect    SQLDialect
+		want       string
+	}{
+		"wildcard": {"* * * * * *", Postgres, "TRUE"},
+		"postgres": {
+			"0 9-11 * * 1-5 *", Postgres,
+			"(EXTRACT(MINUTE FROM created) IN (0) AND EXTRACT(HOUR FROM created) IN (9, 10, 11) AND " +
+				"EXTRACT(DOW FROM created) IN (1, 2, 3, 4, 5))",
+		},
+		"mysql weekdays are shifted": {
+			"* * * * 0,6 *", MySQL,
+			"(DAYOFWEEK(created) IN (1, 7))",
+		},
+		"postgres timezone": {
+			"CRON_TZ=America/New_York * 9 * * * *", Postgres,
+			"(EXTRACT(HOUR FROM (created AT TIME ZONE 'America/New_York')) IN (9))",
+		},
+		"mysql timezone": {
+			"CRON_TZ=America/New_York * 9 * * * *", MySQL,
+			"(EXTRACT(HOUR FROM CONVERT_TZ(created, 'UTC', 'America/New_York')) IN (9))",
+		},
+		"last day": {
+			"* * L-2 * * *", MySQL,
+			"(EXTRACT(DAY FROM (created + INTERVAL 3 DAY)) = 1)",
+		},
+		"interval": {
+			"@every 90m", Postgres,
+			"(MOD(CAST(FLOOR(EXTRACT(EPOCH FROM created) / 60) AS BIGINT), 90) = 0)",
+		},
+		"combined": {
+			"* 9 * * * * || * 17 * * * *", Postgres,
+			"((EXTRACT(HOUR FROM created) IN (9)) OR (EXTRACT(HOUR FROM created) IN (17)))",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := avail.ToSQLPredicate(tc.dialect, "created")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tc.want {
+				t.Errorf("incorrect predicate;\nwant %s\ngot  %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestToSQLPredicateQuartz(t *testing.T) {
+	avail, err := New("0 0 12 ? * 6#3", WithDialect(Quartz))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := avail.ToSQLPredicate(Postgres, "created")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "(FLOOR(EXTRACT(SECOND FROM created)) IN (0) AND EXTRACT(MINUTE FROM created) IN (0) AND " +
+		"EXTRACT(HOUR FROM created) IN (12) AND " +
+		"(EXTRACT(DOW FROM created) = 5 AND FLOOR((EXTRACT(DAY FROM created) - 1) / 7) = 2))"
+	if got != want {
+		t.Errorf("incorrect predicate;\nwant %s\ngot  %s", want, got)
+	}
+}
+
+func TestToSQLPredicateDomDowOr(t *testing.T) {
+	avail, err := New("0 9 1,15 * 1 *", WithDomDowOr())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := avail.ToSQLPredicate(Postgres, "created")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "(EXTRACT(MINUTE FROM created) IN (0) AND EXTRACT(HOUR FROM created) IN (9) AND " +
+		"(EXTRACT(DAY FROM created) IN (1, 15) OR EXTRACT(DOW FROM created) IN (1)))"
+	if got != want {
+		t.Errorf("incorrect predicate;\nwant %s\ngot  %s", want, got)
+	}
+}
+
+func TestToSQLPredicateActiveBetween(t *testing.T) {
+	avail, err := New("0 9 * * * *", WithActiveBetween(time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 10, 1, 0, 0, 0, 500000000, time.UTC)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := avail.ToSQLPredicate(Postgres, "created")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "(EXTRACT(MINUTE FROM created) IN (0) AND EXTRACT(HOUR FROM created) IN (9) AND " +
+		"EXTRACT(EPOCH FROM created) >= 1751328000 AND EXTRACT(EPOCH FROM created) < 1759276800.500000000)"
+	if got != want {
+		t.Errorf("incorrect predicate;\nwant %s\ngot  %s", want, got)
+	}
+}
+
+func TestToSQLPredicateExcept(t *testing.T) {
+	base, err := New("* 9-10 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	christmas, err := New("* * 25 12 * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	avail := base.Except(christmas)
+
+	got, err := avail.ToSQLPredicate(Postgres, "created")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "(EXTRACT(HOUR FROM created) IN (9, 10) AND " +
+		"NOT (EXTRACT(DAY FROM created) IN (25) AND EXTRACT(MONTH FROM created) IN (12)))"
+	if got != want {
+		t.Errorf("incorrect predicate;\nwant %s\ngot  %s", want, got)
+	}
+}
+
+func TestToSQLPredicateInvalid(t *testing.T) {
+	avail, err := New("* * LW * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := avail.ToSQLPredicate(Postgres, "created"); err == nil {
+		t.Error("LW should not be converted")
+	}
+
+	avail, err = New("* * * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := avail.ToSQLPredicate("sqlite", "created"); err == nil {
+		t.Error("unknown dialects should not be accepted")
+	}
+
+	if _, err := avail.ToSQLPredicate(Postgres, " "); err == nil {
+		t.Error("empty columns should not be accepted")
+	}
+}
@@ -0,0 +1,71 @@
+package avail
+
+import (
+	"context"
+	"time"
+)
+
+// Ticker delivers each occurrence of a Timeframe on a channel, as its Next returns them. It is
+// the minimal building block for running work on a schedule without a full scheduler. Ex. a
+// Ticker of "0 * * * * *" delivers the start of every hour.
+//
+// Like the time package's Ticker, C holds at most one pending occurrence: if the receiver is
+// slow, later occurrences are dropped rather than buffered, and the receiver is given the
+// oldest one it missed. Occurrences are waited for as Wait does, so changes to the wall clock
+// are noticed, and occurrences which passed more than a minute (or second, for expressions
+// with seconds) before they could be delivered, such as while the process was suspended, are
+// skipped.
+type Ticker struct {
+	// C delivers the time of each occurrence. It is closed once the Timeframe has no further
+	// occurrences, but not when the Ticker is stopped.
+	C <-chan time.Time
+
+	stop func()
+}
+
+// NewTicker returns a Ticker delivering the occurrences of the Timeframe after the current
+// time, as given by the clock set with WithClock. It must be stopped to release its resources
+// once no longer needed.
+func NewTicker(timeframe Timeframe) *Ticker {
+	ticks := make(chan time.Time, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go runTicker(ctx, timeframe, ticks)
+	return &Ticker{C: ticks, stop: cancel}
+}
+
+// Stop stops the Ticker; no occurrences are delivered afterwards. It may be called more than
+// once.
+func (t *Ticker) Stop() {
+	t.stop()
+}
+
+// runTicker sleeps until each occurrence of the Timeframe and delivers it, until the context
+// is done or the Timeframe has no further occurrences.
+func runTicker(ctx context.Context, timeframe Timeframe, ticks chan time.Time) {
+	current := timeframe.Now()
+	for {
+		next, err := timeframe.Next(current)
+		if err != nil {
+			close(ticks)
+			return
+		}
+
+		fresh, err := timeframe.waitOccurrence(ctx, next)
+		if err != nil || ctx.Err() != nil {
+			return
+		}
+
+		if fresh {
+			select {
+			case ticks <- next:
+			default:
+			}
+		}
+
+		current = next
+		if now := timeframe.Now(); now.After(current) {
+			current = now
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package avail
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTicker(t *testing.T) {
+	timeframe, err := New("* * * * * *", WithGranularity(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ticker := NewTicker(timeframe)
+	defer ticker.Stop()
+
+	var previous time.Time
+	for i := 0; i < 2; i++ {
+		select {
+		case tick := <-ticker.C:
+			if tick.Nanosecond() != 0 {
+				t.Errorf("want tick at the start of a second, got %s", tick)
+			}
+			if !tick.After(previous) {
+				t.Errorf("want tick after %s, got %s", previous, tick)
+			}
+			previous = tick
+		case <-time.After(5 * time.Second):
+			t.Fatalf("want tick, got none")
+		}
+	}
+}
+
+func TestTickerStop(t *testing.T) {
+	timeframe, err := New("* * * * * *", WithGranularity(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ticker := NewTicker(timeframe)
+	ticker.Stop()
+	ticker.Stop()
+
+	select {
+	case tick := <-ticker.C:
+		t.Errorf("want no tick after stopping, got %s", tick)
+	case <-time.After(1500 * time.Millisecond):
+	}
+}
+
+func TestTickerSlowReceiver(t *testing.T) {
+	start := time.Now()
+	timeframe, err := New("* * * * * *", WithGranularity(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ticker := NewTicker(timeframe)
+	defer ticker.Stop()
+
+	// Occurrences after the first are dropped while the receiver is not receiving, so the
+	// pending tick is the oldest one missed.
+	time.Sleep(2500 * time.Millisecond)
+	tick := <-ticker.C
+	if tick.After(start.Add(time.Second)) {
+		t.Errorf("want oldest missed tick at or before %s, got %s", start.Add(time.Second), tick)
+	}
+}
+
+func TestTickerClosed(t *testing.T) {
+	now := time.Now()
+	timeframe, err := New("* * * * * *", WithGranularity(time.Second), WithActiveBetween(time.Time{}, now.Add(time.Second)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ticker := NewTicker(timeframe)
+	defer ticker.Stop()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-ticker.C:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("want channel closed once the timeframe ends, got open")
+		}
+	}
+}
+
+func TestTickerClockJump(t *testing.T) {
+	shortenWaitRecheck(t, time.Millisecond)
+
+	var mu sync.Mutex
+	now := time.Date(2020, 6, 8, 8, 30, 0, 0, time.UTC)
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	set := func(t time.Time) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = t
+	}
+
+	timeframe, err := New("0 * * * * *", WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ticker := NewTicker(timeframe)
+	defer ticker.Stop()
+
+	// Jumping past the 9:00 occurrence, as after a suspend, skips it rather than delivering it
+	// late.
+	set(time.Date(2020, 6, 8, 9, 30, 0, 0, time.UTC))
+	select {
+	case tick := <-ticker.C:
+		t.Fatalf("want stale occurrence skipped, got %s", tick)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	set(time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC))
+	select {
+	case tick := <-ticker.C:
+		want := time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC)
+		if !tick.Equal(want) {
+			t.Errorf("want tick at %s, got %s", want, tick)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("want tick once the clock reaches the occurrence, got none")
+	}
+}
@@ -0,0 +1,167 @@
+package avail
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Translations supply the phrases DescribeIn assembles descriptions from. Each phrase is a
+// fmt template identified by a key; the keys, and the arguments each template is given, are
+// those of the English phrases returned by EnglishPhrases. Ex. the key "at.minute" has the
+// template "at minute %d".
+//
+// Weekdays and months are looked up as "weekday.0" (Sunday) to "weekday.6" and "month.1" to
+// "month.12". Ordinal numbers are looked up as "ordinal.<n>", then formatted with the
+// "ordinal" template (ex. "%d." in German), before falling back to English.
+type Translations interface {
+	// Phrase returns the template for the key, or false if it is not translated, in which case
+	// the English phrase is used.
+	Phrase(key string) (string, bool)
+}
+
+// PhraseTable is a Translations backed by a map of keys to templates.
+type PhraseTable map[string]string
+
+// Phrase returns the template for the key.
+func (p PhraseTable) Phrase(key string) (string, bool) {
+	template, ok := p[key]
+	return template, ok
+}
+
+// englishPhrases are the phrases Describe uses.
+var englishPhrases = PhraseTable{
+	"separator":          ", ",
+	"and":                "%s and %s",
+	"or":                 "%s, or %s",
+	"except":             "%s, except %s",
+	"exceptHolidays":     "%s, except on holidays",
+	"onlyHolidays":       "%s, only on holidays",
+	"location":           "%s (%s)",
+	"interval":           "Every %s",
+	"duration.day":       "%d day",
+	"duration.hour":      "%d hour",
+	"duration.minute":    "%d minute",
+	"duration.second":    "%d second",
+	"durationN.day":      "%d days",
+	"durationN.hour":     "%d hours",
+	"durationN.minute":   "%d minutes",
+	"durationN.second":   "%d seconds",
+	"durationSeparator":  " ",
+	"atTimes":            "at %s",
+	"every.second":       "every second",
+	"every.minute":       "every minute",
+	"everyN.second":      "every %d seconds",
+	"everyN.minute":      "every %d minutes",
+	"from.second":        "%s from second %d",
+	"from.minute":        "%s from minute %d",
+	"at.second":          "at second %d",
+	"at.minute":          "at minute %d",
+	"atList.second":      "at seconds %s",
+	"atList.minute":      "at minutes %s",
+	"pastEveryHour":      "past every hour",
+	"duringEveryNthHour": "during every %s hour",
+	"fromTime":           "%s from %s",
+	"during":             "during %s",
+	"everyDay":           "every day",
+	"on":                 "on %s",
+	"onIf":               "on %s if it falls on %s",
+	"onEither":           "on %s or on %s",
+	"in":                 "in %s",
+	"through":            "%s through %s",
+	"day":                "day %d of the month",
+	"days":               "days %s of the month",
+	"lastDay":            "the last day of the month",
+	"nthToLastDay":       "the %s to last day of the month",
+	"lastWeekday":        "the last weekday of the month",
+	"nearestWeekday":     "the weekday nearest day %d of the month",
+	"nthDayOfWeek":       "the %s %s of the month",
+	"lastDayOfWeek":      "the last %s of the month",
+	"nth.1":              "first",
+	"nth.2":              "second",
+	"nth.3":              "third",
+	"nth.4":              "fourth",
+	"nth.5":              "fifth",
+}
+
+func init() {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		englishPhrases["weekday."+strconv.Itoa(int(weekday))] = weekday.String()
+	}
+	for month := time.January; month <= time.December; month++ {
+		englishPhrases["month."+strconv.Itoa(int(month))] = month.String()
+	}
+}
+
+// EnglishPhrases returns a copy of the phrases Describe uses, as a starting point for new
+// translations.
+func EnglishPhrases() PhraseTable {
+	phrases := PhraseTable{}
+	for key, template := range englishPhrases {
+		phrases[key] = template
+	}
+	return phrases
+}
+
+// English is the locale of the built in phrases.
+const English = "en"
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]Translations{}
+)
+
+// RegisterLocale adds the translations DescribeIn uses for the locale. Locales are
+// case-insensitive and cannot be registered twice. Ex. RegisterLocale("de", germanPhrases).
+func RegisterLocale(locale string, translations Translations) error {
+	locale = strings.ToLower(locale)
+
+	if locale == "" || locale == English {
+		return fmt.Errorf("could not register locale %s: collides with a built in locale", locale)
+	}
+
+	localesMu.Lock()
+	defer localesMu.Unlock()
+
+	if _, ok := locales[locale]; ok {
+		return fmt.Errorf("could not register locale %s: already registered", locale)
+	}
+
+	locales[locale] = translations
+	return nil
+}
+
+// Locales returns the locales DescribeIn accepts, in alphabetical order.
+func Locales() []string {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+
+	names := []string{English}
+	for locale := range locales {
+		names = append(names, locale)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DescribeIn returns a summary of the Timeframe like Describe, using the translations
+// registered for the locale with RegisterLocale. Phrases the translations lack are rendered
+// in English.
+func (a *Timeframe) DescribeIn(locale string) (string, error) {
+	locale = strings.ToLower(locale)
+	if locale == English {
+		return a.Describe(), nil
+	}
+
+	localesMu.RLock()
+	translations, ok := locales[locale]
+	localesMu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("could not describe expression: unknown locale %s", locale)
+	}
+
+	return a.describe(describer{translations: translations}), nil
+}
@@ -0,0 +1,81 @@
+package avail
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDescribeIn(t *testing.T) {
+	german := PhraseTable{
+		"and":          "%s und %s",
+		"atTimes":      "um %s",
+		"everyDay":     "jeden Tag",
+		"every.minute": "jede Minute",
+		"during":       "zwischen %s",
+		"on":           "am %s",
+		"in":           "im %s",
+		"through":      "%s bis %s",
+		"ordinal":      "%d.",
+		"nthToLastDay": "%s letzten Tag des Monats",
+		"weekday.1":    "Montag",
+		"weekday.5":    "Freitag",
+		"month.1":      "Januar",
+	}
+
+	if err := RegisterLocale("DE", german); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RegisterLocale("de", german); err == nil {
+		t.Errorf("locale should not be registered twice")
+	}
+
+	if err := RegisterLocale("en", german); err == nil {
+		t.Errorf("built in locale should not be replaced")
+	}
+
+	diff := cmp.Diff([]string{"de", "en"}, Locales())
+	if diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+
+	tests := map[string]struct {
+		expression string
+		locale     string
+		want       string
+	}{
+		"translated": {"0 12 * 1 * *", "de", "Um 12:00 jeden Tag im Januar"},
+		"list":       {"* 9,17 * * 1-5 *", "de", "Jede Minute zwischen 09:00-09:59 und 17:00-17:59 am Montag bis Freitag"},
+		"ordinal":    {"0 0 L-1 * * *", "de", "Um 00:00 am 2. letzten Tag des Monats"},
+		"fallback":   {"0 9 * * 0 *", "de", "Um 09:00 am Sunday"},
+		"english":    {"0 12 * 1 * *", "EN", "At 12:00 every day in January"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := avail.DescribeIn(tc.locale)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tc.want {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+
+	avail, err := New("0 12 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := avail.DescribeIn("fr"); err == nil {
+		t.Errorf("unknown locale should not be described")
+	}
+}
@@ -0,0 +1,66 @@
+package avail
+
+import (
+	"sort"
+	"strings"
+)
+
+// Union returns a Timeframe which is able whenever the Timeframe or any of the others is.
+// Ex. "0 9 * * 1-5 *" united with "0 10 * * 0,6 *" is able at 9:00 on weekdays and 10:00 on
+// weekends. The result is a combined Timeframe, as if its alternatives had been separated by
+// "||", so it can be described, iterated and stored like any other; Next returns the earliest
+// occurrence of any of them.
+//
+// Each Timeframe keeps its own options, location and exclusions. The alternatives of combined
+// Timeframes become alternatives of the result and duplicates are removed.
+func (a Timeframe) Union(others ...Timeframe) Timeframe {
+	parts := []Timeframe{}
+	for _, timeframe := range append([]Timeframe{a}, others...) {
+		for _, part := range unionParts(timeframe) {
+			if !containsEqual([]Timeframe{part}, parts) {
+				parts = append(parts, part)
+			}
+		}
+	}
+
+	// Alternatives without a location come first so that the combined expression does not
+	// apply the location of another to them when it is parsed.
+	sort.SliceStable(parts, func(i, j int) bool {
+		return parts[i].Location == nil && parts[j].Location != nil
+	})
+
+	if len(parts) == 1 {
+		return parts[0]
+	}
+
+	union := Timeframe{alternatives: parts}
+	expressions := []string{}
+	for i := range parts {
+		expressions = append(expressions, parts[i].Expression)
+		union.options.seconds = union.options.seconds || parts[i].options.seconds
+	}
+	union.Expression = strings.Join(expressions, " "+alternativeSeparator+" ")
+
+	return union
+}
+
+// unionParts returns the alternatives of a combined Timeframe, each given the location and
+// exclusions of the combined Timeframe, or the Timeframe itself.
+func unionParts(a Timeframe) []Timeframe {
+	if a.alternatives == nil {
+		return []Timeframe{a}
+	}
+
+	parts := []Timeframe{}
+	for _, part := range a.alternatives {
+		if part.Location == nil && a.Location != nil {
+			part.Location = a.Location
+			part.Expression = locationPrefixes[0] + a.Location.String() + " " + part.Expression
+		}
+		for _, exclusion := range a.exclusions {
+			part = part.Except(exclusion)
+		}
+		parts = append(parts, part)
+	}
+	return parts
+}
@@ -0,0 +1,75 @@
+package avail
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUnion(t *testing.T) {
+	weekdays := MustNew("0 9 * * 1-5 *")
+	weekends := MustNew("0 10 * * 0,6 *")
+	berlin := MustNew("CRON_TZ=Europe/Berlin 0 12 * * * * || 0 13 * * * *")
+	seconds := MustNew("30 15 8 * * * *", WithSeconds())
+	lunch := MustNew("* 9-17 * * * *").Except(MustNew("* 12 * * * *"))
+
+	union := weekdays.Union(weekends, berlin, seconds, lunch, weekdays)
+
+	start := time.Date(2021, 1, 25, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	for current := start; current.Before(end); current = current.Add(time.Second) {
+		want := weekdays.Able(current) || weekends.Able(current) || berlin.Able(current) ||
+			seconds.Able(current) || lunch.Able(current)
+		if got := union.Able(current); got != want {
+			t.Fatalf("at %s; want %t, got %t", current, want, got)
+		}
+	}
+
+	if len(union.alternatives) != 6 {
+		t.Errorf("duplicate alternatives should be removed; want %d, got %d", 6, len(union.alternatives))
+	}
+}
+
+func TestUnionNext(t *testing.T) {
+	union := MustNew("0 9 * * 1-5 *").Union(MustNew("0 10 * * 0,6 *"), MustNew("0 9 * * 1 *"))
+
+	got := union.NextN(time.Date(2021, 1, 29, 0, 0, 0, 0, time.UTC), 4)
+	want := []time.Time{
+		time.Date(2021, 1, 29, 9, 0, 0, 0, time.UTC),
+		time.Date(2021, 1, 30, 10, 0, 0, 0, time.UTC),
+		time.Date(2021, 1, 31, 10, 0, 0, 0, time.UTC),
+		time.Date(2021, 2, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	diff := cmp.Diff(want, got)
+	if diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+
+	if description := union.Describe(); description != "At 09:00 on Monday through Friday, or at 10:00 on Sunday and Saturday, or at 09:00 on Monday" {
+		t.Errorf("unexpected description %q", description)
+	}
+}
+
+func TestUnionMarshal(t *testing.T) {
+	union := MustNew("0 9 * * 1-5 *").Union(MustNew("CRON_TZ=Europe/Berlin 0 10 * * 0,6 *"))
+
+	encoded, err := json.Marshal(union)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded Timeframe
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.Equal(union) {
+		t.Errorf("decoded union %s should be equal to %s", decoded.Expression, union.Expression)
+	}
+
+	if _, err := json.Marshal(union.Union(MustNew("0 0 9 * * * *", WithSeconds()))); err == nil {
+		t.Errorf("unions with grammar options should not be marshalled")
+	}
+}
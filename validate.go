@@ -0,0 +1,68 @@
+package avail
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Validate returns the error New would return for the expression and options, or nil if the
+// expression is valid. Terms are checked against their bounds without building the sets of
+// values they match, so it is cheaper than New when only the verdict is needed. Ex. when
+// validating configuration or form input.
+func Validate(expression string, opts ...Option) error {
+	prepared, err := prepareExpression(expression, opts)
+	if err != nil {
+		return err
+	}
+	options, schedule := prepared.options, prepared.schedule
+
+	// Whether an expression can match depends on the dates its sets select, so they are built.
+	if options.rejectImpossible {
+		_, err := New(expression, opts...)
+		return err
+	}
+
+	switch prepared.kind {
+	case combinedExpression:
+		for i, alternative := range strings.Split(schedule, alternativeSeparator) {
+			offset := alternativeOffset(schedule, i)
+			alternative = strings.TrimSpace(alternative)
+			if alternative == "" {
+				return fmt.Errorf("could not parse cron expression: %s; alternatives cannot be empty", expression)
+			}
+
+			if err := Validate(alternative, opts...); err != nil {
+				err = shiftPosition(err, offset+prepared.offset)
+				return fmt.Errorf("could not parse cron expression: %s; %w", expression, err)
+			}
+		}
+		return nil
+
+	case dialectExpression:
+		if _, err := parseDialect(prepared.spec, schedule); err != nil {
+			err = shiftPosition(err, prepared.offset)
+			return fmt.Errorf("could not parse cron expression: %s; %w", expression, err)
+		}
+		return nil
+
+	case intervalExpression:
+		return nil
+	}
+
+	fields := nativeFields
+	if !options.seconds {
+		fields = fields[1:]
+	}
+
+	for i, bounds := range fields {
+		if bounds.kind == year {
+			bounds.min, bounds.max = options.yearBounds(bounds.min, bounds.max)
+		}
+		field := Field{Kind: bounds.kind, Term: prepared.terms[i], Min: bounds.min, Max: bounds.max}
+		if err := field.check(options); err != nil {
+			return locateTerm(err, schedule, i, prepared.offset)
+		}
+	}
+
+	return nil
+}
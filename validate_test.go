@@ -0,0 +1,76 @@
+package avail
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	holidays := NewStaticHolidays(time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC))
+
+	tests := map[string]struct {
+		expression string
+		opts       []Option
+	}{
+		"wildcard":             {"* * * * * *", nil},
+		"values":               {"0 9 1,15 JAN-MAR MON-FRI 2020-2030", nil},
+		"relative":             {"0 0 L-3 * * *", nil},
+		"hashed":               {"H H * * * *", []Option{WithHashKey("backup")}},
+		"macro":                {"@daily", nil},
+		"interval":             {"@every 1h30m", nil},
+		"location":             {"CRON_TZ=America/New_York 0 9 * * * *", nil},
+		"alternatives":         {"0 9 * * 1-5 * || 0 10 * * 6,0 *", nil},
+		"seconds":              {"30 0 9 * * * *", []Option{WithSeconds()}},
+		"optional year":        {"0 9 * * *", []Option{WithOptionalYear()}},
+		"quartz":               {"0 0 9 ? * MON-FRI", []Option{WithDialect(Quartz)}},
+		"too few terms":        {"0 9 * *", nil},
+		"value above max":      {"60 * * * * *", nil},
+		"value below min":      {"* * 0 * * *", nil},
+		"span out of order":    {"* 9-5 * * * *", nil},
+		"span above max":       {"* * * * * 2020-2200", nil},
+		"list above max":       {"* * * 1,13 * *", nil},
+		"unknown name":         {"* * * * FOO *", nil},
+		"unknown term":         {"*/5 * * * * *", nil},
+		"last outside day":     {"* * * * L *", nil},
+		"hash without key":     {"H * * * * *", nil},
+		"invalid zone":         {"CRON_TZ=Nowhere/Special 0 9 * * * *", nil},
+		"invalid interval":     {"@every soon", nil},
+		"empty alternative":    {"0 9 * * * * ||", nil},
+		"invalid alternative":  {"0 9 * * * * || 0 25 * * * *", nil},
+		"invalid quartz":       {"0 0 9 * * MON-FRI", []Option{WithDialect(Quartz)}},
+		"invalid seconds":      {"60 0 9 * * * *", []Option{WithSeconds()}},
+		"invalid optionalYear": {"0 24 * * *", []Option{WithOptionalYear()}},
+		"holidays excluded":    {"0 9 * * * *", []Option{WithHolidaysExcluded(holidays)}},
+		"holidays only":        {"0 9 * * * *", []Option{WithHolidaysOnly(holidays)}},
+		"interval excluding holidays": {
+			"@every 1h", []Option{WithHolidaysExcluded(holidays)},
+		},
+		"interval only on holidays": {"@every 1h", []Option{WithHolidaysOnly(holidays)}},
+		"interval alternative with holidays": {
+			"0 9 * * * * || @every 1h", []Option{WithHolidaysExcluded(holidays)},
+		},
+		"second granularity interval": {"@every 30s", []Option{WithGranularity(time.Second)}},
+		"minute granularity interval": {"@every 30s", nil},
+		"invalid granularity":         {"0 9 * * * *", []Option{WithGranularity(time.Hour)}},
+		"impossible":                  {"0 9 31 2 * *", []Option{WithRejectImpossible()}},
+		"possible":                    {"0 9 29 2 * *", []Option{WithRejectImpossible()}},
+		"invalid year range":          {"0 9 * * * *", []Option{WithYearRange(2030, 2020)}},
+		"within year range":           {"0 9 * * * 2025", []Option{WithYearRange(2020, 2030)}},
+		"outside year range":          {"0 9 * * * 2035", []Option{WithYearRange(2020, 2030)}},
+		"quartz interval":             {"@every 1h", []Option{WithDialect(Quartz)}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, want := New(tc.expression, tc.opts...)
+			got := Validate(tc.expression, tc.opts...)
+
+			if (want == nil) != (got == nil) {
+				t.Fatalf("want %v, got %v", want, got)
+			}
+			if want != nil && want.Error() != got.Error() {
+				t.Errorf("want %s, got %s", want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package avail
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// waitRecheck is the longest Wait sleeps, as a time.Duration, before checking the clock again,
+// so that changes to the wall clock during long sleeps are noticed; it is shortened in tests.
+// It is accessed atomically since goroutines from earlier tests may still be waiting.
+var waitRecheck = int64(time.Minute)
+
+// Wait blocks until the Timeframe's next occurrence after the current time, as given by the
+// clock set with WithClock and returned by Next, or returns the context's error if it is done
+// first. ErrNoOccurrence is returned if there is no such occurrence. Ex. a worker looping over
+// Wait with "0 2 * * * *" does its work once a day at 2:00.
+//
+// Rather than sleeping until the occurrence in one go, Wait sleeps at most a minute at a time
+// and checks the clock again, so that it wakes at the right time even if the wall clock is
+// changed while it sleeps. It returns as soon as the clock reaches the occurrence, including
+// when the clock is moved past it.
+func (a *Timeframe) Wait(ctx context.Context) error {
+	next, err := a.Next(a.Now())
+	if err != nil {
+		return err
+	}
+
+	return a.waitUntil(ctx, next)
+}
+
+// waitUntil blocks until the Timeframe's clock reaches the time given, checking the clock again
+// at least every waitRecheck, or returns the context's error if it is done first.
+func (a *Timeframe) waitUntil(ctx context.Context, t time.Time) error {
+	for {
+		remaining := t.Sub(a.Now())
+		if remaining <= 0 {
+			return nil
+		}
+		if recheck := time.Duration(atomic.LoadInt64(&waitRecheck)); remaining > recheck {
+			remaining = recheck
+		}
+
+		timer := time.NewTimer(remaining)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// waitOccurrence blocks until the Timeframe's clock reaches the occurrence given, as waitUntil
+// does, and reports whether it is still current. Occurrences the clock passed by more than the
+// Timeframe's precision before it could be noticed, such as while the process was suspended,
+// are stale.
+func (a *Timeframe) waitOccurrence(ctx context.Context, occurrence time.Time) (bool, error) {
+	if err := a.waitUntil(ctx, occurrence); err != nil {
+		return false, err
+	}
+	return !occurrence.Before(a.Now().Add(-a.options.precision())), nil
+}
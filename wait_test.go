@@ -0,0 +1,126 @@
+package avail
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWait(t *testing.T) {
+	timeframe, err := New("* * * * * *", WithGranularity(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	err = timeframe.Wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := start.Truncate(time.Second).Add(time.Second)
+	if time.Now().Before(want) {
+		t.Errorf("want wait until %s, returned at %s", want, time.Now())
+	}
+}
+
+func TestWaitCancelled(t *testing.T) {
+	timeframe, err := New("0 9 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = timeframe.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("want %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestWaitNoOccurrence(t *testing.T) {
+	now := time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC)
+	timeframe, err := New("0 9 * * * 2019", WithClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = timeframe.Wait(context.Background())
+	if !errors.Is(err, ErrNoOccurrence) {
+		t.Errorf("want %v, got %v", ErrNoOccurrence, err)
+	}
+}
+
+func TestWaitClockChange(t *testing.T) {
+	shortenWaitRecheck(t, 10*time.Millisecond)
+
+	var mu sync.Mutex
+	now := time.Date(2020, 6, 8, 8, 0, 0, 0, time.UTC)
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+
+	timeframe, err := New("0 9 * * * *", WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The occurrence is an hour away, so Wait only returns in time if it notices the clock
+	// being moved forward.
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		mu.Lock()
+		now = time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC)
+		mu.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = timeframe.Wait(ctx)
+	if err != nil {
+		t.Errorf("want wait to return once the clock reaches the occurrence, got %v", err)
+	}
+}
+
+func TestWaitOccurrenceStale(t *testing.T) {
+	now := time.Date(2020, 6, 8, 9, 30, 0, 0, time.UTC)
+	timeframe, err := New("* * * * * *", WithClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		occurrence time.Time
+		want       bool
+	}{
+		"passed long ago":         {time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), false},
+		"passed within precision": {time.Date(2020, 6, 8, 9, 29, 30, 0, time.UTC), true},
+		"now":                     {now, true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := timeframe.waitOccurrence(context.Background(), tc.occurrence)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+// shortenWaitRecheck shortens how long Wait sleeps before checking the clock again for the
+// duration of the test, so that tests can move a fake clock.
+func shortenWaitRecheck(t *testing.T, recheck time.Duration) {
+	previous := atomic.SwapInt64(&waitRecheck, int64(recheck))
+	t.Cleanup(func() { atomic.StoreInt64(&waitRecheck, previous) })
+}
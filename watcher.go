@@ -0,0 +1,84 @@
+package avail
+
+import (
+	"context"
+	"time"
+)
+
+// Transition is a time at which a Timeframe becomes able, or stops being able.
+type Transition struct {
+	Time time.Time
+	// Able is true if the Timeframe becomes able at the time, and false if it stops being able.
+	Able bool
+}
+
+// Watch calls fn at each transition of the Timeframe after the current time, as given by the
+// clock set with WithClock, until the context is done. It is intended for systems which care
+// about the edges of a Timeframe rather than every time it is able, such as feature flags or
+// maintenance modes. Ex. "* 2-4 * * 6 *" calls fn with a transition to able at 2:00 every
+// Saturday and to not able at 5:00.
+//
+// Transitions are found from the Timeframe's windows, as returned by NextWindow, so they
+// alternate between able and not able. Only edges are reported: whether the Timeframe is able
+// when watching starts can be found with Able. Transitions are waited for as Wait does, and fn
+// is called from the goroutine calling Watch, so a slow fn delays later transitions rather
+// than dropping them. Watch returns the context's error once it is done, or ErrNoOccurrence
+// once the Timeframe has no further transitions.
+func (a *Timeframe) Watch(ctx context.Context, fn func(Transition)) error {
+	current := a.Now()
+	for {
+		window, ok := a.NextWindow(current)
+		if !ok {
+			return ErrNoOccurrence
+		}
+
+		if window.Start.After(current) {
+			if err := a.waitUntil(ctx, window.Start); err != nil {
+				return err
+			}
+			fn(Transition{Time: window.Start, Able: true})
+		}
+
+		if err := a.waitUntil(ctx, window.End); err != nil {
+			return err
+		}
+		fn(Transition{Time: window.End, Able: false})
+
+		current = window.End
+	}
+}
+
+// Watcher delivers each transition of a Timeframe on a channel, as Watch finds them.
+type Watcher struct {
+	// C delivers each transition. It is closed once the Watcher is stopped or the Timeframe has
+	// no further transitions.
+	C <-chan Transition
+
+	stop func()
+}
+
+// NewWatcher returns a Watcher delivering the transitions of the Timeframe after the current
+// time. Transitions are never dropped: if the receiver is slow they are delivered late, in
+// order. It must be stopped to release its resources once no longer needed.
+func NewWatcher(timeframe Timeframe) *Watcher {
+	transitions := make(chan Transition)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		defer close(transitions)
+		_ = timeframe.Watch(ctx, func(transition Transition) {
+			select {
+			case transitions <- transition:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	return &Watcher{C: transitions, stop: cancel}
+}
+
+// Stop stops the Watcher, closing C once any transition being delivered has been given up. It
+// may be called more than once.
+func (w *Watcher) Stop() {
+	w.stop()
+}
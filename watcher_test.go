@@ -0,0 +1,183 @@
+package avail
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWatch(t *testing.T) {
+	shortenWaitRecheck(t, time.Millisecond)
+
+	var mu sync.Mutex
+	now := time.Date(2020, 6, 8, 8, 30, 0, 0, time.UTC)
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	set := func(t time.Time) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = t
+	}
+
+	timeframe, err := New("* 9 * * * *", WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The clock is moved to the first edge once watching has started, and to each later edge
+	// once the transition before it has been seen.
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		set(time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC))
+	}()
+
+	got := []Transition{}
+	err = timeframe.Watch(ctx, func(transition Transition) {
+		got = append(got, transition)
+		switch len(got) {
+		case 1:
+			set(time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC))
+		case 2:
+			cancel()
+		}
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("want %v, got %v", context.Canceled, err)
+	}
+
+	want := []Transition{
+		{Time: time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), Able: true},
+		{Time: time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC), Able: false},
+	}
+	diff := cmp.Diff(want, got)
+	if diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+}
+
+func TestWatchInterval(t *testing.T) {
+	shortenWaitRecheck(t, time.Millisecond)
+
+	var mu sync.Mutex
+	now := time.Date(2020, 6, 8, 8, 30, 0, 0, time.UTC)
+	clock := func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	set := func(t time.Time) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = t
+	}
+
+	timeframe, err := New("@every 2h", WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		set(time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC))
+	}()
+
+	// Each occurrence of the interval is a window a minute long.
+	got := []Transition{}
+	err = timeframe.Watch(ctx, func(transition Transition) {
+		got = append(got, transition)
+		switch len(got) {
+		case 1:
+			set(time.Date(2020, 6, 8, 10, 1, 0, 0, time.UTC))
+		case 2:
+			cancel()
+		}
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("want %v, got %v", context.Canceled, err)
+	}
+
+	want := []Transition{
+		{Time: time.Date(2020, 6, 8, 10, 0, 0, 0, time.UTC), Able: true},
+		{Time: time.Date(2020, 6, 8, 10, 1, 0, 0, time.UTC), Able: false},
+	}
+	diff := cmp.Diff(want, got)
+	if diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+}
+
+func TestWatchNoOccurrence(t *testing.T) {
+	now := time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC)
+	timeframe, err := New("0 9 * * * 2019", WithClock(func() time.Time { return now }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = timeframe.Watch(context.Background(), func(Transition) {})
+	if !errors.Is(err, ErrNoOccurrence) {
+		t.Errorf("want %v, got %v", ErrNoOccurrence, err)
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	// Able on even seconds, so that a transition happens every second.
+	seconds := []string{}
+	for second := 0; second < 60; second += 2 {
+		seconds = append(seconds, strconv.Itoa(second))
+	}
+
+	timeframe, err := New(strings.Join(seconds, ",")+" * * * * * *", WithSeconds())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	watcher := NewWatcher(timeframe)
+
+	var previous *Transition
+	for i := 0; i < 3; i++ {
+		select {
+		case transition := <-watcher.C:
+			if transition.Time.Nanosecond() != 0 {
+				t.Errorf("want transition at the start of a second, got %s", transition.Time)
+			}
+			if transition.Able != (transition.Time.Second()%2 == 0) {
+				t.Errorf("want able %t at %s, got %t", !transition.Able, transition.Time, transition.Able)
+			}
+			if previous != nil && previous.Able == transition.Able {
+				t.Errorf("want transitions to alternate, got able %t twice", transition.Able)
+			}
+			previous = &transition
+		case <-time.After(5 * time.Second):
+			t.Fatal("want transition, got none")
+		}
+	}
+
+	watcher.Stop()
+	watcher.Stop()
+
+	select {
+	case _, ok := <-watcher.C:
+		if ok {
+			t.Errorf("want channel closed after stopping, got transition")
+		}
+	case <-time.After(time.Second):
+		t.Error("want channel closed after stopping, got open")
+	}
+}
@@ -0,0 +1,318 @@
+package avail
+
+import "time"
+
+// Window is a span of time during which a Timeframe is continuously able. Start is inclusive
+// and End is exclusive.
+type Window struct {
+	Start, End time.Time
+}
+
+// Duration returns the length of the window.
+func (w Window) Duration() time.Duration {
+	return w.End.Sub(w.Start)
+}
+
+// Windows returns, in chronological order, the spans of time within [start, end) during which
+// the Timeframe is able, with consecutive matching minutes (or seconds, for expressions with
+// seconds) coalesced. Ex. "* 9-17 * * 1-5 *" yields one window from 9:00 to 18:00 per weekday.
+// Windows which extend past either end of the range are cut short at it.
+func (a *Timeframe) Windows(start, end time.Time) []Window {
+	windows := []Window{}
+	start, end = a.options.clampActive(start, end)
+	if !start.Before(end) {
+		return windows
+	}
+
+	location := start.Location()
+	if a.Location != nil {
+		location = a.Location
+	}
+	precision := a.options.precision()
+
+	var open *Window
+
+	// Occurrences strictly after the instant before start include one at start itself.
+	current := start.Add(-time.Nanosecond)
+	if a.Able(start) {
+		aligned := start.Truncate(precision)
+		open = &Window{Start: start.In(location), End: aligned.Add(precision).In(location)}
+		current = aligned
+	}
+
+	for {
+		next, err := a.nextFrom(current, location)
+		if err != nil || !next.Before(end) {
+			break
+		}
+
+		if open != nil && next.Equal(open.End) {
+			open.End = next.Add(precision)
+		} else {
+			if open != nil {
+				windows = append(windows, *open)
+			}
+			open = &Window{Start: next, End: next.Add(precision)}
+		}
+		current = next
+	}
+
+	if open != nil {
+		if open.End.After(end) {
+			open.End = end.In(location)
+		}
+		windows = append(windows, *open)
+	}
+
+	return windows
+}
+
+// NextWindow returns the next span of time during which the Timeframe is continuously able.
+// If the Timeframe is able at the time given the window in progress is returned, starting at
+// that time. The second return value is false if the Timeframe will never be able again.
+func (a *Timeframe) NextWindow(after time.Time) (Window, bool) {
+	location := after.Location()
+	if a.Location != nil {
+		location = a.Location
+	}
+	precision := a.options.precision()
+
+	if a.Able(after) {
+		aligned := after.Truncate(precision)
+		return Window{Start: after.In(location), End: a.windowEnd(aligned, location)}, true
+	}
+
+	next, err := a.nextFrom(after, location)
+	if err != nil {
+		return Window{}, false
+	}
+
+	return Window{Start: next, End: a.windowEnd(next, location)}, true
+}
+
+// windowEnd returns the end of the window containing the time given, which must be one at
+// which the Timeframe is able. Rather than checking every minute it skips the hours, days,
+// months and years the expression is sure to match throughout, and it stops at the end of the
+// expression's years or of the period given with WithActiveBetween.
+func (a *Timeframe) windowEnd(able time.Time, location *time.Location) time.Time {
+	if a.Location != nil {
+		location = a.Location
+	}
+
+	end := a.matchEnd(able.Truncate(a.options.precision()), location)
+
+	// The window is cut short by the first exclusion to become able within it.
+	for i := range a.exclusions {
+		next, err := a.exclusions[i].nextFrom(able, location)
+		if err == nil && next.Before(end) {
+			end = next
+		}
+	}
+
+	if !a.options.activeEnd.IsZero() && end.After(a.options.activeEnd) {
+		end = a.options.activeEnd
+	}
+	return end.In(location)
+}
+
+// windowStart returns the start of the window containing the time given, which must be one at
+// which the Timeframe is able. It searches back in the same way as windowEnd searches forward.
+func (a *Timeframe) windowStart(able time.Time, location *time.Location) time.Time {
+	if a.Location != nil {
+		location = a.Location
+	}
+
+	start := a.matchStart(able.Truncate(a.options.precision()), location)
+
+	// The window starts no earlier than the last exclusion to be able before it stops being so.
+	for i := range a.exclusions {
+		exclusion := &a.exclusions[i]
+		prev, err := exclusion.prevFrom(able, location)
+		if err != nil {
+			continue
+		}
+		if end := prev.Add(exclusion.options.precision()); end.After(start) {
+			start = end
+		}
+	}
+
+	if start.Before(a.options.activeStart) {
+		start = a.options.activeStart
+	}
+	return start.In(location)
+}
+
+// matchEnd returns the first time after the time given, which must be one at which the
+// expression matches, at which it no longer does, without considering exclusions.
+func (a *Timeframe) matchEnd(t time.Time, location *time.Location) time.Time {
+	precision := a.options.precision()
+	t = t.In(location)
+
+	if a.alternatives != nil {
+		// The window lasts until no alternative is able, so it is extended by each alternative
+		// which is able where the windows of the others end.
+		for {
+			end := t
+			for i := range a.alternatives {
+				if !a.alternatives[i].Able(t) {
+					continue
+				}
+				if alternativeEnd := a.alternatives[i].windowEnd(t, location); alternativeEnd.After(end) {
+					end = alternativeEnd
+				}
+			}
+			if !end.After(t) {
+				return t
+			}
+			t = end
+		}
+	}
+
+	if a.Interval != 0 {
+		// An interval as short as the precision is always able.
+		if a.Interval == precision {
+			return a.yearsEnd(location)
+		}
+		return t.Add(precision)
+	}
+
+	limit := a.yearsEnd(location)
+	for t.Before(limit) {
+		if !a.matches(t) {
+			return t
+		}
+		_, t = a.matchedUnit(t, location)
+	}
+	return limit
+}
+
+// matchStart returns the earliest time before the time given, which must be one at which the
+// expression matches, from which it has matched throughout, without considering exclusions.
+func (a *Timeframe) matchStart(t time.Time, location *time.Location) time.Time {
+	precision := a.options.precision()
+	t = t.In(location)
+
+	if a.alternatives != nil {
+		for {
+			start := t
+			previous := t.Add(-precision)
+			for i := range a.alternatives {
+				if !a.alternatives[i].Able(previous) {
+					continue
+				}
+				if alternativeStart := a.alternatives[i].windowStart(previous, location); alternativeStart.Before(start) {
+					start = alternativeStart
+				}
+			}
+			if !start.Before(t) {
+				return t
+			}
+			t = start
+		}
+	}
+
+	if a.Interval != 0 {
+		if a.Interval == precision {
+			return a.yearsStart(location)
+		}
+		return t
+	}
+
+	limit := a.yearsStart(location)
+	for t.After(limit) {
+		previous := t.Add(-precision)
+		if !a.matches(previous) {
+			return t
+		}
+		t, _ = a.matchedUnit(previous, location)
+	}
+	return limit
+}
+
+// matchedUnit returns the start of the largest unit of time containing the time given, which
+// must be one at which the plain expression matches, throughout which it matches, and the
+// start of the unit after it. Units are a second, a minute, an hour, a day, a month or a year,
+// and each is matched throughout when the fields finer than it are wildcards.
+func (a *Timeframe) matchedUnit(t time.Time, location *time.Location) (time.Time, time.Time) {
+	parsed := &a.ParsedExpression
+	precision := a.options.precision()
+	t = t.In(location)
+
+	start := t.Truncate(precision)
+	next := start.Add(precision)
+
+	// Times matched only because clocks went forward past a match are matched alone.
+	if a.options.dstPolicy != DSTWallClock && !a.matchesWallClock(t) {
+		return start, next
+	}
+
+	// Holidays and times repeated when clocks go back are not matched throughout a month even
+	// when its days are wildcards.
+	wildcards := []bool{
+		!a.options.seconds || parsed.Seconds.isWildcard(),
+		parsed.Minutes.isWildcard(),
+		parsed.Hours.isWildcard(),
+		parsed.Days.isWildcard() && parsed.Weekdays.isWildcard() && a.options.holidays == nil &&
+			a.options.dstPolicy != DSTFirst,
+		parsed.Months.isWildcard(),
+	}
+
+	for unit, wildcard := range wildcards {
+		if !wildcard {
+			break
+		}
+
+		var wider, widerNext time.Time
+		switch unit {
+		case 0:
+			wider = t.Add(-time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+			widerNext = wider.Add(time.Minute)
+		case 1:
+			wider = t.Add(-time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second -
+				time.Duration(t.Nanosecond()))
+			widerNext = wider.Add(time.Hour)
+		case 2:
+			wider, widerNext = dayStart(t, location), nextDayStart(t, location)
+		case 3:
+			wider = startOfDate(time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC), location)
+			widerNext = startOfDate(time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC), location)
+		case 4:
+			wider = startOfDate(time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC), location)
+			widerNext = startOfDate(time.Date(t.Year()+1, time.January, 1, 0, 0, 0, 0, time.UTC), location)
+		}
+
+		// Only times which are repeated are not matched with DSTFirst, so a unit over which
+		// clocks change is not matched throughout.
+		if a.options.dstPolicy == DSTFirst {
+			_, startOffset := wider.Zone()
+			_, endOffset := widerNext.Add(-time.Nanosecond).Zone()
+			if startOffset != endOffset {
+				break
+			}
+		}
+
+		start, next = wider, widerNext
+	}
+
+	return start, next
+}
+
+// yearsStart returns the start of the first year the Timeframe's expression can match in.
+// Intervals are bounded by the same years as native expressions.
+func (a *Timeframe) yearsStart(location *time.Location) time.Time {
+	min, _ := a.options.yearBounds(1970, 2100)
+	if a.Interval == 0 {
+		min = a.ParsedExpression.Years.Min
+	}
+	return startOfDate(time.Date(min, time.January, 1, 0, 0, 0, 0, time.UTC), location)
+}
+
+// yearsEnd returns the start of the year after the last one the Timeframe's expression can
+// match in.
+func (a *Timeframe) yearsEnd(location *time.Location) time.Time {
+	_, max := a.options.yearBounds(1970, 2100)
+	if a.Interval == 0 {
+		max = a.ParsedExpression.Years.Max
+	}
+	return startOfDate(time.Date(max+1, time.January, 1, 0, 0, 0, 0, time.UTC), location)
+}
@@ -0,0 +1,177 @@
+package avail
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWindows(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		start      time.Time
+		end        time.Time
+		want       []Window
+	}{
+		"business hours": {"* 9-17 * * 1-5 *", time.Date(2020, 6, 5, 0, 0, 0, 0, time.UTC), time.Date(2020, 6, 9, 0, 0, 0, 0, time.UTC), []Window{
+			{time.Date(2020, 6, 5, 9, 0, 0, 0, time.UTC), time.Date(2020, 6, 5, 18, 0, 0, 0, time.UTC)},
+			{time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), time.Date(2020, 6, 8, 18, 0, 0, 0, time.UTC)},
+		}},
+		"across midnight": {"* 22,23,0,1 * * * *", time.Date(2020, 6, 5, 12, 0, 0, 0, time.UTC), time.Date(2020, 6, 6, 12, 0, 0, 0, time.UTC), []Window{
+			{time.Date(2020, 6, 5, 22, 0, 0, 0, time.UTC), time.Date(2020, 6, 6, 2, 0, 0, 0, time.UTC)},
+		}},
+		"single minutes": {"0,30 9 * * * *", time.Date(2020, 6, 5, 0, 0, 0, 0, time.UTC), time.Date(2020, 6, 6, 0, 0, 0, 0, time.UTC), []Window{
+			{time.Date(2020, 6, 5, 9, 0, 0, 0, time.UTC), time.Date(2020, 6, 5, 9, 1, 0, 0, time.UTC)},
+			{time.Date(2020, 6, 5, 9, 30, 0, 0, time.UTC), time.Date(2020, 6, 5, 9, 31, 0, 0, time.UTC)},
+		}},
+		"clipped": {"* 9-17 * * * *", time.Date(2020, 6, 5, 12, 0, 30, 0, time.UTC), time.Date(2020, 6, 5, 13, 15, 0, 0, time.UTC), []Window{
+			{time.Date(2020, 6, 5, 12, 0, 30, 0, time.UTC), time.Date(2020, 6, 5, 13, 15, 0, 0, time.UTC)},
+		}},
+		"none": {"* 9-17 * * * *", time.Date(2020, 6, 5, 18, 0, 0, 0, time.UTC), time.Date(2020, 6, 5, 20, 0, 0, 0, time.UTC), []Window{}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			diff := cmp.Diff(tc.want, avail.Windows(tc.start, tc.end))
+			if diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNextWindow(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		after      time.Time
+		want       Window
+		ok         bool
+	}{
+		"upcoming": {"* 9-17 * * 1-5 *", time.Date(2020, 6, 6, 12, 0, 0, 0, time.UTC),
+			Window{time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), time.Date(2020, 6, 8, 18, 0, 0, 0, time.UTC)}, true},
+		"in progress": {"* 9-17 * * 1-5 *", time.Date(2020, 6, 8, 12, 30, 0, 0, time.UTC),
+			Window{time.Date(2020, 6, 8, 12, 30, 0, 0, time.UTC), time.Date(2020, 6, 8, 18, 0, 0, 0, time.UTC)}, true},
+		"whole days": {"* * * * 0,6 *", time.Date(2020, 6, 5, 12, 0, 0, 0, time.UTC),
+			Window{time.Date(2020, 6, 6, 0, 0, 0, 0, time.UTC), time.Date(2020, 6, 8, 0, 0, 0, 0, time.UTC)}, true},
+		"until the end of the years": {"* * * * * 2100", time.Date(2100, 12, 30, 0, 0, 0, 0, time.UTC),
+			Window{time.Date(2100, 12, 30, 0, 0, 0, 0, time.UTC), time.Date(2101, 1, 1, 0, 0, 0, 0, time.UTC)}, true},
+		"never": {"0 9 30 2 * *", time.Date(2020, 6, 5, 12, 0, 0, 0, time.UTC), Window{}, false},
+		"interval": {"@every 2h", time.Date(2020, 6, 5, 11, 30, 0, 0, time.UTC),
+			Window{time.Date(2020, 6, 5, 12, 0, 0, 0, time.UTC), time.Date(2020, 6, 5, 12, 1, 0, 0, time.UTC)}, true},
+		"interval always able": {"@every 1m", time.Date(2020, 6, 5, 12, 0, 0, 0, time.UTC),
+			Window{time.Date(2020, 6, 5, 12, 0, 0, 0, time.UTC), time.Date(2101, 1, 1, 0, 0, 0, 0, time.UTC)}, true},
+		"overlapping alternatives": {"* 9-10 * * * * || * 10-11 * * * *", time.Date(2020, 6, 5, 9, 30, 0, 0, time.UTC),
+			Window{time.Date(2020, 6, 5, 9, 30, 0, 0, time.UTC), time.Date(2020, 6, 5, 12, 0, 0, 0, time.UTC)}, true},
+		"alternative always able": {"* * * * * * || 0 0 1 1 * *", time.Date(2020, 6, 5, 12, 0, 0, 0, time.UTC),
+			Window{time.Date(2020, 6, 5, 12, 0, 0, 0, time.UTC), time.Date(2101, 1, 1, 0, 0, 0, 0, time.UTC)}, true},
+		"across clocks going back": {"CRON_TZ=America/New_York * 0-3 1 11 * 2020", time.Date(2020, 11, 1, 4, 30, 0, 0, time.UTC),
+			Window{time.Date(2020, 11, 1, 4, 30, 0, 0, time.UTC), time.Date(2020, 11, 1, 9, 0, 0, 0, time.UTC)}, true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			avail, err := New(tc.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, ok := avail.NextWindow(tc.after)
+			if ok != tc.ok {
+				t.Fatalf("want %t, got %t", tc.ok, ok)
+			}
+
+			diff := cmp.Diff(tc.want, got)
+			if diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWindowsExcept(t *testing.T) {
+	base, err := New("* * * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backup, err := New("* 2-3 * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	avail := base.Except(backup)
+
+	got := avail.Windows(time.Date(2020, 6, 5, 0, 0, 0, 0, time.UTC), time.Date(2020, 6, 6, 12, 0, 0, 0, time.UTC))
+	want := []Window{
+		{time.Date(2020, 6, 5, 0, 0, 0, 0, time.UTC), time.Date(2020, 6, 5, 2, 0, 0, 0, time.UTC)},
+		{time.Date(2020, 6, 5, 4, 0, 0, 0, time.UTC), time.Date(2020, 6, 6, 2, 0, 0, 0, time.UTC)},
+		{time.Date(2020, 6, 6, 4, 0, 0, 0, time.UTC), time.Date(2020, 6, 6, 12, 0, 0, 0, time.UTC)},
+	}
+
+	diff := cmp.Diff(want, got)
+	if diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+
+	window, ok := avail.NextWindow(time.Date(2020, 6, 5, 2, 30, 0, 0, time.UTC))
+	if !ok {
+		t.Fatalf("want %t, got %t", true, ok)
+	}
+
+	wantWindow := Window{time.Date(2020, 6, 5, 4, 0, 0, 0, time.UTC), time.Date(2020, 6, 6, 2, 0, 0, 0, time.UTC)}
+	diff = cmp.Diff(wantWindow, window)
+	if diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+}
+
+func TestNextWindowExcept(t *testing.T) {
+	christmas, err := New("* * 25 12 * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	avail := MustNew("* * * * * *").Except(christmas)
+
+	got, ok := avail.NextWindow(time.Date(2020, 6, 5, 12, 0, 0, 0, time.UTC))
+	if !ok {
+		t.Fatalf("want %t, got %t", true, ok)
+	}
+
+	want := Window{time.Date(2020, 6, 5, 12, 0, 0, 0, time.UTC), time.Date(2020, 12, 25, 0, 0, 0, 0, time.UTC)}
+	diff := cmp.Diff(want, got)
+	if diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+
+	start := avail.windowStart(time.Date(2021, 6, 5, 12, 0, 0, 0, time.UTC), time.UTC)
+	wantStart := time.Date(2020, 12, 26, 0, 0, 0, 0, time.UTC)
+	if !start.Equal(wantStart) {
+		t.Errorf("want %s, got %s", wantStart, start)
+	}
+}
+
+func TestNextWindowDSTFirst(t *testing.T) {
+	avail, err := New("CRON_TZ=America/New_York * * * * * *", WithDSTPolicy(DSTFirst))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The hour repeated when clocks go back is not able the second time, ending the window.
+	got, ok := avail.NextWindow(time.Date(2020, 6, 5, 12, 0, 0, 0, time.UTC))
+	if !ok {
+		t.Fatalf("want %t, got %t", true, ok)
+	}
+
+	want := Window{time.Date(2020, 6, 5, 12, 0, 0, 0, time.UTC), time.Date(2020, 11, 1, 6, 0, 0, 0, time.UTC)}
+	diff := cmp.Diff(want, got)
+	if diff != "" {
+		t.Errorf("result is different than expected(-want +got):\n%s", diff)
+	}
+}
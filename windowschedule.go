@@ -0,0 +1,95 @@
+package avail
+
+import (
+	"fmt"
+	"time"
+)
+
+// WindowSchedule is a schedule of windows which open each time a trigger Timeframe occurs and
+// stay open for a fixed duration. Ex. a trigger of "0 2 * * * *" lasting 3h is able from 2:00
+// to 5:00 every day. It saves writing the end of each window as a second expression, which
+// breaks when windows cross midnight or a daylight saving time transition.
+//
+// Windows open at the occurrences of the trigger, as returned by its Next, and their duration
+// is elapsed time, so a 3h window opening at 1:00 on the day clocks go forward closes at 5:00.
+// Windows which overlap or touch are treated as one.
+type WindowSchedule struct {
+	Trigger  Timeframe
+	Duration time.Duration
+}
+
+// NewWindowSchedule parses the trigger expression with New and returns a WindowSchedule whose
+// windows last for the duration given, which must be positive.
+func NewWindowSchedule(expression string, duration time.Duration, opts ...Option) (WindowSchedule, error) {
+	if duration <= 0 {
+		return WindowSchedule{}, fmt.Errorf("window duration(%s) must be positive", duration)
+	}
+
+	trigger, err := New(expression, opts...)
+	if err != nil {
+		return WindowSchedule{}, err
+	}
+
+	return WindowSchedule{Trigger: trigger, Duration: duration}, nil
+}
+
+// Able reports whether a window is open at the time given, which is the case when the trigger
+// occurred within the duration before it.
+func (w *WindowSchedule) Able(t time.Time) bool {
+	_, ok := w.openedBy(t)
+	return ok
+}
+
+// NextWindow returns the next window of the schedule. If a window is open at the time given
+// the window in progress is returned, starting at the earliest occurrence of the trigger whose
+// window includes that time. The second return value is false if no window opens again.
+func (w *WindowSchedule) NextWindow(after time.Time) (Window, bool) {
+	start, ok := w.openedBy(after)
+	if !ok {
+		next, err := w.Trigger.Next(after)
+		if err != nil {
+			return Window{}, false
+		}
+		start = next
+	}
+
+	return Window{Start: start, End: w.windowEnd(start)}, true
+}
+
+// openedBy returns the earliest occurrence of the trigger whose window includes the time
+// given. It returns false if no window is open at that time.
+func (w *WindowSchedule) openedBy(t time.Time) (time.Time, bool) {
+	start, err := w.Trigger.Next(t.Add(-w.Duration))
+	if err != nil || start.After(t) {
+		return time.Time{}, false
+	}
+	return start, true
+}
+
+// windowEnd returns the end of the window opened at the occurrence of the trigger given,
+// extended by the windows of later occurrences which open before it closes. Consecutive
+// occurrences of the trigger are taken a run at a time, so triggers which occur every minute
+// are cheap to cross.
+func (w *WindowSchedule) windowEnd(start time.Time) time.Time {
+	end := start.Add(w.Duration)
+
+	// Windows shorter than the trigger's precision close before its next occurrence.
+	precision := w.Trigger.options.precision()
+	if w.Duration < precision {
+		return end
+	}
+
+	for cursor := start; ; {
+		run, ok := w.Trigger.NextWindow(cursor)
+		if !ok || run.Start.After(end) {
+			return end
+		}
+
+		// Every occurrence within the run is a precision apart, so their windows are continuous
+		// and the last of them closes latest.
+		if last := run.End.Add(-precision).Add(w.Duration); last.After(end) {
+			end = last
+		}
+		cursor = run.End
+	}
+}
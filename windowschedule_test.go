@@ -0,0 +1,120 @@
+package avail
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWindowScheduleAble(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		expression string
+		duration   time.Duration
+		time       time.Time
+		want       bool
+	}{
+		"at start":       {"0 2 * * * *", 3 * time.Hour, time.Date(2020, 6, 8, 2, 0, 0, 0, time.UTC), true},
+		"before close":   {"0 2 * * * *", 3 * time.Hour, time.Date(2020, 6, 8, 4, 59, 59, 0, time.UTC), true},
+		"at close":       {"0 2 * * * *", 3 * time.Hour, time.Date(2020, 6, 8, 5, 0, 0, 0, time.UTC), false},
+		"before start":   {"0 2 * * * *", 3 * time.Hour, time.Date(2020, 6, 8, 1, 59, 0, 0, time.UTC), false},
+		"past midnight":  {"0 23 * * * *", 3 * time.Hour, time.Date(2020, 6, 9, 1, 30, 0, 0, time.UTC), true},
+		"next day":       {"0 23 * * 1 *", 3 * time.Hour, time.Date(2020, 6, 9, 1, 30, 0, 0, time.UTC), true},
+		"wrong day":      {"0 23 * * 1 *", 3 * time.Hour, time.Date(2020, 6, 8, 1, 30, 0, 0, time.UTC), false},
+		"short":          {"0 2 * * * *", 30 * time.Second, time.Date(2020, 6, 8, 2, 0, 45, 0, time.UTC), false},
+		"elapsed in dst": {"0 1 * * * *", 3 * time.Hour, time.Date(2020, 3, 8, 4, 30, 0, 0, location), true},
+		"closed in dst":  {"0 1 * * * *", 3 * time.Hour, time.Date(2020, 3, 8, 5, 0, 0, 0, location), false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := NewWindowSchedule(tc.expression, tc.duration)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := schedule.Able(tc.time); got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWindowScheduleNextWindow(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		duration   time.Duration
+		after      time.Time
+		want       Window
+	}{
+		"upcoming": {"0 2 * * * *", 3 * time.Hour, time.Date(2020, 6, 8, 12, 0, 0, 0, time.UTC), Window{
+			Start: time.Date(2020, 6, 9, 2, 0, 0, 0, time.UTC), End: time.Date(2020, 6, 9, 5, 0, 0, 0, time.UTC),
+		}},
+		"in progress": {"0 2 * * * *", 3 * time.Hour, time.Date(2020, 6, 8, 3, 0, 0, 0, time.UTC), Window{
+			Start: time.Date(2020, 6, 8, 2, 0, 0, 0, time.UTC), End: time.Date(2020, 6, 8, 5, 0, 0, 0, time.UTC),
+		}},
+		"overlapping": {"0,30 9 * * * *", 45 * time.Minute, time.Date(2020, 6, 8, 9, 40, 0, 0, time.UTC), Window{
+			Start: time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), End: time.Date(2020, 6, 8, 10, 15, 0, 0, time.UTC),
+		}},
+		"touching": {"0 9,10 * * * *", time.Hour, time.Date(2020, 6, 8, 8, 0, 0, 0, time.UTC), Window{
+			Start: time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), End: time.Date(2020, 6, 8, 11, 0, 0, 0, time.UTC),
+		}},
+		"consecutive minutes": {"* 9 * * * *", 30 * time.Minute, time.Date(2020, 6, 8, 8, 0, 0, 0, time.UTC), Window{
+			Start: time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), End: time.Date(2020, 6, 8, 10, 29, 0, 0, time.UTC),
+		}},
+		"shorter than precision": {"* 9 * * * *", 30 * time.Second, time.Date(2020, 6, 8, 8, 0, 0, 0, time.UTC), Window{
+			Start: time.Date(2020, 6, 8, 9, 0, 0, 0, time.UTC), End: time.Date(2020, 6, 8, 9, 0, 30, 0, time.UTC),
+		}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			schedule, err := NewWindowSchedule(tc.expression, tc.duration)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, ok := schedule.NextWindow(tc.after)
+			if !ok {
+				t.Fatal("want a window")
+			}
+
+			diff := cmp.Diff(tc.want, got)
+			if diff != "" {
+				t.Errorf("result is different than expected(-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	never, err := NewWindowSchedule("0 9 * * * 2020", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := never.NextWindow(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)); ok {
+		t.Errorf("want %t, got %t", false, true)
+	}
+}
+
+func TestWindowScheduleInvalid(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		duration   time.Duration
+	}{
+		"zero duration":     {"0 2 * * * *", 0},
+		"negative duration": {"0 2 * * * *", -time.Hour},
+		"bad expression":    {"0 25 * * * *", time.Hour},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewWindowSchedule(tc.expression, tc.duration); err == nil {
+				t.Errorf("window schedule %s lasting %s should not be accepted", tc.expression, tc.duration)
+			}
+		})
+	}
+}